@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 资源客户端的接口，测试中可替换为client/fake
+type Interface interface {
+	Get(ctx context.Context, key resource.ObjectKey, obj interface{}) (*resource.ObjectMeta, error)
+	List(ctx context.Context, apiVersion, kind string, opts ListOptions) (*ListPage, error)
+	Watch(ctx context.Context, apiVersion, kind string, opts ListOptions) (watch.Interface, error)
+	Create(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error)
+	Update(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error)
+	UpdateWithRetry(ctx context.Context, key resource.ObjectKey, obj resource.IResource, mutate func(obj resource.IResource) error) (*resource.ObjectMeta, error)
+	Patch(ctx context.Context, key resource.ObjectKey, pt rest.PatchType, data []byte, obj interface{}) (*resource.ObjectMeta, error)
+	Delete(ctx context.Context, key resource.ObjectKey, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, apiVersion, kind string, opts *resource.DeleteOptions, listOpts ListOptions) error
+	Apply(ctx context.Context, obj resource.IResource, fieldManager string, force bool) (*resource.ObjectMeta, error)
+	GetLogs(ctx context.Context, namespace, name string, opts PodLogOptions) (io.ReadCloser, error)
+	GetScale(ctx context.Context, key resource.ObjectKey) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, key resource.ObjectKey, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error)
+	Evict(ctx context.Context, namespace, name string, opts *resource.DeleteOptions) error
+}
+
+var _ Interface = &Client{}
+
+// 通用的资源客户端，根据资源的apiVersion和kind确定请求路径
+type Client struct {
+	rest   *rest.RESTClient
+	mapper rest.RESTMapper
+}
+
+// mapper为空时使用内置资源的映射
+func NewClient(restClient *rest.RESTClient, mapper rest.RESTMapper) *Client {
+	if mapper == nil {
+		mapper = rest.DefaultRESTMapper
+	}
+	return &Client{rest: restClient, mapper: mapper}
+}
+
+func NewClientForConfig(config *rest.Config) (*Client, error) {
+	restClient, err := rest.NewRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(restClient, nil), nil
+}
+
+func (c *Client) RESTClient() *rest.RESTClient {
+	return c.rest
+}
+
+func (c *Client) RESTMapper() rest.RESTMapper {
+	return c.mapper
+}
+
+// 资源的请求路径，命名空间级资源未指定命名空间时使用default
+func (c *Client) resourcePath(key resource.ObjectKey, withName bool) (string, *rest.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(key.ApiVersion, key.Kind)
+	if err != nil {
+		return "", nil, err
+	}
+	namespace := key.Namespace
+	if mapping.Namespaced && namespace == "" {
+		namespace = "default"
+	}
+	name := ""
+	if withName {
+		if key.Name == "" {
+			return "", nil, errors.New("name is empty")
+		}
+		name = key.Name
+	}
+	return mapping.ResourcePath(namespace, name), mapping, nil
+}
+
+// 服务端apply，由fieldManager声明对所提交字段的所有权，force为true时强制接管冲突的字段
+// 成功后将服务端返回的对象写回obj，并返回其中的metadata
+func (c *Client) Apply(ctx context.Context, obj resource.IResource, fieldManager string, force bool) (*resource.ObjectMeta, error) {
+	if fieldManager == "" {
+		return nil, errors.New("fieldManager is required for apply")
+	}
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	body, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	req.SetQuery("fieldManager", fieldManager)
+	if force {
+		req.SetQuery("force", strconv.FormatBool(force))
+	}
+	data, err := readResponse(req.Patch(rest.ApplyPatchType, body, nil))
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(data, obj)
+}
+
+// 检查响应状态并读取响应体
+func readResponse(resp *http.Response, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// 将响应解码到obj，返回metadata
+// 服务端返回的部分字段与结构体定义不一致时返回*resource.PartialDecodeError，同时返回已解码的metadata
+func decodeObject(data []byte, obj interface{}) (*resource.ObjectMeta, error) {
+	var decodeErr error
+	if obj != nil {
+		if err := resource.DecodeInto(data, obj); err != nil {
+			if !resource.IsPartialDecode(err) {
+				return nil, err
+			}
+			decodeErr = err
+		}
+	}
+	envelope := struct {
+		Metadata resource.ObjectMeta
+	}{}
+	if err := resource.DecodeInto(data, &envelope); err != nil {
+		if !resource.IsPartialDecode(err) {
+			return nil, err
+		}
+		if decodeErr == nil {
+			decodeErr = err
+		}
+	}
+	return &envelope.Metadata, decodeErr
+}
+
+// 创建对象，成功后将服务端返回的对象写回obj
+func (c *Client) Create(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error) {
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	path, _, err := c.resourcePath(key, false)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	data, err := readResponse(req.Post(body, map[string]string{"Content-Type": "application/json"}))
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(data, obj)
+}
+
+// 对key指定的对象执行patch，obj不为空时将结果解码到obj
+func (c *Client) Patch(ctx context.Context, key resource.ObjectKey, pt rest.PatchType, data []byte, obj interface{}) (*resource.ObjectMeta, error) {
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	out, err := readResponse(req.Patch(pt, data, nil))
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(out, obj)
+}
+
+// 删除对象，opts为nil时使用服务端的默认值
+func (c *Client) Delete(ctx context.Context, key resource.ObjectKey, opts *resource.DeleteOptions) error {
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return err
+	}
+	return c.delete(ctx, path, opts, ListOptions{})
+}
+
+// 删除匹配listOpts中标签和字段选择器的全部对象，listOpts.Namespace为空时使用default
+func (c *Client) DeleteCollection(ctx context.Context, apiVersion, kind string, opts *resource.DeleteOptions, listOpts ListOptions) error {
+	path, _, err := c.resourcePath(resource.ObjectKey{ApiVersion: apiVersion, Kind: kind, Namespace: listOpts.Namespace}, false)
+	if err != nil {
+		return err
+	}
+	return c.delete(ctx, path, opts, listOpts)
+}
+
+func (c *Client) delete(ctx context.Context, path string, opts *resource.DeleteOptions, listOpts ListOptions) error {
+	body, err := resource.EncodeDeleteOptions(opts)
+	if err != nil {
+		return err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	req.SetLabelSelector(listOpts.LabelSelector)
+	req.SetFieldSelector(listOpts.FieldSelector)
+	_, err = readResponse(req.Delete(body, map[string]string{"Content-Type": "application/json"}))
+	return err
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"k8s-client-go/patch"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+// 保存上次apply的配置的注解，与kubectl保持一致
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// 支持strategic merge patch的内置API组，其它资源（如CRD）使用merge patch
+var strategicGroups = map[string]bool{
+	"":                          true,
+	"apps":                      true,
+	"extensions":                true,
+	"batch":                     true,
+	"autoscaling":               true,
+	"policy":                    true,
+	"networking.k8s.io":         true,
+	"settings.k8s.io":           true,
+	"storage.k8s.io":            true,
+	"rbac.authorization.k8s.io": true,
+}
+
+// 客户端apply，用于未开启服务端apply的集群
+// 对象不存在时创建，存在时根据上次apply的配置、本次的配置和服务端当前对象计算三路合并patch
+// 成功后将服务端返回的对象写回obj，并返回其中的metadata
+func (c *Client) ClientSideApply(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error) {
+	manifest, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.applyManifest(ctx, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(data, obj)
+}
+
+// 对yaml文件（可包含多个以---分隔的文档）中的每个对象执行客户端apply
+func (c *Client) ClientSideApplyManifest(ctx context.Context, data []byte) ([]*resource.ObjectMeta, error) {
+	manifests, err := resource.DecodeManifests(data)
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]*resource.ObjectMeta, 0, len(manifests))
+	for _, manifest := range manifests {
+		out, err := c.applyManifest(ctx, manifest)
+		if err != nil {
+			return metas, err
+		}
+		meta, err := decodeObject(out, nil)
+		if err != nil {
+			return metas, err
+		}
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+func (c *Client) applyManifest(ctx context.Context, manifest map[string]interface{}) ([]byte, error) {
+	key, err := resource.GetObjectKey(manifest)
+	if err != nil {
+		return nil, err
+	}
+	path, mapping, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := withLastApplied(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	current, err := readResponse(req.Get())
+	if rest.IsNotFound(err) {
+		collection, _, err := c.resourcePath(key, false)
+		if err != nil {
+			return nil, err
+		}
+		req := c.rest.NewRequest(collection)
+		req.SetContext(ctx)
+		return readResponse(req.Post(modified, map[string]string{"Content-Type": "application/json"}))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := resource.DecodeMap(current)
+	if err != nil {
+		return nil, err
+	}
+	original := ""
+	if metadata, ok := live["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			original, _ = annotations[LastAppliedConfigAnnotation].(string)
+		}
+	}
+
+	strategic := strategicGroups[mapping.Group]
+	p, err := patch.CreateThreeWayMergePatch([]byte(original), modified, current, strategic)
+	if err != nil {
+		return nil, err
+	}
+	if string(p) == "{}" {
+		return current, nil
+	}
+	pt := rest.MergePatchType
+	if strategic {
+		pt = rest.StrategicMergePatchType
+	}
+	req = c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	return readResponse(req.Patch(pt, p, nil))
+}
+
+// 将不含注解本身的配置写入last-applied注解，返回带注解的json
+func withLastApplied(manifest map[string]interface{}) ([]byte, error) {
+	metadata, ok := manifest["metadata"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("metadata is empty")
+	}
+	annotations := map[string]interface{}{}
+	if existing, ok := metadata["annotations"].(map[string]interface{}); ok {
+		for k, v := range existing {
+			if k != LastAppliedConfigAnnotation {
+				annotations[k] = v
+			}
+		}
+	}
+
+	applied := copyWithAnnotations(manifest, metadata, annotations)
+	lastApplied, err := json.Marshal(applied)
+	if err != nil {
+		return nil, err
+	}
+
+	withAnnotation := map[string]interface{}{LastAppliedConfigAnnotation: string(lastApplied)}
+	for k, v := range annotations {
+		withAnnotation[k] = v
+	}
+	return json.Marshal(copyWithAnnotations(manifest, metadata, withAnnotation))
+}
+
+// 浅拷贝清单并替换metadata.annotations，注解为空时去掉该字段
+func copyWithAnnotations(manifest, metadata, annotations map[string]interface{}) map[string]interface{} {
+	meta := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		meta[k] = v
+	}
+	delete(meta, "annotations")
+	if len(annotations) > 0 {
+		meta["annotations"] = annotations
+	}
+	out := make(map[string]interface{}, len(manifest))
+	for k, v := range manifest {
+		out[k] = v
+	}
+	out["metadata"] = meta
+	return out
+}
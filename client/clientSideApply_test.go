@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s-client-go/patch"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	"k8s-client-go/rest"
+)
+
+func TestClient_ClientSideApply(t *testing.T) {
+	var live []byte
+	var patches []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodGet && live == nil:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		case r.Method == http.MethodPost && r.URL.Path == "/apis/apps/v1/namespaces/default/deployments":
+			live = body
+		case r.Method == http.MethodPatch && r.URL.Path == "/apis/apps/v1/namespaces/default/deployments/web":
+			if r.Header.Get("Content-Type") != string(rest.StrategicMergePatchType) {
+				t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+			}
+			patches = append(patches, string(body))
+			merged, err := patch.ApplyStrategicMergePatch(live, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			live = merged
+		case r.Method != http.MethodGet:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write(live)
+	})
+
+	deploy := appsv1.NewResDeployment()
+	deploy.SetMetadataName("web")
+	deploy.Metadata.Labels["app"] = "web"
+	deploy.Metadata.Labels["tier"] = "front"
+	deploy.AddContainer(resource.NewContainer("web", "nginx:1.16"))
+	meta, err := c.ClientSideApply(context.Background(), deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(meta.Annotations[LastAppliedConfigAnnotation], `"tier":"front"`) {
+		t.Fatalf("missing last-applied annotation: %+v", meta.Annotations)
+	}
+
+	// 模拟其它客户端修改了对象
+	live = []byte(strings.Replace(string(live), `"labels":{`, `"labels":{"team":"ops",`, 1))
+
+	// 再次apply相同的配置不发送patch
+	if _, err := c.ClientSideApply(context.Background(), deploy); err != nil || len(patches) != 0 {
+		t.Fatalf("unexpected patches %v, err %v", patches, err)
+	}
+
+	delete(deploy.Metadata.Labels, "tier")
+	deploy.Spec.Template.Spec.Containers[0].(*resource.Container).Image = "nginx:1.17"
+	if _, err := c.ClientSideApply(context.Background(), deploy); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 {
+		t.Fatalf("expected one patch, got %v", patches)
+	}
+	result := struct {
+		Metadata struct {
+			Labels map[string]string
+		}
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []map[string]interface{}
+				}
+			}
+		}
+	}{}
+	if err := json.Unmarshal(live, &result); err != nil {
+		t.Fatal(err)
+	}
+	labels := result.Metadata.Labels
+	if labels["team"] != "ops" || labels["app"] != "web" || labels["tier"] != "" {
+		t.Fatalf("unexpected labels %v", labels)
+	}
+	if image := result.Spec.Template.Spec.Containers[0]["image"]; image != "nginx:1.17" {
+		t.Fatalf("unexpected image %v", image)
+	}
+}
+
+func TestClient_ClientSideApplyServerDefaults(t *testing.T) {
+	var live []byte
+	var patches []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method {
+		case http.MethodGet:
+			if live == nil {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+				return
+			}
+		case http.MethodPost:
+			// 模拟服务端填充默认值
+			obj, err := resource.DecodeMap(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			containers, _, _ := resource.NestedSlice(obj, "spec", "template", "spec", "containers")
+			for _, container := range containers {
+				m := container.(map[string]interface{})
+				m["imagePullPolicy"] = "IfNotPresent"
+				m["terminationMessagePath"] = "/dev/termination-log"
+				m["resources"] = map[string]interface{}{}
+			}
+			resource.SetNestedSlice(obj, containers, "spec", "template", "spec", "containers")
+			resource.SetNestedField(obj, int64(1), "spec", "replicas")
+			resource.SetNestedField(obj, "RollingUpdate", "spec", "strategy", "type")
+			live, _ = json.Marshal(obj)
+		case http.MethodPatch:
+			patches = append(patches, string(body))
+		}
+		w.Write(live)
+	})
+
+	// 与kubectl每次读取配置文件一致，每次apply使用新构造的对象
+	config := func() *appsv1.ResDeployment {
+		deploy := appsv1.NewResDeployment()
+		deploy.SetMetadataName("web")
+		deploy.Metadata.Labels["app"] = "web"
+		deploy.AddContainer(resource.NewContainer("web", "nginx:1.17"))
+		return deploy
+	}
+	meta, err := c.ClientSideApply(context.Background(), config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastApplied := meta.Annotations[LastAppliedConfigAnnotation]
+	for _, field := range []string{"livenessProbe", "lifecycle", "securityContext", "imagePullPolicy"} {
+		if strings.Contains(lastApplied, field) {
+			t.Fatalf("unset field %s recorded in last-applied configuration %s", field, lastApplied)
+		}
+	}
+
+	// 服务端的默认值不在配置中，再次apply不应产生patch
+	for i := 0; i < 2; i++ {
+		if _, err := c.ClientSideApply(context.Background(), config()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(patches) != 0 {
+		t.Fatalf("unexpected patches %v", patches)
+	}
+}
+
+func TestClient_ClientSideApplyManifest(t *testing.T) {
+	var created []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		created = append(created, r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: kube-system
+data:
+  level: debug
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+`
+	metas, err := c.ClientSideApplyManifest(context.Background(), []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 2 || metas[0].Name != "settings" || metas[1].Annotations[LastAppliedConfigAnnotation] == "" {
+		t.Fatalf("unexpected metadata %+v", metas)
+	}
+	want := "/api/v1/namespaces/kube-system/configmaps /api/v1/namespaces/default/services"
+	if got := strings.Join(created, " "); got != want {
+		t.Fatalf("unexpected requests %s", got)
+	}
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	"k8s-client-go/rest"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := NewClientForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+const appliedDeployment = `{
+	"apiVersion": "apps/v1",
+	"kind": "Deployment",
+	"metadata": {
+		"name": "web",
+		"namespace": "default",
+		"resourceVersion": "42",
+		"uid": "8d5a4c1e",
+		"generation": 2,
+		"managedFields": [{
+			"manager": "dashboard",
+			"operation": "Apply",
+			"apiVersion": "apps/v1",
+			"time": "2020-03-01T08:00:00Z",
+			"fieldsType": "FieldsV1",
+			"fieldsV1": {
+				"f:metadata": {"f:labels": {"f:app": {}}},
+				"f:spec": {
+					"f:replicas": {},
+					"f:template": {"f:spec": {"f:containers": {"k:{\"name\":\"web\"}": {".": {}, "f:image": {}, "f:name": {}}}}}
+				}
+			}
+		}, {
+			"manager": "kube-controller-manager",
+			"operation": "Update",
+			"apiVersion": "apps/v1",
+			"fieldsType": "FieldsV1",
+			"fieldsV1": {"f:status": {"f:replicas": {}}}
+		}]
+	},
+	"spec": {
+		"replicas": 3,
+		"template": {"spec": {"containers": [{"name": "web", "image": "nginx:1.17"}]}}
+	},
+	"status": {"replicas": 3}
+}`
+
+func TestClient_Apply(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/apis/apps/v1/namespaces/default/deployments/web" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/apply-patch+yaml" {
+			t.Errorf("unexpected content type %s", ct)
+		}
+		if r.URL.Query().Get("fieldManager") != "dashboard" || r.URL.Query().Get("force") != "true" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		sent := map[string]interface{}{}
+		if err := yaml.Unmarshal(body, &sent); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		// nil的selector不发送，显式设置的replicas按整数发送
+		spec, _ := sent["spec"].(map[interface{}]interface{})
+		if _, ok := spec["selector"]; ok || spec["replicas"] != 3 {
+			t.Errorf("unexpected body %s", string(body))
+		}
+		// NewContainer未设置的探针、生命周期钩子和securityContext不发送
+		template, _ := yaml.Marshal(spec["template"])
+		if string(template) != "spec:\n  containers:\n  - image: nginx:1.17\n    name: web\n" {
+			t.Errorf("unexpected template:\n%s", template)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(appliedDeployment))
+	})
+
+	deploy := appsv1.NewResDeployment()
+	deploy.SetMetadataName("web")
+	deploy.Metadata.Labels["app"] = "web"
+	replicas := int32(3)
+	deploy.Spec.Replicas = &replicas
+	deploy.AddContainer(resource.NewContainer("web", "nginx:1.17"))
+
+	meta, err := c.Apply(context.Background(), deploy, "dashboard", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.ResourceVersion != "42" || meta.Generation != 2 || len(meta.ManagedFields) != 2 {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+	entry := meta.ManagedFields[0]
+	if entry.Manager != "dashboard" || entry.Operation != "Apply" || entry.Time.Year() != 2020 {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	owned := strings.Join(entry.OwnedFields(), " ")
+	want := "metadata.labels.app spec.replicas spec.template.spec.containers[name=web] spec.template.spec.containers[name=web].image spec.template.spec.containers[name=web].name"
+	if owned != want {
+		t.Fatalf("unexpected owned fields %s", owned)
+	}
+	if deploy.Metadata.Namespace != "default" || len(deploy.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("response was not decoded into the object: %+v", deploy)
+	}
+}
+
+func TestClient_ApplyConflict(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Apply failed with 1 conflict: conflict with \"kubectl\": .spec.replicas","reason":"Conflict","code":409}`))
+	})
+	deploy := appsv1.NewResDeployment()
+	deploy.SetMetadataName("web")
+	_, err := c.Apply(context.Background(), deploy, "dashboard", false)
+	if !rest.IsConflict(err) || !strings.Contains(err.Error(), "spec.replicas") {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if _, err := c.Apply(context.Background(), deploy, "", false); err == nil {
+		t.Fatal("expected missing field manager error")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Apply(ctx, deploy, "dashboard", false); err == nil || !strings.Contains(err.Error(), "context canceled") {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestClient_Create(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/apps/v1/namespaces/default/replicasets" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		// 显式设置的0不能被去掉，否则服务端会使用默认值1
+		if !strings.Contains(string(body), `"replicas":0`) {
+			t.Errorf("unexpected body %s", string(body))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+
+	rs := appsv1.NewResReplicaSet()
+	rs.SetMetadataName("web")
+	rs.SetNamespace("default")
+	rs.SetReplicas(0)
+	if _, err := c.Create(context.Background(), rs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_GetPartialDecode(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"},"spec":{"replicas":"3"}}`))
+	})
+	deploy := appsv1.NewResDeployment()
+	meta, err := c.Get(context.Background(), resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}, deploy)
+	if !resource.IsPartialDecode(err) {
+		t.Fatalf("expected partial decode error, got %v", err)
+	}
+	if meta == nil || meta.Name != "web" || deploy.Metadata.Name != "web" {
+		t.Fatalf("decoded fields should be kept, got %+v %+v", meta, deploy.Metadata)
+	}
+}
+
+func TestClient_Delete(t *testing.T) {
+	var bodies []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.URL.RequestURI()+" "+string(body))
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	})
+	ctx := context.Background()
+
+	grace := int64(0)
+	opts := &resource.DeleteOptions{
+		GracePeriodSeconds: &grace,
+		PropagationPolicy:  resource.DeletePropagationForeground,
+		Preconditions:      &resource.Preconditions{Uid: "8d5a4c1e"},
+		DryRun:             []string{resource.DryRunAll},
+	}
+	if err := c.Delete(ctx, resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, resource.ObjectKey{ApiVersion: "v1", Kind: "Pod", Namespace: "test", Name: "web"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCollection(ctx, "v1", "Pod", nil, ListOptions{Namespace: "test", LabelSelector: "app=web"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`/apis/apps/v1/namespaces/default/deployments/web {"dryRun":["All"],"gracePeriodSeconds":0,"preconditions":{"uid":"8d5a4c1e"},"propagationPolicy":"Foreground"}`,
+		`/api/v1/namespaces/test/pods/web `,
+		`/api/v1/namespaces/test/pods?labelSelector=app%3Dweb `,
+	}
+	if strings.Join(bodies, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(bodies, "\n"))
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"k8s-client-go/resource"
+	policyv1 "k8s-client-go/resource/policy/v1"
+)
+
+// 通过pod的eviction子资源驱逐pod，opts可以为nil
+// 违反PodDisruptionBudget时服务端返回429，可用rest.IsTooManyRequests判断并按rest.SuggestsClientDelay重试，其他错误不应重试
+func (c *Client) Evict(ctx context.Context, namespace, name string, opts *resource.DeleteOptions) error {
+	path, _, err := c.resourcePath(resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: namespace, Name: name}, true)
+	if err != nil {
+		return err
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	body, err := resource.ToJson(policyv1.NewEviction(namespace, name, opts))
+	if err != nil {
+		return err
+	}
+	req := c.rest.NewRequest(path + "/eviction")
+	req.SetContext(ctx)
+	_, err = readResponse(req.Post(body, map[string]string{"Content-Type": "application/json"}))
+	return err
+}
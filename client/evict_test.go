@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+func TestClient_Evict(t *testing.T) {
+	blocked := true
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/namespaces/test/pods/web/eviction" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		eviction := map[string]interface{}{}
+		json.Unmarshal(body, &eviction)
+		expected := map[string]interface{}{
+			"apiVersion":    "policy/v1",
+			"kind":          "Eviction",
+			"metadata":      map[string]interface{}{"name": "web", "namespace": "test"},
+			"deleteOptions": map[string]interface{}{"gracePeriodSeconds": float64(30), "preconditions": map[string]interface{}{"uid": "pod-uid"}},
+		}
+		if !reflect.DeepEqual(eviction, expected) {
+			t.Errorf("unexpected eviction %s", body)
+		}
+		if blocked {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Cannot evict pod as it would violate the pod's disruption budget.","reason":"TooManyRequests","code":429}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success","code":201}`))
+	})
+
+	grace := int64(30)
+	opts := &resource.DeleteOptions{GracePeriodSeconds: &grace, Preconditions: &resource.Preconditions{Uid: "pod-uid"}}
+	err := c.Evict(context.Background(), "test", "web", opts)
+	if !rest.IsTooManyRequests(err) {
+		t.Fatalf("expected 429, got %v", err)
+	}
+	if seconds, ok := rest.SuggestsClientDelay(err); !ok || seconds != 10 {
+		t.Fatalf("unexpected retry delay %d", seconds)
+	}
+	blocked = false
+	if err := c.Evict(context.Background(), "test", "web", opts); err != nil {
+		t.Fatal(err)
+	}
+}
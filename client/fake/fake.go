@@ -0,0 +1,600 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	policyv1 "k8s-client-go/resource/policy/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/retry"
+	"k8s-client-go/watch"
+)
+
+// 客户端发出的一次操作
+type Action struct {
+	Verb        string // get、list、watch、create、update、patch、delete、delete-collection
+	Resource    resource.GroupVersionResource
+	Namespace   string
+	Name        string
+	Subresource string
+
+	Object      map[string]interface{} // create和update提交的对象
+	PatchType   rest.PatchType
+	Patch       []byte
+	ListOptions client.ListOptions
+	LogOptions  client.PodLogOptions
+
+	DeleteOptions *resource.DeleteOptions // delete、delete-collection和驱逐时的参数
+
+	FieldManager string // apply时的fieldManager
+}
+
+// verb和resource为"*"时匹配任意值，resource为资源名，如 pods、deployments
+func (a Action) Matches(verb, resourceName string) bool {
+	return (verb == "*" || verb == a.Verb) && (resourceName == "*" || resourceName == a.Resource.Resource)
+}
+
+// 处理一次操作，handled为false时交给下一个reactor
+// ret可以是资源结构体、Unstructured、map或*client.ListPage，会被解码到调用方传入的对象
+// watch时ret须为watch.Interface，获取日志时ret可以是string、[]byte或io.Reader
+type ReactionFunc func(action Action) (handled bool, ret interface{}, err error)
+
+type reactor struct {
+	verb     string
+	resource string
+	reaction ReactionFunc
+}
+
+// 用于单元测试的客户端，记录所有操作，默认由内存中的ObjectTracker响应
+type Client struct {
+	lock     sync.Mutex
+	mapper   rest.RESTMapper
+	tracker  *ObjectTracker
+	actions  []Action
+	reactors []reactor
+	mappings map[resource.GroupVersionResource]rest.RESTMapping // 操作中出现过的资源
+}
+
+var _ client.Interface = &Client{}
+
+// 创建fake客户端并写入初始对象，对象无法写入时panic
+func NewSimpleClient(objects ...resource.IResource) *Client {
+	c := NewClient(rest.DefaultRESTMapper)
+	for _, obj := range objects {
+		if err := c.tracker.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+	return c
+}
+
+func NewClient(mapper rest.RESTMapper) *Client {
+	c := &Client{mapper: mapper, tracker: NewObjectTracker(mapper), mappings: map[resource.GroupVersionResource]rest.RESTMapping{}}
+	c.AddReactor("*", "*", c.trackerReaction)
+	return c
+}
+
+func (c *Client) Tracker() *ObjectTracker {
+	return c.tracker
+}
+
+// 已记录的操作
+func (c *Client) Actions() []Action {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	actions := make([]Action, len(c.actions))
+	copy(actions, c.actions)
+	return actions
+}
+
+func (c *Client) ClearActions() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.actions = nil
+}
+
+// 在已有reactor之前添加，用于注入错误或返回固定的结果
+func (c *Client) PrependReactor(verb, resourceName string, fn ReactionFunc) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.reactors = append([]reactor{{verb, resourceName, fn}}, c.reactors...)
+}
+
+// 在已有reactor之后添加
+func (c *Client) AddReactor(verb, resourceName string, fn ReactionFunc) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.reactors = append(c.reactors, reactor{verb, resourceName, fn})
+}
+
+// 记录操作并依次交给reactor处理
+func (c *Client) invoke(action Action) (interface{}, error) {
+	c.lock.Lock()
+	c.actions = append(c.actions, action)
+	reactors := make([]reactor, len(c.reactors))
+	copy(reactors, c.reactors)
+	c.lock.Unlock()
+
+	for _, r := range reactors {
+		if !action.Matches(r.verb, r.resource) {
+			continue
+		}
+		handled, ret, err := r.reaction(action)
+		if handled {
+			return ret, err
+		}
+	}
+	return nil, errors.New("no reaction implemented for " + action.Verb + " " + action.Resource.String())
+}
+
+// 默认的reactor，在ObjectTracker上执行操作
+func (c *Client) trackerReaction(action Action) (bool, interface{}, error) {
+	mapping, err := c.mappingFor(action.Resource)
+	if err != nil {
+		return true, nil, err
+	}
+	var ret interface{}
+	if action.Subresource != "" {
+		return c.subresourceReaction(mapping, action)
+	}
+	switch action.Verb {
+	case "get":
+		ret, err = c.tracker.Get(mapping, action.Namespace, action.Name)
+	case "list":
+		ret, err = c.listPage(mapping, action)
+	case "create":
+		ret, err = c.tracker.Create(mapping, action.Namespace, action.Object)
+	case "update":
+		ret, err = c.tracker.Update(mapping, action.Namespace, action.Object)
+	case "patch":
+		ret, err = c.tracker.Patch(mapping, action.Namespace, action.Name, action.PatchType, action.Patch)
+	case "delete":
+		err = c.delete(mapping, action.Namespace, action.Name, action.DeleteOptions)
+	case "delete-collection":
+		err = c.deleteCollection(mapping, action)
+	case "watch":
+		ret, err = c.tracker.Watch(mapping, action.Namespace, action.ListOptions.LabelSelector, action.ListOptions.FieldSelector)
+	default:
+		return false, nil, nil
+	}
+	return true, ret, err
+}
+
+// 默认的日志为 "fake logs"，pod不存在时返回NotFound
+func (c *Client) subresourceReaction(mapping *rest.RESTMapping, action Action) (bool, interface{}, error) {
+	if action.Verb == "get" && action.Subresource == "log" {
+		if _, err := c.tracker.Get(mapping, action.Namespace, action.Name); err != nil {
+			return true, nil, err
+		}
+		return true, "fake logs", nil
+	}
+	if action.Subresource == "scale" {
+		return c.scaleReaction(mapping, action)
+	}
+	// 驱逐时直接删除pod，不检查PodDisruptionBudget
+	if action.Verb == "create" && action.Subresource == "eviction" {
+		return true, nil, c.delete(mapping, action.Namespace, action.Name, action.DeleteOptions)
+	}
+	return false, nil, nil
+}
+
+// scale子资源读写对象的spec.replicas，status.replicas需由测试设置
+func (c *Client) scaleReaction(mapping *rest.RESTMapping, action Action) (bool, interface{}, error) {
+	current, err := c.tracker.Get(mapping, action.Namespace, action.Name)
+	if err != nil {
+		return true, nil, err
+	}
+	switch action.Verb {
+	case "get":
+		return true, autoscalingv1.ScaleFromObject(current), nil
+	case "update":
+		replicas, _, _ := resource.NestedInt64(action.Object, "spec", "replicas")
+		resource.SetNestedField(current, replicas, "spec", "replicas")
+		if rv, _, _ := resource.NestedString(action.Object, "metadata", "resourceVersion"); rv != "" {
+			resource.SetNestedField(current, rv, "metadata", "resourceVersion")
+		}
+		updated, err := c.tracker.Update(mapping, action.Namespace, current)
+		if err != nil {
+			return true, nil, err
+		}
+		return true, autoscalingv1.ScaleFromObject(updated), nil
+	}
+	return false, nil, nil
+}
+
+// 检查preconditions，dryRun时不删除；不处理级联删除和优雅退出
+func (c *Client) delete(mapping *rest.RESTMapping, namespace, name string, opts *resource.DeleteOptions) error {
+	current, err := c.tracker.Get(mapping, namespace, name)
+	if err != nil {
+		return err
+	}
+	if opts != nil {
+		if err := opts.Preconditions.Check(current); err != nil {
+			return rest.NewPreconditionFailed(mapping.QualifiedResource(), name, err)
+		}
+	}
+	if opts.IsDryRun() {
+		return nil
+	}
+	return c.tracker.Delete(mapping, namespace, name)
+}
+
+func (c *Client) deleteCollection(mapping *rest.RESTMapping, action Action) error {
+	objs, err := c.tracker.List(mapping, action.Namespace, action.ListOptions.LabelSelector, action.ListOptions.FieldSelector)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		u := &resource.Unstructured{Object: obj}
+		if err := c.delete(mapping, u.GetNamespace(), u.GetName(), action.DeleteOptions); err != nil && !rest.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// 按Limit分页，continue为下一页的起始位置
+func (c *Client) listPage(mapping *rest.RESTMapping, action Action) (*client.ListPage, error) {
+	opts := action.ListOptions
+	objs, err := c.tracker.List(mapping, action.Namespace, opts.LabelSelector, opts.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	start := 0
+	if opts.Continue != "" {
+		if start, err = strconv.Atoi(opts.Continue); err != nil || start < 0 || start > len(objs) {
+			return nil, rest.NewBadRequest("invalid continue token")
+		}
+	}
+	end := len(objs)
+	page := &client.ListPage{ApiVersion: mapping.GroupVersion(), Kind: mapping.Kind + "List"}
+	if opts.Limit > 0 && int64(end-start) > opts.Limit {
+		end = start + int(opts.Limit)
+		remaining := int64(len(objs) - end)
+		page.Metadata.Continue = strconv.Itoa(end)
+		page.Metadata.RemainingItemCount = &remaining
+	}
+	for _, obj := range objs[start:end] {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, data)
+	}
+	return page, nil
+}
+
+func (c *Client) mappingFor(gvr resource.GroupVersionResource) (*rest.RESTMapping, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	mapping, ok := c.mappings[gvr]
+	if !ok {
+		return nil, errors.New("no mapping for " + gvr.String())
+	}
+	return &mapping, nil
+}
+
+// 根据apiVersion和kind构造操作，命名空间级资源未指定命名空间时使用default
+func (c *Client) newAction(verb string, key resource.ObjectKey) (Action, error) {
+	mapping, err := c.mapper.RESTMapping(key.ApiVersion, key.Kind)
+	if err != nil {
+		return Action{}, err
+	}
+	action := Action{
+		Verb:     verb,
+		Resource: resource.GroupVersionResource{Group: mapping.Group, Version: mapping.Version, Resource: mapping.Resource},
+		Name:     key.Name,
+	}
+	c.lock.Lock()
+	c.mappings[action.Resource] = *mapping
+	c.lock.Unlock()
+	if mapping.Namespaced {
+		action.Namespace = key.Namespace
+		if action.Namespace == "" && verb != "list" && verb != "watch" {
+			action.Namespace = "default"
+		}
+	}
+	return action, nil
+}
+
+func (c *Client) Get(ctx context.Context, key resource.ObjectKey, obj interface{}) (*resource.ObjectMeta, error) {
+	if key.Name == "" {
+		return nil, errors.New("name is empty")
+	}
+	action, err := c.newAction("get", key)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResult(ret, obj)
+}
+
+func (c *Client) List(ctx context.Context, apiVersion, kind string, opts client.ListOptions) (*client.ListPage, error) {
+	action, err := c.newAction("list", resource.ObjectKey{ApiVersion: apiVersion, Kind: kind, Namespace: opts.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	action.ListOptions = opts
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	if page, ok := ret.(*client.ListPage); ok {
+		return page, nil
+	}
+	data, err := json.Marshal(ret)
+	if err != nil {
+		return nil, err
+	}
+	page := &client.ListPage{}
+	if err := json.Unmarshal(data, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (c *Client) Watch(ctx context.Context, apiVersion, kind string, opts client.ListOptions) (watch.Interface, error) {
+	action, err := c.newAction("watch", resource.ObjectKey{ApiVersion: apiVersion, Kind: kind, Namespace: opts.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	action.ListOptions = opts
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	w, ok := ret.(watch.Interface)
+	if !ok {
+		return nil, fmt.Errorf("unexpected watch result %T", ret)
+	}
+	return w, nil
+}
+
+func (c *Client) Create(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error) {
+	return c.write("create", obj)
+}
+
+func (c *Client) Update(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error) {
+	return c.write("update", obj)
+}
+
+func (c *Client) write(verb string, obj resource.IResource) (*resource.ObjectMeta, error) {
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	action, err := c.newAction(verb, key)
+	if err != nil {
+		return nil, err
+	}
+	if action.Object, err = resource.ToManifest(obj); err != nil {
+		return nil, err
+	}
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResult(ret, obj)
+}
+
+// 读取-修改-写入，遇到冲突时重试，与client.Client的行为一致
+func (c *Client) UpdateWithRetry(ctx context.Context, key resource.ObjectKey, obj resource.IResource, mutate func(obj resource.IResource) error) (*resource.ObjectMeta, error) {
+	if mutate == nil {
+		return nil, errors.New("mutate is nil")
+	}
+	var meta *resource.ObjectMeta
+	err := retry.OnErrorContext(ctx, retry.DefaultRetry, rest.IsConflict, func() error {
+		if _, err := c.Get(ctx, key, obj); err != nil {
+			return err
+		}
+		if err := mutate(obj); err != nil {
+			return err
+		}
+		var err error
+		meta, err = c.Update(ctx, obj)
+		return err
+	})
+	return meta, err
+}
+
+func (c *Client) Patch(ctx context.Context, key resource.ObjectKey, pt rest.PatchType, data []byte, obj interface{}) (*resource.ObjectMeta, error) {
+	if key.Name == "" {
+		return nil, errors.New("name is empty")
+	}
+	action, err := c.newAction("patch", key)
+	if err != nil {
+		return nil, err
+	}
+	action.PatchType = pt
+	action.Patch = data
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResult(ret, obj)
+}
+
+func (c *Client) Delete(ctx context.Context, key resource.ObjectKey, opts *resource.DeleteOptions) error {
+	if key.Name == "" {
+		return errors.New("name is empty")
+	}
+	action, err := c.newAction("delete", key)
+	if err != nil {
+		return err
+	}
+	action.DeleteOptions = opts
+	_, err = c.invoke(action)
+	return err
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, apiVersion, kind string, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	action, err := c.newAction("delete-collection", resource.ObjectKey{ApiVersion: apiVersion, Kind: kind, Namespace: listOpts.Namespace})
+	if err != nil {
+		return err
+	}
+	action.DeleteOptions = opts
+	action.ListOptions = listOpts
+	_, err = c.invoke(action)
+	return err
+}
+
+// 记录为apply类型的patch操作
+func (c *Client) Apply(ctx context.Context, obj resource.IResource, fieldManager string, force bool) (*resource.ObjectMeta, error) {
+	if fieldManager == "" {
+		return nil, errors.New("fieldManager is required for apply")
+	}
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	action, err := c.newAction("patch", key)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	if action.Patch, err = json.Marshal(manifest); err != nil {
+		return nil, err
+	}
+	action.PatchType = rest.ApplyPatchType
+	action.FieldManager = fieldManager
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResult(ret, obj)
+}
+
+// 记录为子资源为log的get操作
+func (c *Client) GetLogs(ctx context.Context, namespace, name string, opts client.PodLogOptions) (io.ReadCloser, error) {
+	action, err := c.newAction("get", resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: namespace, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	action.Subresource = "log"
+	action.LogOptions = opts
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	switch r := ret.(type) {
+	case io.ReadCloser:
+		return r, nil
+	case io.Reader:
+		return ioutil.NopCloser(r), nil
+	case string:
+		return ioutil.NopCloser(strings.NewReader(r)), nil
+	case []byte:
+		return ioutil.NopCloser(bytes.NewReader(r)), nil
+	}
+	return nil, fmt.Errorf("unexpected log result %T", ret)
+}
+
+// 记录为子资源为scale的get操作
+func (c *Client) GetScale(ctx context.Context, key resource.ObjectKey) (*autoscalingv1.Scale, error) {
+	return c.scale("get", key, nil)
+}
+
+// 记录为子资源为scale的update操作，Object为提交的scale
+func (c *Client) UpdateScale(ctx context.Context, key resource.ObjectKey, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	if scale == nil {
+		return nil, errors.New("scale is nil")
+	}
+	return c.scale("update", key, scale)
+}
+
+func (c *Client) scale(verb string, key resource.ObjectKey, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	key, err := client.ScaleObjectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if key.Name == "" {
+		return nil, errors.New("name is empty")
+	}
+	action, err := c.newAction(verb, key)
+	if err != nil {
+		return nil, err
+	}
+	action.Subresource = "scale"
+	if scale != nil {
+		if action.Object, err = resource.ToMap(scale); err != nil {
+			return nil, err
+		}
+	}
+	ret, err := c.invoke(action)
+	if err != nil {
+		return nil, err
+	}
+	result := autoscalingv1.NewScale()
+	if _, err := decodeResult(ret, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 记录为子资源为eviction的create操作，Object为提交的Eviction
+func (c *Client) Evict(ctx context.Context, namespace, name string, opts *resource.DeleteOptions) error {
+	action, err := c.newAction("create", resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: namespace, Name: name})
+	if err != nil {
+		return err
+	}
+	action.Subresource = "eviction"
+	action.DeleteOptions = opts
+	if action.Object, err = resource.ToMap(policyv1.NewEviction(action.Namespace, name, opts)); err != nil {
+		return err
+	}
+	_, err = c.invoke(action)
+	return err
+}
+
+// 将reactor的返回值解码到obj，返回其中的metadata，部分字段类型不一致时返回*resource.PartialDecodeError
+func decodeResult(ret interface{}, obj interface{}) (*resource.ObjectMeta, error) {
+	if ret == nil {
+		return &resource.ObjectMeta{}, nil
+	}
+	m, err := toObject(ret)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var decodeErr error
+	if obj != nil {
+		if err := resource.DecodeInto(data, obj); err != nil {
+			if !resource.IsPartialDecode(err) {
+				return nil, err
+			}
+			decodeErr = err
+		}
+	}
+	envelope := struct {
+		Metadata resource.ObjectMeta
+	}{}
+	if err := resource.DecodeInto(data, &envelope); err != nil {
+		if !resource.IsPartialDecode(err) {
+			return nil, err
+		}
+		if decodeErr == nil {
+			decodeErr = err
+		}
+	}
+	return &envelope.Metadata, decodeErr
+}
@@ -0,0 +1,274 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	"k8s-client-go/rest"
+)
+
+func newDeployment(name string, replicas int32) *appsv1.ResDeployment {
+	deploy := appsv1.NewResDeployment()
+	deploy.Metadata.Name = name
+	deploy.Metadata.Labels = map[string]string{"app": name}
+	deploy.Spec.Replicas = &replicas
+	return deploy
+}
+
+func TestClient_Actions(t *testing.T) {
+	c := NewSimpleClient(newDeployment("web", 1))
+	ctx := context.Background()
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+
+	deploy := appsv1.NewResDeployment()
+	meta, err := c.Get(ctx, key, deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Namespace != "default" || *deploy.Spec.Replicas != 1 {
+		t.Fatalf("unexpected object %+v %+v", meta, deploy.Spec)
+	}
+	if _, err := c.Create(ctx, newDeployment("web", 1)); !rest.IsAlreadyExists(err) {
+		t.Fatalf("expected already exists, got %v", err)
+	}
+	if _, err := c.Patch(ctx, key, rest.MergePatchType, []byte(`{"spec":{"replicas":3}}`), deploy); err != nil {
+		t.Fatal(err)
+	}
+	if *deploy.Spec.Replicas != 3 {
+		t.Fatalf("unexpected replicas %d", *deploy.Spec.Replicas)
+	}
+	if err := c.Delete(ctx, key, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, key, nil); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	var verbs []string
+	for _, action := range c.Actions() {
+		if action.Resource.Resource != "deployments" || action.Namespace != "default" || action.Name != "web" {
+			t.Errorf("unexpected action %+v", action)
+		}
+		verbs = append(verbs, action.Verb)
+	}
+	if fmt.Sprint(verbs) != "[get create patch delete get]" {
+		t.Fatalf("unexpected actions %v", verbs)
+	}
+	if string(c.Actions()[2].Patch) != `{"spec":{"replicas":3}}` || c.Actions()[1].Object["kind"] != "Deployment" {
+		t.Fatalf("action body not recorded")
+	}
+}
+
+func TestClient_PrependReactor(t *testing.T) {
+	c := NewSimpleClient(newDeployment("web", 1))
+	ctx := context.Background()
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+
+	c.PrependReactor("delete", "deployments", func(action Action) (bool, interface{}, error) {
+		return true, nil, errors.New("injected")
+	})
+	c.PrependReactor("get", "*", func(action Action) (bool, interface{}, error) {
+		switch action.Name {
+		case "canned":
+			return true, newDeployment("canned", 5), nil
+		case "mismatched":
+			return true, map[string]interface{}{"metadata": map[string]interface{}{"name": "mismatched"}, "spec": map[string]interface{}{"replicas": "5"}}, nil
+		}
+		return false, nil, nil
+	})
+
+	if err := c.Delete(ctx, key, nil); err == nil || err.Error() != "injected" {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	deploy := appsv1.NewResDeployment()
+	key.Name = "canned"
+	if _, err := c.Get(ctx, key, deploy); err != nil || *deploy.Spec.Replicas != 5 {
+		t.Fatalf("unexpected canned response %v %+v", err, deploy.Spec)
+	}
+	key.Name = "mismatched"
+	if meta, err := c.Get(ctx, key, appsv1.NewResDeployment()); !resource.IsPartialDecode(err) || meta.Name != "mismatched" {
+		t.Fatalf("expected partial decode error, got %v %+v", err, meta)
+	}
+	key.Name = "web"
+	if _, err := c.Get(ctx, key, deploy); err != nil || *deploy.Spec.Replicas != 1 {
+		t.Fatalf("expected fallthrough to tracker, got %v %+v", err, deploy.Spec)
+	}
+}
+
+func TestClient_UpdateWithRetry(t *testing.T) {
+	c := NewSimpleClient(newDeployment("web", 1))
+	conflicts := 0
+	c.PrependReactor("update", "deployments", func(action Action) (bool, interface{}, error) {
+		if conflicts < 2 {
+			conflicts++
+			return true, nil, rest.NewConflict("deployments.apps", action.Name)
+		}
+		return false, nil, nil
+	})
+
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	deploy := appsv1.NewResDeployment()
+	_, err := c.UpdateWithRetry(context.Background(), key, deploy, func(obj resource.IResource) error {
+		replicas := int32(2)
+		obj.(*appsv1.ResDeployment).Spec.Replicas = &replicas
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflicts != 2 || len(c.Actions()) != 6 {
+		t.Fatalf("unexpected retries %d, actions %d", conflicts, len(c.Actions()))
+	}
+	if _, err := c.Get(context.Background(), key, deploy); err != nil || *deploy.Spec.Replicas != 2 {
+		t.Fatalf("update not stored: %v %+v", err, deploy.Spec)
+	}
+}
+
+func TestClient_Pager(t *testing.T) {
+	var objects []resource.IResource
+	for i := 0; i < 5; i++ {
+		objects = append(objects, newDeployment(fmt.Sprintf("web-%d", i), 1))
+	}
+	c := NewSimpleClient(objects...)
+	pager := client.NewListPager(c, "apps/v1", "Deployment", client.ListOptions{Namespace: "default"})
+	pager.PageSize = 2
+
+	list, err := pager.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 5 || len(c.Actions()) != 3 {
+		t.Fatalf("unexpected list of %d items in %d requests", len(list.Items), len(c.Actions()))
+	}
+	page, err := c.List(context.Background(), "apps/v1", "Deployment", client.ListOptions{LabelSelector: "app=web-3"})
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("unexpected selector result %v %v", err, page)
+	}
+}
+
+func newPod(name string, containers ...string) *resource.Unstructured {
+	pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+	pod.SetName(name)
+	pod.SetLabels(map[string]string{"app": strings.Split(name, "-")[0]})
+	var list []interface{}
+	for _, container := range containers {
+		list = append(list, map[string]interface{}{"name": container, "image": "nginx"})
+	}
+	resource.SetNestedSlice(pod.Object, list, "spec", "containers")
+	return pod
+}
+
+func TestStreamLogs(t *testing.T) {
+	c := NewSimpleClient(newPod("web-0", "nginx", "sidecar"), newPod("web-1", "nginx"), newPod("db-0", "mysql"))
+	c.PrependReactor("get", "pods", func(action Action) (bool, interface{}, error) {
+		if action.Subresource != "log" {
+			return false, nil, nil
+		}
+		if action.LogOptions.TailLines == nil || *action.LogOptions.TailLines != 2 {
+			t.Errorf("log options not passed: %+v", action.LogOptions)
+		}
+		return true, action.Name + " " + action.LogOptions.Container + " line 1\nline 2", nil
+	})
+
+	tail := int64(2)
+	out := &bytes.Buffer{}
+	if err := client.StreamLogs(context.Background(), c, "default", "app=web", client.PodLogOptions{TailLines: &tail}, out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	sort.Strings(lines)
+	expected := []string{
+		"[web-0/nginx] line 2",
+		"[web-0/nginx] web-0 nginx line 1",
+		"[web-0/sidecar] line 2",
+		"[web-0/sidecar] web-0 sidecar line 1",
+		"[web-1/nginx] line 2",
+		"[web-1/nginx] web-1 nginx line 1",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+
+	if err := client.StreamLogs(context.Background(), c, "default", "app=none", client.PodLogOptions{}, out); err == nil {
+		t.Fatal("expected error for no pods")
+	}
+
+	// 默认的reactor
+	c = NewSimpleClient(newPod("web-0", "nginx"))
+	if _, err := c.GetLogs(context.Background(), "default", "missing", client.PodLogOptions{}); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	rc, err := c.GetLogs(context.Background(), "default", "web-0", client.PodLogOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if data, _ := ioutil.ReadAll(rc); string(data) != "fake logs" {
+		t.Fatalf("unexpected default logs %q", data)
+	}
+}
+
+func TestClient_Scale(t *testing.T) {
+	c := NewSimpleClient(newDeployment("web", 1))
+	ctx := context.Background()
+	key := resource.ObjectKey{Kind: "Deployment", Name: "web"}
+
+	scale, err := c.GetScale(ctx, key)
+	if err != nil || scale.Spec.Replicas != 1 || scale.Metadata.Name != "web" {
+		t.Fatalf("unexpected scale %+v, err %v", scale, err)
+	}
+	scale.Spec.Replicas = 3
+	if _, err := c.UpdateScale(ctx, key, scale); err != nil {
+		t.Fatal(err)
+	}
+	deploy := appsv1.NewResDeployment()
+	if _, err := c.Get(ctx, resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}, deploy); err != nil || *deploy.Spec.Replicas != 3 {
+		t.Fatalf("unexpected deployment %+v, err %v", deploy.Spec, err)
+	}
+	actions := c.Actions()
+	if len(actions) != 3 || !actions[1].Matches("update", "deployments") || actions[1].Subresource != "scale" || actions[1].Namespace != "default" {
+		t.Fatalf("unexpected actions %+v", actions)
+	}
+	if _, err := c.UpdateScale(ctx, key, scale); !rest.IsConflict(err) {
+		t.Fatalf("expected conflict with stale resourceVersion, got %v", err)
+	}
+}
+
+func TestClient_DeleteOptions(t *testing.T) {
+	c := NewSimpleClient(newDeployment("web", 1), newDeployment("db", 1), newDeployment("cache", 1))
+	ctx := context.Background()
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+
+	if err := c.Delete(ctx, key, &resource.DeleteOptions{Preconditions: &resource.Preconditions{ResourceVersion: "999"}}); !rest.IsConflict(err) {
+		t.Fatalf("expected precondition conflict, got %v", err)
+	}
+	if err := c.Delete(ctx, key, &resource.DeleteOptions{DryRun: []string{resource.DryRunAll}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, key, nil); err != nil {
+		t.Fatalf("dry run should keep the object, got %v", err)
+	}
+
+	opts := &resource.DeleteOptions{PropagationPolicy: resource.DeletePropagationOrphan}
+	if err := c.DeleteCollection(ctx, "apps/v1", "Deployment", opts, client.ListOptions{LabelSelector: "app in (web,db)"}); err != nil {
+		t.Fatal(err)
+	}
+	page, err := c.List(ctx, "apps/v1", "Deployment", client.ListOptions{})
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("expected 1 remaining deployment, got %v, err %v", page, err)
+	}
+	actions := c.Actions()
+	last := actions[len(actions)-2]
+	if last.Verb != "delete-collection" || last.DeleteOptions != opts || last.ListOptions.LabelSelector != "app in (web,db)" {
+		t.Fatalf("unexpected action %+v", last)
+	}
+}
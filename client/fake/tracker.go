@@ -0,0 +1,334 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s-client-go/fields"
+	"k8s-client-go/labels"
+	"k8s-client-go/patch"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 内存中的对象存储，作为fake客户端默认的响应来源
+// 同一组内不同版本的资源共享存储，每次写入递增resourceVersion
+type ObjectTracker struct {
+	lock     sync.Mutex
+	mapper   rest.RESTMapper
+	rv       int64
+	objects  map[string]map[string]interface{}
+	watchers map[*trackerWatcher]bool
+}
+
+func NewObjectTracker(mapper rest.RESTMapper) *ObjectTracker {
+	if mapper == nil {
+		mapper = rest.DefaultRESTMapper
+	}
+	return &ObjectTracker{
+		mapper:   mapper,
+		objects:  map[string]map[string]interface{}{},
+		watchers: map[*trackerWatcher]bool{},
+	}
+}
+
+// 直接写入对象，obj可以是资源结构体、Unstructured或map，命名空间级资源未指定命名空间时使用default
+func (t *ObjectTracker) Add(obj interface{}) error {
+	m, err := toObject(obj)
+	if err != nil {
+		return err
+	}
+	u := &resource.Unstructured{Object: m}
+	mapping, err := t.mapper.RESTMapping(u.GetApiVersion(), u.GetKind())
+	if err != nil {
+		return err
+	}
+	_, err = t.Create(mapping, u.GetNamespace(), m)
+	return err
+}
+
+func (t *ObjectTracker) Get(mapping *rest.RESTMapping, namespace, name string) (map[string]interface{}, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	obj, ok := t.objects[trackerKey(mapping, namespace, name)]
+	if !ok {
+		return nil, rest.NewNotFound(mapping.QualifiedResource(), name)
+	}
+	return withVersion(mapping, obj), nil
+}
+
+// 按名称排序返回匹配的对象，namespace为空时返回所有命名空间的对象
+func (t *ObjectTracker) List(mapping *rest.RESTMapping, namespace, labelSelector, fieldSelector string) ([]map[string]interface{}, error) {
+	label, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, rest.NewBadRequest(err.Error())
+	}
+	field, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, rest.NewBadRequest(err.Error())
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	prefix := trackerKey(mapping, namespace, "")
+	if namespace == "" {
+		prefix = mapping.Group + "/" + mapping.Resource + "/"
+	}
+	var keys []string
+	for key := range t.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var objs []map[string]interface{}
+	for _, key := range keys {
+		obj := t.objects[key]
+		u := &resource.Unstructured{Object: obj}
+		if label.Matches(labels.Set(u.GetLabels())) && field.Matches(objectFields(obj)) {
+			objs = append(objs, withVersion(mapping, obj))
+		}
+	}
+	return objs, nil
+}
+
+func (t *ObjectTracker) Create(mapping *rest.RESTMapping, namespace string, obj map[string]interface{}) (map[string]interface{}, error) {
+	u := (&resource.Unstructured{Object: obj}).DeepCopy()
+	namespace = objectNamespace(mapping, namespace, u)
+	if u.GetName() == "" {
+		return nil, rest.NewBadRequest("name is required")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := trackerKey(mapping, namespace, u.GetName())
+	if _, ok := t.objects[key]; ok {
+		return nil, rest.NewAlreadyExists(mapping.QualifiedResource(), u.GetName())
+	}
+	t.rv++
+	u.SetResourceVersion(strconv.FormatInt(t.rv, 10))
+	t.objects[key] = u.Object
+	t.notify(watch.Added, mapping, namespace, u.Object)
+	return withVersion(mapping, u.Object), nil
+}
+
+// 替换对象，obj中带有resourceVersion时必须与当前版本一致
+func (t *ObjectTracker) Update(mapping *rest.RESTMapping, namespace string, obj map[string]interface{}) (map[string]interface{}, error) {
+	u := (&resource.Unstructured{Object: obj}).DeepCopy()
+	namespace = objectNamespace(mapping, namespace, u)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := trackerKey(mapping, namespace, u.GetName())
+	current, ok := t.objects[key]
+	if !ok {
+		return nil, rest.NewNotFound(mapping.QualifiedResource(), u.GetName())
+	}
+	currentVersion := (&resource.Unstructured{Object: current}).GetResourceVersion()
+	if rv := u.GetResourceVersion(); rv != "" && rv != currentVersion {
+		return nil, rest.NewConflict(mapping.QualifiedResource(), u.GetName())
+	}
+	t.rv++
+	u.SetResourceVersion(strconv.FormatInt(t.rv, 10))
+	t.objects[key] = u.Object
+	t.notify(watch.Modified, mapping, namespace, u.Object)
+	return withVersion(mapping, u.Object), nil
+}
+
+// 在当前对象上应用patch，apply类型按merge patch处理，对象不存在时创建
+func (t *ObjectTracker) Patch(mapping *rest.RESTMapping, namespace, name string, pt rest.PatchType, data []byte) (map[string]interface{}, error) {
+	if pt == rest.ApplyPatchType {
+		applied, err := resource.DecodeMap(data)
+		if err != nil {
+			return nil, rest.NewBadRequest(err.Error())
+		}
+		if _, err := t.Get(mapping, namespace, name); rest.IsNotFound(err) {
+			return t.Create(mapping, namespace, applied)
+		}
+		if data, err = json.Marshal(applied); err != nil {
+			return nil, err
+		}
+		pt = rest.MergePatchType
+	}
+	current, err := t.Get(mapping, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(pt, doc, data)
+	if err != nil {
+		return nil, rest.NewBadRequest(err.Error())
+	}
+	obj, err := resource.DecodeMap(patched)
+	if err != nil {
+		return nil, err
+	}
+	return t.Update(mapping, namespace, obj)
+}
+
+func (t *ObjectTracker) Delete(mapping *rest.RESTMapping, namespace, name string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := trackerKey(mapping, namespace, name)
+	obj, ok := t.objects[key]
+	if !ok {
+		return rest.NewNotFound(mapping.QualifiedResource(), name)
+	}
+	delete(t.objects, key)
+	t.notify(watch.Deleted, mapping, namespace, obj)
+	return nil
+}
+
+// 监听对象的变化，事件中的对象为*resource.Unstructured，namespace为空时监听所有命名空间
+// 只发送此后的变化，接收方处理不及时导致缓存满时关闭该watch
+func (t *ObjectTracker) Watch(mapping *rest.RESTMapping, namespace, labelSelector, fieldSelector string) (watch.Interface, error) {
+	label, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, rest.NewBadRequest(err.Error())
+	}
+	field, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, rest.NewBadRequest(err.Error())
+	}
+	if !mapping.Namespaced {
+		namespace = ""
+	}
+	w := &trackerWatcher{
+		tracker:   t,
+		group:     mapping.Group,
+		resource:  mapping.Resource,
+		namespace: namespace,
+		label:     label,
+		field:     field,
+		result:    make(chan watch.Event, watchBufferSize),
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.watchers[w] = true
+	return w, nil
+}
+
+// 需在持有lock时调用
+func (t *ObjectTracker) notify(eventType watch.EventType, mapping *rest.RESTMapping, namespace string, obj map[string]interface{}) {
+	for w := range t.watchers {
+		if w.group != mapping.Group || w.resource != mapping.Resource {
+			continue
+		}
+		if w.namespace != "" && w.namespace != namespace {
+			continue
+		}
+		u := &resource.Unstructured{Object: withVersion(mapping, obj)}
+		if !w.label.Matches(labels.Set(u.GetLabels())) || !w.field.Matches(objectFields(u.Object)) {
+			continue
+		}
+		select {
+		case w.result <- watch.Event{Type: eventType, Object: u}:
+		default:
+			t.removeWatcher(w)
+		}
+	}
+}
+
+func (t *ObjectTracker) removeWatcher(w *trackerWatcher) {
+	if t.watchers[w] {
+		delete(t.watchers, w)
+		close(w.result)
+	}
+}
+
+// 每个watch缓存的事件数
+const watchBufferSize = 100
+
+type trackerWatcher struct {
+	tracker   *ObjectTracker
+	group     string
+	resource  string
+	namespace string
+	label     labels.Selector
+	field     fields.Selector
+	result    chan watch.Event
+}
+
+func (w *trackerWatcher) Stop() {
+	w.tracker.lock.Lock()
+	defer w.tracker.lock.Unlock()
+	w.tracker.removeWatcher(w)
+}
+
+func (w *trackerWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func trackerKey(mapping *rest.RESTMapping, namespace, name string) string {
+	if !mapping.Namespaced {
+		namespace = ""
+	}
+	return mapping.Group + "/" + mapping.Resource + "/" + namespace + "/" + name
+}
+
+// 确定对象的命名空间并写回对象，集群级资源去掉命名空间
+func objectNamespace(mapping *rest.RESTMapping, namespace string, u *resource.Unstructured) string {
+	if !mapping.Namespaced {
+		resource.RemoveNestedField(u.Object, "metadata", "namespace")
+		return ""
+	}
+	if namespace == "" {
+		namespace = u.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	u.SetNamespace(namespace)
+	return namespace
+}
+
+// 按请求的版本返回对象的副本
+func withVersion(mapping *rest.RESTMapping, obj map[string]interface{}) map[string]interface{} {
+	u := (&resource.Unstructured{Object: obj}).DeepCopy()
+	u.SetApiVersion(mapping.GroupVersion())
+	u.SetKind(mapping.Kind)
+	return u.Object
+}
+
+// 资源结构体、Unstructured或map转换为map
+func toObject(obj interface{}) (map[string]interface{}, error) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		return (&resource.Unstructured{Object: o}).DeepCopy().Object, nil
+	case *resource.Unstructured:
+		return o.DeepCopy().Object, nil
+	case nil:
+		return nil, fmt.Errorf("object is nil")
+	}
+	return resource.ToManifest(obj)
+}
+
+// 对象中所有非列表的标量字段，用于字段选择器
+func objectFields(obj map[string]interface{}) fields.Set {
+	set := fields.Set{}
+	var flatten func(prefix string, v interface{})
+	flatten = func(prefix string, v interface{}) {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			for k, item := range val {
+				if prefix != "" {
+					k = prefix + "." + k
+				}
+				flatten(k, item)
+			}
+		case []interface{}, nil:
+		default:
+			set[prefix] = fmt.Sprint(val)
+		}
+	}
+	flatten("", obj)
+	return set
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+// 分页时默认每页的数量
+const DefaultPageSize = 500
+
+// list请求的参数，Namespace为空时列出所有命名空间的对象
+type ListOptions struct {
+	Namespace       string
+	LabelSelector   string
+	FieldSelector   string
+	Limit           int64  // 每页最多返回的数量，0表示不分页
+	Continue        string // 上一页返回的continue
+	ResourceVersion string
+}
+
+// list返回的一页数据，Items为每个对象的原始json
+type ListPage struct {
+	ApiVersion string
+	Kind       string
+	Metadata   resource.ListMeta
+	Items      []json.RawMessage
+
+	// continue过期后重新全量获取时为true，此前收到的页已失效，调用方应丢弃
+	Restarted bool `json:"-"`
+}
+
+// 将第i个对象解码到obj
+func (p *ListPage) DecodeItem(i int, obj interface{}) (*resource.ObjectMeta, error) {
+	if i < 0 || i >= len(p.Items) {
+		return nil, errors.New("item index out of range")
+	}
+	return decodeObject(p.Items[i], obj)
+}
+
+// 获取一页对象
+func (c *Client) List(ctx context.Context, apiVersion, kind string, opts ListOptions) (*ListPage, error) {
+	mapping, err := c.mapper.RESTMapping(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(mapping.ResourcePath(opts.Namespace, ""))
+	req.SetContext(ctx)
+	req.SetLabelSelector(opts.LabelSelector)
+	req.SetFieldSelector(opts.FieldSelector)
+	if opts.Limit > 0 {
+		req.SetQuery("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	req.SetQuery("continue", opts.Continue)
+	req.SetQuery("resourceVersion", opts.ResourceVersion)
+
+	data, err := readResponse(req.Get())
+	if err != nil {
+		return nil, err
+	}
+	page := &ListPage{}
+	if err := json.Unmarshal(data, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// 获取一页对象，Client和client/fake均实现了该接口
+type Lister interface {
+	List(ctx context.Context, apiVersion, kind string, opts ListOptions) (*ListPage, error)
+}
+
+// 按页获取对象，自动跟随continue
+type Pager struct {
+	client     Lister
+	apiVersion string
+	kind       string
+	options    ListOptions
+
+	PageSize int64 // 每页的数量，默认为DefaultPageSize
+	// continue过期（410）时是否不分页重新获取全部对象，为false时直接返回错误
+	FullListIfExpired bool
+}
+
+func (c *Client) NewPager(apiVersion, kind string, opts ListOptions) *Pager {
+	return NewListPager(c, apiVersion, kind, opts)
+}
+
+func NewListPager(lister Lister, apiVersion, kind string, opts ListOptions) *Pager {
+	return &Pager{
+		client:            lister,
+		apiVersion:        apiVersion,
+		kind:              kind,
+		options:           opts,
+		PageSize:          DefaultPageSize,
+		FullListIfExpired: true,
+	}
+}
+
+// 依次对每一页调用fn，fn返回错误时停止
+func (p *Pager) EachPage(ctx context.Context, fn func(page *ListPage) error) error {
+	opts := p.options
+	if opts.Limit == 0 {
+		opts.Limit = p.PageSize
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := p.client.List(ctx, p.apiVersion, p.kind, opts)
+		if err != nil {
+			if opts.Continue == "" || !p.FullListIfExpired || !rest.IsGone(err) {
+				return err
+			}
+			// continue已过期，无法从中断处继续，改为一次性获取全部对象
+			full := p.options
+			full.Limit = 0
+			full.Continue = ""
+			if page, err = p.client.List(ctx, p.apiVersion, p.kind, full); err != nil {
+				return err
+			}
+			page.Restarted = true
+			return fn(page)
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if page.Metadata.Continue == "" {
+			return nil
+		}
+		opts.Continue = page.Metadata.Continue
+	}
+}
+
+// 依次对每个对象的原始json调用fn，fn返回错误时停止
+// continue过期重新获取时会再次收到此前的对象
+func (p *Pager) EachItem(ctx context.Context, fn func(item json.RawMessage) error) error {
+	return p.EachPage(ctx, func(page *ListPage) error {
+		for _, item := range page.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 获取全部对象并合并为一页，ResourceVersion为最后一页的版本
+func (p *Pager) List(ctx context.Context) (*ListPage, error) {
+	var result *ListPage
+	err := p.EachPage(ctx, func(page *ListPage) error {
+		if result == nil || page.Restarted {
+			result = page
+			return nil
+		}
+		result.Items = append(result.Items, page.Items...)
+		result.Metadata = page.Metadata
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Restarted = false
+	result.Metadata.Continue = ""
+	result.Metadata.RemainingItemCount = nil
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	v1 "k8s-client-go/resource/core/v1"
+)
+
+// 模拟分页返回pod，expireAt不为空时该continue返回410
+func pagedPods(t *testing.T, total int, expireAt string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("labelSelector") != "app=web" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		if expireAt != "" && query.Get("continue") == expireAt {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"kind":"Status","status":"Failure","reason":"Expired","code":410}`))
+			return
+		}
+		start, _ := strconv.Atoi(query.Get("continue"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		end := total
+		if limit > 0 && start+limit < total {
+			end = start + limit
+		}
+		var items []string
+		for i := start; i < end; i++ {
+			items = append(items, fmt.Sprintf(`{"metadata":{"name":"pod-%d"}}`, i))
+		}
+		next := ""
+		if end < total {
+			next = strconv.Itoa(end)
+		}
+		fmt.Fprintf(w, `{"kind":"PodList","apiVersion":"v1","metadata":{"resourceVersion":"7","continue":%q},"items":[%s]}`, next, strings.Join(items, ","))
+	}
+}
+
+func TestPager_EachPage(t *testing.T) {
+	c := newTestClient(t, pagedPods(t, 5, ""))
+	pager := c.NewPager("v1", "Pod", ListOptions{Namespace: "default", LabelSelector: "app=web"})
+	pager.PageSize = 2
+
+	var sizes []int
+	var names []string
+	err := pager.EachPage(context.Background(), func(page *ListPage) error {
+		sizes = append(sizes, len(page.Items))
+		for i := range page.Items {
+			pod := v1.NewResPod("")
+			meta, err := page.DecodeItem(i, pod)
+			if err != nil {
+				return err
+			}
+			names = append(names, meta.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sizes) != "[2 2 1]" || names[4] != "pod-4" {
+		t.Fatalf("unexpected pages %v %v", sizes, names)
+	}
+}
+
+func TestPager_ListExpired(t *testing.T) {
+	c := newTestClient(t, pagedPods(t, 5, "4"))
+	pager := c.NewPager("v1", "Pod", ListOptions{Namespace: "default", LabelSelector: "app=web"})
+	pager.PageSize = 2
+
+	list, err := pager.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 5 || list.Metadata.ResourceVersion != "7" || list.Metadata.Continue != "" {
+		t.Fatalf("unexpected list %d items, %+v", len(list.Items), list.Metadata)
+	}
+
+	pager.FullListIfExpired = false
+	if _, err := pager.List(context.Background()); err == nil {
+		t.Fatal("expected expired error")
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+// 获取pod日志的参数，指针字段为nil时不设置
+type PodLogOptions struct {
+	Container    string // pod中只有一个容器时可以为空
+	Follow       bool   // 持续输出新的日志，直到容器结束或调用方关闭
+	Previous     bool   // 上一次退出的容器的日志
+	SinceSeconds *int64 // 最近若干秒内的日志，与SinceTime只能设置一个
+	SinceTime    *time.Time
+	TailLines    *int64 // 最后若干行
+	Timestamps   bool   // 每行前加上RFC3339格式的时间
+	LimitBytes   *int64 // 最多返回的字节数
+}
+
+func (o PodLogOptions) validate() error {
+	if o.SinceSeconds != nil && o.SinceTime != nil {
+		return errors.New("at most one of sinceSeconds or sinceTime may be specified")
+	}
+	if o.SinceSeconds != nil && *o.SinceSeconds < 1 {
+		return errors.New("sinceSeconds must be greater than 0")
+	}
+	if o.TailLines != nil && *o.TailLines < 0 {
+		return errors.New("tailLines must be greater than or equal to 0")
+	}
+	if o.LimitBytes != nil && *o.LimitBytes < 1 {
+		return errors.New("limitBytes must be greater than 0")
+	}
+	return nil
+}
+
+// 请求参数
+func (o PodLogOptions) query() map[string]string {
+	query := map[string]string{}
+	if o.Container != "" {
+		query["container"] = o.Container
+	}
+	if o.Follow {
+		query["follow"] = "true"
+	}
+	if o.Previous {
+		query["previous"] = "true"
+	}
+	if o.SinceSeconds != nil {
+		query["sinceSeconds"] = strconv.FormatInt(*o.SinceSeconds, 10)
+	}
+	if o.SinceTime != nil {
+		query["sinceTime"] = o.SinceTime.UTC().Format(time.RFC3339)
+	}
+	if o.TailLines != nil {
+		query["tailLines"] = strconv.FormatInt(*o.TailLines, 10)
+	}
+	if o.Timestamps {
+		query["timestamps"] = "true"
+	}
+	if o.LimitBytes != nil {
+		query["limitBytes"] = strconv.FormatInt(*o.LimitBytes, 10)
+	}
+	return query
+}
+
+// 获取pod的日志，调用方读取完毕后需关闭返回的ReadCloser
+// Follow为true时请求会一直保持，RESTClient的http.Client若设置了Timeout会导致提前结束
+func (c *Client) GetLogs(ctx context.Context, namespace, name string, opts PodLogOptions) (io.ReadCloser, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	path, _, err := c.resourcePath(resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: namespace, Name: name}, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path + "/log")
+	req.SetContext(ctx)
+	for key, value := range opts.query() {
+		req.SetQuery(key, value)
+	}
+	resp, err := req.Get()
+	if err != nil {
+		return nil, err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// 获取命名空间中所有匹配selector的pod的日志并写入out，namespace为空时为所有命名空间，每行前加上 [pod/container] 前缀
+// opts.Container为空时输出每个pod的所有容器，Follow为true时直到所有日志流结束或ctx取消才返回
+// 各日志流的行交错输出，但不会拆开同一行；任一日志流出错时停止其它日志流并返回该错误
+func StreamLogs(ctx context.Context, c Interface, namespace, selector string, opts PodLogOptions, out io.Writer) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	type stream struct {
+		namespace string
+		pod       string
+		container string
+	}
+	var streams []stream
+	pager := NewListPager(c, "v1", resource.RESOURCE_POD, ListOptions{Namespace: namespace, LabelSelector: selector})
+	err := pager.EachPage(ctx, func(page *ListPage) error {
+		if page.Restarted {
+			streams = nil
+		}
+		for i := range page.Items {
+			pod := &resource.Unstructured{}
+			if _, err := page.DecodeItem(i, pod); err != nil {
+				return err
+			}
+			if opts.Container != "" {
+				streams = append(streams, stream{pod.GetNamespace(), pod.GetName(), opts.Container})
+				continue
+			}
+			containers, _, _ := resource.NestedSlice(pod.Object, "spec", "containers")
+			for _, container := range containers {
+				if m, ok := container.(map[string]interface{}); ok {
+					name, _ := m["name"].(string)
+					streams = append(streams, stream{pod.GetNamespace(), pod.GetName(), name})
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(streams) == 0 {
+		return fmt.Errorf("no pods found for selector %q", selector)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	errOnce := sync.Once{}
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, s := range streams {
+		wg.Add(1)
+		go func(s stream) {
+			defer wg.Done()
+			streamOpts := opts
+			streamOpts.Container = s.container
+			rc, err := c.GetLogs(ctx, s.namespace, s.pod, streamOpts)
+			if err != nil {
+				fail(fmt.Errorf("%s/%s: %v", s.pod, s.container, err))
+				return
+			}
+			defer rc.Close()
+			prefix := "[" + s.pod + "/" + s.container + "] "
+			reader := bufio.NewReader(rc)
+			for {
+				line, err := reader.ReadString('\n')
+				if len(line) > 0 {
+					if line[len(line)-1] != '\n' {
+						line += "\n"
+					}
+					lock.Lock()
+					_, werr := io.WriteString(out, prefix+line)
+					lock.Unlock()
+					if werr != nil {
+						fail(werr)
+						return
+					}
+				}
+				if err != nil {
+					if err != io.EOF && ctx.Err() == nil {
+						fail(fmt.Errorf("%s/%s: %v", s.pod, s.container, err))
+					}
+					return
+				}
+			}
+		}(s)
+	}
+	wg.Wait()
+	return firstErr
+}
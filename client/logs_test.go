@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_GetLogs(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/test/pods/web/log" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("container") != "nginx" || query.Get("follow") != "true" || query.Get("tailLines") != "10" ||
+			query.Get("sinceTime") != "2020-01-02T03:04:05Z" || query.Get("previous") != "" {
+			t.Errorf("unexpected query %v", query)
+		}
+		w.Write([]byte("line 1\nline 2\n"))
+	})
+
+	tail := int64(10)
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rc, err := c.GetLogs(context.Background(), "test", "web", PodLogOptions{Container: "nginx", Follow: true, TailLines: &tail, SinceTime: &since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil || string(data) != "line 1\nline 2\n" {
+		t.Fatalf("unexpected logs %q %v", data, err)
+	}
+
+	seconds := int64(5)
+	if _, err := c.GetLogs(context.Background(), "test", "web", PodLogOptions{SinceSeconds: &seconds, SinceTime: &since}); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestStreamLogs_FollowStopsOnError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[` +
+				`{"metadata":{"name":"web-0","namespace":"default"},"spec":{"containers":[{"name":"nginx"}]}},` +
+				`{"metadata":{"name":"web-1","namespace":"default"},"spec":{"containers":[{"name":"nginx"}]}}]}`))
+		case "/api/v1/namespaces/default/pods/web-0/log":
+			// 持续输出的日志流，直到请求被取消
+			w.Write([]byte("line 1\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "/api/v1/namespaces/default/pods/web-1/log":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"kind":"Status","status":"Failure","message":"container nginx is waiting to start","reason":"BadRequest","code":400}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := StreamLogs(ctx, c, "default", "app=web", PodLogOptions{Follow: true}, ioutil.Discard)
+	if err == nil || !strings.Contains(err.Error(), "web-1/nginx") || ctx.Err() != nil {
+		t.Fatalf("expected web-1 error before the deadline, got %v", err)
+	}
+}
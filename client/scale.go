@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s-client-go/resource"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/retry"
+)
+
+// 支持scale子资源的类型及未指定apiVersion时使用的版本
+var scalableKinds = map[string]string{
+	resource.RESOURCE_DEPLOYMENT:             "apps/v1",
+	resource.RESOURCE_REPLICASET:             "apps/v1",
+	resource.RESOURCE_STATEFULE_SET:          "apps/v1",
+	resource.RESOURCE_REPLICATION_CONTROLLER: "v1",
+}
+
+// ScaleTo等待副本数变化时轮询的间隔
+var scalePollInterval = time.Second
+
+// 补全scale子资源请求的apiVersion，kind不支持scale时返回错误
+func ScaleObjectKey(key resource.ObjectKey) (resource.ObjectKey, error) {
+	apiVersion, ok := scalableKinds[key.Kind]
+	if !ok {
+		return key, fmt.Errorf("%s does not support the scale subresource", key.Kind)
+	}
+	if key.ApiVersion == "" {
+		key.ApiVersion = apiVersion
+	}
+	return key, nil
+}
+
+// 获取key指定对象的scale子资源，key.ApiVersion为空时使用默认版本
+func (c *Client) GetScale(ctx context.Context, key resource.ObjectKey) (*autoscalingv1.Scale, error) {
+	key, err := ScaleObjectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path + "/scale")
+	req.SetContext(ctx)
+	data, err := readResponse(req.Get())
+	if err != nil {
+		return nil, err
+	}
+	return decodeScale(data)
+}
+
+// 更新副本数，scale.Metadata.ResourceVersion不为空时服务端会检查版本
+func (c *Client) UpdateScale(ctx context.Context, key resource.ObjectKey, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	key, err := ScaleObjectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	body, err := encodeScale(key, scale)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path + "/scale")
+	req.SetContext(ctx)
+	data, err := readResponse(req.Put(body, map[string]string{"Content-Type": "application/json"}))
+	if err != nil {
+		return nil, err
+	}
+	return decodeScale(data)
+}
+
+// 补全scale的apiVersion、kind、name和namespace并转换为json，replicas为0时也会保留
+func encodeScale(key resource.ObjectKey, scale *autoscalingv1.Scale) ([]byte, error) {
+	if scale == nil {
+		return nil, errors.New("scale is nil")
+	}
+	s := *scale
+	s.ApiVersion, s.Kind = "autoscaling/v1", "Scale"
+	s.Metadata.Name = key.Name
+	if s.Metadata.Namespace == "" {
+		s.Metadata.Namespace = key.Namespace
+	}
+	return resource.ToJson(&s)
+}
+
+// 部分字段类型不一致时返回*resource.PartialDecodeError，同时返回已解码其它字段的scale
+func decodeScale(data []byte) (*autoscalingv1.Scale, error) {
+	scale := autoscalingv1.NewScale()
+	if err := resource.DecodeInto(data, scale); err != nil {
+		if !resource.IsPartialDecode(err) {
+			return nil, err
+		}
+		return scale, err
+	}
+	return scale, nil
+}
+
+// 将kind（Deployment、ReplicaSet、StatefulSet或ReplicationController）的副本数设为replicas，
+// 并等待status中的副本数达到replicas，直到ctx取消
+func ScaleTo(ctx context.Context, c Interface, kind, namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return errors.New("replicas must be non-negative")
+	}
+	key := resource.ObjectKey{Kind: kind, Namespace: namespace, Name: name}
+	err := retry.OnErrorContext(ctx, retry.DefaultRetry, rest.IsConflict, func() error {
+		scale, err := c.GetScale(ctx, key)
+		if err != nil {
+			return err
+		}
+		if scale.Spec.Replicas == replicas {
+			return nil
+		}
+		scale.Spec.Replicas = replicas
+		_, err = c.UpdateScale(ctx, key, scale)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(scalePollInterval)
+	defer ticker.Stop()
+	for {
+		scale, err := c.GetScale(ctx, key)
+		if err != nil {
+			return err
+		}
+		if scale.Status.Replicas == replicas {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s to reach %d replicas (current %d): %v", key, replicas, scale.Status.Replicas, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"k8s-client-go/resource"
+)
+
+func TestClient_GetScale(t *testing.T) {
+	body := `{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"web","namespace":"default"},"spec":{"replicas":3},"status":{"replicas":2,"selector":"app=web"}}`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/apps/v1/namespaces/default/deployments/web/scale" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: resource.RESOURCE_DEPLOYMENT, Namespace: "default", Name: "web"}
+
+	scale, err := c.GetScale(context.Background(), key)
+	if err != nil || scale.Spec.Replicas != 3 || scale.Status.Selector != "app=web" {
+		t.Fatalf("unexpected scale %+v, err %v", scale, err)
+	}
+
+	// 类型不一致的字段不能被静默丢弃
+	body = `{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"web"},"spec":{"replicas":"3"}}`
+	if _, err := c.GetScale(context.Background(), key); !resource.IsPartialDecode(err) {
+		t.Fatalf("expected partial decode error, got %v", err)
+	}
+}
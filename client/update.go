@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"k8s-client-go/patch"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/retry"
+)
+
+// 获取对象并解码到obj，返回其中的metadata
+func (c *Client) Get(ctx context.Context, key resource.ObjectKey, obj interface{}) (*resource.ObjectMeta, error) {
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	data, err := readResponse(req.Get())
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(data, obj)
+}
+
+// 用obj替换服务端的对象，metadata.resourceVersion不为空时服务端会检查版本，不一致时返回409冲突
+func (c *Client) Update(ctx context.Context, obj resource.IResource) (*resource.ObjectMeta, error) {
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.put(ctx, key, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(data, obj)
+}
+
+// 读取-修改-写入，遇到409冲突时重新获取对象并按退避重试
+// 每次尝试都会将服务端的对象解码到obj后调用mutate，成功后obj为更新后的对象
+// 提交的内容以服务端返回的原始对象为基础，只改动mutate修改过的字段，结构体中未定义的字段不会丢失
+func (c *Client) UpdateWithRetry(ctx context.Context, key resource.ObjectKey, obj resource.IResource, mutate func(obj resource.IResource) error) (*resource.ObjectMeta, error) {
+	if mutate == nil {
+		return nil, errors.New("mutate is nil")
+	}
+	var meta *resource.ObjectMeta
+	err := retry.OnErrorContext(ctx, retry.DefaultRetry, rest.IsConflict, func() error {
+		var err error
+		meta, err = c.updateOnce(ctx, key, obj, mutate)
+		return err
+	})
+	return meta, err
+}
+
+func (c *Client) updateOnce(ctx context.Context, key resource.ObjectKey, obj resource.IResource, mutate func(obj resource.IResource) error) (*resource.ObjectMeta, error) {
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	current, err := readResponse(req.Get())
+	if err != nil {
+		return nil, err
+	}
+
+	resetObject(obj)
+	meta, err := decodeObject(current, obj)
+	if err != nil {
+		return nil, err
+	}
+	before, err := resource.ToJson(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutate(obj); err != nil {
+		return nil, err
+	}
+	after, err := resource.ToJson(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := patch.CreateMergePatch(before, after)
+	if err != nil {
+		return nil, err
+	}
+	if string(p) == "{}" {
+		return meta, nil
+	}
+	body, err := patch.ApplyMergePatch(current, p)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.put(ctx, key, body)
+	if err != nil {
+		return nil, err
+	}
+	resetObject(obj)
+	return decodeObject(data, obj)
+}
+
+// 清空obj中已有的字段，避免重复解码时残留旧的map元素
+func resetObject(obj interface{}) {
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Ptr && !v.IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
+}
+
+func (c *Client) put(ctx context.Context, key resource.ObjectKey, body []byte) ([]byte, error) {
+	path, _, err := c.resourcePath(key, true)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(path)
+	req.SetContext(ctx)
+	return readResponse(req.Put(body, map[string]string{"Content-Type": "application/json"}))
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	"k8s-client-go/rest"
+)
+
+func TestClient_UpdateWithRetry(t *testing.T) {
+	version := 1
+	puts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/apps/v1/namespaces/default/deployments/web" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default","resourceVersion":"` +
+				string(rune('0'+version)) + `","labels":{"app":"web"}},"spec":{"replicas":1,"progressDeadlineSeconds":600}}`))
+		case http.MethodPut:
+			puts++
+			body, _ := ioutil.ReadAll(r.Body)
+			sent := map[string]interface{}{}
+			json.Unmarshal(body, &sent)
+			meta := sent["metadata"].(map[string]interface{})
+			// 第一次提交时对象已被其它客户端修改
+			if puts == 1 {
+				version++
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"kind":"Status","status":"Failure","reason":"Conflict","code":409}`))
+				return
+			}
+			if meta["resourceVersion"] != "2" {
+				t.Errorf("unexpected resourceVersion %v", meta["resourceVersion"])
+			}
+			if spec := sent["spec"].(map[string]interface{}); spec["progressDeadlineSeconds"] == nil || spec["replicas"] != float64(3) {
+				t.Errorf("unexpected spec %v", spec)
+			}
+			meta["resourceVersion"] = "3"
+			data, _ := json.Marshal(sent)
+			w.Write(data)
+		}
+	})
+
+	deploy := appsv1.NewResDeployment()
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	meta, err := c.UpdateWithRetry(context.Background(), key, deploy, func(obj resource.IResource) error {
+		replicas := int32(3)
+		obj.(*appsv1.ResDeployment).Spec.Replicas = &replicas
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if puts != 2 || meta.ResourceVersion != "3" || *deploy.Spec.Replicas != 3 {
+		t.Fatalf("unexpected result after %d puts: %+v", puts, meta)
+	}
+}
+
+func TestClient_UpdateWithRetryGivesUp(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+	})
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	_, err := c.UpdateWithRetry(context.Background(), key, appsv1.NewResDeployment(), func(obj resource.IResource) error {
+		obj.(*appsv1.ResDeployment).Metadata.Labels = map[string]string{"app": "web"}
+		return nil
+	})
+	if !rest.IsConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx, key, nil); err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+}
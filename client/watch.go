@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 监听对象的变化，事件中的对象为*resource.Unstructured
+// opts.ResourceVersion为空时先以ADDED事件返回现有对象，Limit和Continue不生效
+func (c *Client) Watch(ctx context.Context, apiVersion, kind string, opts ListOptions) (watch.Interface, error) {
+	mapping, err := c.mapper.RESTMapping(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	req := c.rest.NewRequest(mapping.ResourcePath(opts.Namespace, ""))
+	req.SetContext(ctx)
+	req.SetLabelSelector(opts.LabelSelector)
+	req.SetFieldSelector(opts.FieldSelector)
+	req.SetQuery("resourceVersion", opts.ResourceVersion)
+	req.SetQuery("watch", strconv.FormatBool(true))
+	resp, err := req.Get()
+	if err != nil {
+		return nil, err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	return watch.NewStreamWatcher(resp.Body, func(_ watch.EventType, raw json.RawMessage) (interface{}, error) {
+		obj := &resource.Unstructured{}
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}), nil
+}
@@ -0,0 +1,94 @@
+package clientset
+
+import (
+	"k8s-client-go/client"
+	appsv1 "k8s-client-go/clientset/typed/apps/v1"
+	appsv1beta1 "k8s-client-go/clientset/typed/apps/v1beta1"
+	autoscalingv2beta1 "k8s-client-go/clientset/typed/autoscaling/v2beta1"
+	batchv1 "k8s-client-go/clientset/typed/batch/v1"
+	batchv1beta1 "k8s-client-go/clientset/typed/batch/v1beta1"
+	corev1 "k8s-client-go/clientset/typed/core/v1"
+	extensionsv1beta1 "k8s-client-go/clientset/typed/extensions/v1beta1"
+	networkingv1 "k8s-client-go/clientset/typed/networking/v1"
+	settingsv1alpha1 "k8s-client-go/clientset/typed/settings/v1alpha1"
+	storagev1 "k8s-client-go/clientset/typed/storage/v1"
+	"k8s-client-go/rest"
+)
+
+// 按组和版本划分的类型化客户端，对象为resource下对应的结构体
+type Interface interface {
+	CoreV1() corev1.CoreV1Interface
+	AppsV1() appsv1.AppsV1Interface
+	AppsV1beta1() appsv1beta1.AppsV1beta1Interface
+	ExtensionsV1beta1() extensionsv1beta1.ExtensionsV1beta1Interface
+	BatchV1() batchv1.BatchV1Interface
+	BatchV1beta1() batchv1beta1.BatchV1beta1Interface
+	AutoscalingV2beta1() autoscalingv2beta1.AutoscalingV2beta1Interface
+	NetworkingV1() networkingv1.NetworkingV1Interface
+	StorageV1() storagev1.StorageV1Interface
+	SettingsV1alpha1() settingsv1alpha1.SettingsV1alpha1Interface
+}
+
+type Clientset struct {
+	client client.Interface
+}
+
+var _ Interface = &Clientset{}
+
+// 基于任意client.Interface创建，测试时可传入client/fake
+func New(c client.Interface) *Clientset {
+	return &Clientset{client: c}
+}
+
+func NewForConfig(config *rest.Config) (*Clientset, error) {
+	c, err := client.NewClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// 底层的通用客户端
+func (c *Clientset) Client() client.Interface {
+	return c.client
+}
+
+func (c *Clientset) CoreV1() corev1.CoreV1Interface {
+	return corev1.NewForClient(c.client)
+}
+
+func (c *Clientset) AppsV1() appsv1.AppsV1Interface {
+	return appsv1.NewForClient(c.client)
+}
+
+func (c *Clientset) AppsV1beta1() appsv1beta1.AppsV1beta1Interface {
+	return appsv1beta1.NewForClient(c.client)
+}
+
+func (c *Clientset) ExtensionsV1beta1() extensionsv1beta1.ExtensionsV1beta1Interface {
+	return extensionsv1beta1.NewForClient(c.client)
+}
+
+func (c *Clientset) BatchV1() batchv1.BatchV1Interface {
+	return batchv1.NewForClient(c.client)
+}
+
+func (c *Clientset) BatchV1beta1() batchv1beta1.BatchV1beta1Interface {
+	return batchv1beta1.NewForClient(c.client)
+}
+
+func (c *Clientset) AutoscalingV2beta1() autoscalingv2beta1.AutoscalingV2beta1Interface {
+	return autoscalingv2beta1.NewForClient(c.client)
+}
+
+func (c *Clientset) NetworkingV1() networkingv1.NetworkingV1Interface {
+	return networkingv1.NewForClient(c.client)
+}
+
+func (c *Clientset) StorageV1() storagev1.StorageV1Interface {
+	return storagev1.NewForClient(c.client)
+}
+
+func (c *Clientset) SettingsV1alpha1() settingsv1alpha1.SettingsV1alpha1Interface {
+	return settingsv1alpha1.NewForClient(c.client)
+}
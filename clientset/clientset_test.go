@@ -0,0 +1,170 @@
+package clientset_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s-client-go/client"
+	"k8s-client-go/client/fake"
+	"k8s-client-go/clientset"
+	"k8s-client-go/fakeserver"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+func newDeployment(name string) *appsv1.ResDeployment {
+	deploy := appsv1.NewResDeployment()
+	deploy.Metadata.Name = name
+	deploy.Metadata.Labels = map[string]string{"app": name}
+	replicas := int32(2)
+	deploy.Spec.Replicas = &replicas
+	return deploy
+}
+
+func TestClientset_Deployments(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	cs, err := clientset.NewForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	deployments := cs.AppsV1().Deployments("test")
+
+	created, err := deployments.Create(ctx, newDeployment("web"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Metadata.Namespace != "test" || *created.Spec.Replicas != 2 {
+		t.Fatalf("unexpected created object %+v", created)
+	}
+	if _, err := cs.AppsV1().Deployments("other").Create(ctx, created); err == nil {
+		t.Fatal("expected namespace mismatch error")
+	}
+
+	patched, err := deployments.Patch(ctx, "web", rest.MergePatchType, []byte(`{"spec":{"replicas":3}}`))
+	if err != nil || *patched.Spec.Replicas != 3 {
+		t.Fatalf("unexpected patch result %v %+v", err, patched)
+	}
+	list, err := deployments.List(ctx, client.ListOptions{LabelSelector: "app=web"})
+	if err != nil || len(list.Items) != 1 || list.Items[0].Metadata.Name != "web" || list.Metadata.ResourceVersion == "" {
+		t.Fatalf("unexpected list %v %+v", err, list)
+	}
+	if err := deployments.Delete(ctx, "web", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := deployments.Get(ctx, "web"); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestClientset_CreateSendsSetFields(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer server.Close()
+	cs, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy := newDeployment("web")
+	deploy.AddContainer(resource.NewContainer("web", "nginx"))
+	if _, err := cs.AppsV1().Deployments("test").Create(context.Background(), deploy); err != nil {
+		t.Fatal(err)
+	}
+	// NewContainer未设置的探针、生命周期钩子和securityContext以及nil的selector不发送
+	expected := `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"labels":{"app":"web"},"name":"web","namespace":"test"},` +
+		`"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"nginx","name":"web"}]}}}}`
+	if string(body) != expected {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
+
+func TestClientset_Endpoints(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	cs, err := clientset.NewForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	endpoints := cs.CoreV1().Endpoints("test")
+
+	ep := corev1.NewResEndpoints()
+	ep.SetMetadataName("web")
+	ep.Subsets = []corev1.Subset{{Addresses: []corev1.SubsetAddr{{Ip: "10.0.0.1"}}, Ports: []corev1.SubsetPort{{Port: 80}}}}
+	if _, err := endpoints.Create(ctx, ep); err != nil {
+		t.Fatal(err)
+	}
+	got, err := endpoints.Get(ctx, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Metadata.Namespace != "test" || len(got.Subsets) != 1 || got.Subsets[0].Addresses[0].Ip != "10.0.0.1" || got.Subsets[0].Ports[0].Port != 80 {
+		t.Fatalf("unexpected endpoints %+v", got)
+	}
+	got.Subsets[0].Addresses = append(got.Subsets[0].Addresses, corev1.SubsetAddr{Ip: "10.0.0.2"})
+	if updated, err := endpoints.Update(ctx, got); err != nil || len(updated.Subsets[0].Addresses) != 2 {
+		t.Fatalf("unexpected update result %v %+v", err, updated)
+	}
+	if list, err := cs.CoreV1().Endpoints("").List(ctx, client.ListOptions{}); err != nil || len(list.Items) != 1 {
+		t.Fatalf("unexpected list %v %+v", err, list)
+	}
+	if err := endpoints.Delete(ctx, "web", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientset_PartialDecode(t *testing.T) {
+	deploy := resource.NewUnstructured("apps/v1", resource.RESOURCE_DEPLOYMENT)
+	deploy.SetName("web")
+	deploy.SetNamespace("default")
+	resource.SetNestedField(deploy.Object, "3", "spec", "replicas")
+	cs := clientset.New(fake.NewSimpleClient(deploy))
+
+	if _, err := cs.AppsV1().Deployments("default").Get(context.Background(), "web"); !resource.IsPartialDecode(err) {
+		t.Fatalf("expected partial decode error, got %v", err)
+	}
+}
+
+func TestClientset_WatchFake(t *testing.T) {
+	f := fake.NewSimpleClient()
+	cs := clientset.New(f)
+	ctx := context.Background()
+
+	w, err := cs.CoreV1().Pods("default").Watch(ctx, client.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	pod := corev1.NewResPod("web")
+	if _, err := cs.CoreV1().Pods("default").Create(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-w.ResultChan():
+		got, ok := event.Object.(*corev1.ResPod)
+		if event.Type != watch.Added || !ok || got.Metadata.Name != "web" {
+			t.Fatalf("unexpected event %v %#v", event.Type, event.Object)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	actions := f.Actions()
+	if len(actions) != 2 || actions[0].Verb != "watch" || actions[1].Verb != "create" || actions[1].Namespace != "default" {
+		t.Fatalf("unexpected actions %+v", actions)
+	}
+}
@@ -0,0 +1,32 @@
+package v1
+
+import "k8s-client-go/client"
+
+// apps/v1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type AppsV1Interface interface {
+	Deployments(namespace string) DeploymentInterface
+	ReplicaSets(namespace string) ReplicaSetInterface
+	StatefulSets(namespace string) StatefulSetInterface
+}
+
+type AppsV1Client struct {
+	client client.Interface
+}
+
+var _ AppsV1Interface = &AppsV1Client{}
+
+func NewForClient(c client.Interface) *AppsV1Client {
+	return &AppsV1Client{client: c}
+}
+
+func (c *AppsV1Client) Deployments(namespace string) DeploymentInterface {
+	return newDeployments(c.client, namespace)
+}
+
+func (c *AppsV1Client) ReplicaSets(namespace string) ReplicaSetInterface {
+	return newReplicaSets(c.client, namespace)
+}
+
+func (c *AppsV1Client) StatefulSets(namespace string) StatefulSetInterface {
+	return newStatefulSets(c.client, namespace)
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type ReplicaSetInterface interface {
+	Get(ctx context.Context, name string) (*appsv1.ResReplicaSet, error)
+	List(ctx context.Context, opts client.ListOptions) (*ReplicaSetList, error)
+	Create(ctx context.Context, replicaSet *appsv1.ResReplicaSet) (*appsv1.ResReplicaSet, error)
+	Update(ctx context.Context, replicaSet *appsv1.ResReplicaSet) (*appsv1.ResReplicaSet, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*appsv1.ResReplicaSet, error)
+	GetScale(ctx context.Context, name string) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, name string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type ReplicaSetList struct {
+	Metadata resource.ListMeta
+	Items    []*appsv1.ResReplicaSet
+}
+
+type replicaSets struct {
+	client typed.ResourceClient
+}
+
+func newReplicaSets(c client.Interface, namespace string) *replicaSets {
+	return &replicaSets{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return appsv1.NewResReplicaSet() },
+	}}
+}
+
+func (c *replicaSets) Get(ctx context.Context, name string) (*appsv1.ResReplicaSet, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResReplicaSet), nil
+}
+
+func (c *replicaSets) List(ctx context.Context, opts client.ListOptions) (*ReplicaSetList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &ReplicaSetList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*appsv1.ResReplicaSet))
+	}
+	return list, nil
+}
+
+func (c *replicaSets) Create(ctx context.Context, replicaSet *appsv1.ResReplicaSet) (*appsv1.ResReplicaSet, error) {
+	obj, err := c.client.Create(ctx, replicaSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResReplicaSet), nil
+}
+
+func (c *replicaSets) Update(ctx context.Context, replicaSet *appsv1.ResReplicaSet) (*appsv1.ResReplicaSet, error) {
+	obj, err := c.client.Update(ctx, replicaSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResReplicaSet), nil
+}
+
+func (c *replicaSets) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *replicaSets) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *replicaSets) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *replicaSets) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*appsv1.ResReplicaSet, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResReplicaSet), nil
+}
+
+func (c *replicaSets) GetScale(ctx context.Context, name string) (*autoscalingv1.Scale, error) {
+	return c.client.GetScale(ctx, name)
+}
+
+func (c *replicaSets) UpdateScale(ctx context.Context, name string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	return c.client.UpdateScale(ctx, name, scale)
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type StatefulSetInterface interface {
+	Get(ctx context.Context, name string) (*appsv1.ResStatefulSet, error)
+	List(ctx context.Context, opts client.ListOptions) (*StatefulSetList, error)
+	Create(ctx context.Context, statefulSet *appsv1.ResStatefulSet) (*appsv1.ResStatefulSet, error)
+	Update(ctx context.Context, statefulSet *appsv1.ResStatefulSet) (*appsv1.ResStatefulSet, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*appsv1.ResStatefulSet, error)
+	GetScale(ctx context.Context, name string) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, name string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type StatefulSetList struct {
+	Metadata resource.ListMeta
+	Items    []*appsv1.ResStatefulSet
+}
+
+type statefulSets struct {
+	client typed.ResourceClient
+}
+
+func newStatefulSets(c client.Interface, namespace string) *statefulSets {
+	return &statefulSets{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return appsv1.NewResStatefulSet() },
+	}}
+}
+
+func (c *statefulSets) Get(ctx context.Context, name string) (*appsv1.ResStatefulSet, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResStatefulSet), nil
+}
+
+func (c *statefulSets) List(ctx context.Context, opts client.ListOptions) (*StatefulSetList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &StatefulSetList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*appsv1.ResStatefulSet))
+	}
+	return list, nil
+}
+
+func (c *statefulSets) Create(ctx context.Context, statefulSet *appsv1.ResStatefulSet) (*appsv1.ResStatefulSet, error) {
+	obj, err := c.client.Create(ctx, statefulSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResStatefulSet), nil
+}
+
+func (c *statefulSets) Update(ctx context.Context, statefulSet *appsv1.ResStatefulSet) (*appsv1.ResStatefulSet, error) {
+	obj, err := c.client.Update(ctx, statefulSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResStatefulSet), nil
+}
+
+func (c *statefulSets) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *statefulSets) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *statefulSets) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *statefulSets) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*appsv1.ResStatefulSet, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*appsv1.ResStatefulSet), nil
+}
+
+func (c *statefulSets) GetScale(ctx context.Context, name string) (*autoscalingv1.Scale, error) {
+	return c.client.GetScale(ctx, name)
+}
+
+func (c *statefulSets) UpdateScale(ctx context.Context, name string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	return c.client.UpdateScale(ctx, name, scale)
+}
@@ -0,0 +1,22 @@
+package v1beta1
+
+import "k8s-client-go/client"
+
+// apps/v1beta1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type AppsV1beta1Interface interface {
+	Deployments(namespace string) DeploymentInterface
+}
+
+type AppsV1beta1Client struct {
+	client client.Interface
+}
+
+var _ AppsV1beta1Interface = &AppsV1beta1Client{}
+
+func NewForClient(c client.Interface) *AppsV1beta1Client {
+	return &AppsV1beta1Client{client: c}
+}
+
+func (c *AppsV1beta1Client) Deployments(namespace string) DeploymentInterface {
+	return newDeployments(c.client, namespace)
+}
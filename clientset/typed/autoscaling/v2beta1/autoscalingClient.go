@@ -0,0 +1,22 @@
+package v2beta1
+
+import "k8s-client-go/client"
+
+// autoscaling/v2beta1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type AutoscalingV2beta1Interface interface {
+	HorizontalPodAutoscalers(namespace string) HorizontalPodAutoscalerInterface
+}
+
+type AutoscalingV2beta1Client struct {
+	client client.Interface
+}
+
+var _ AutoscalingV2beta1Interface = &AutoscalingV2beta1Client{}
+
+func NewForClient(c client.Interface) *AutoscalingV2beta1Client {
+	return &AutoscalingV2beta1Client{client: c}
+}
+
+func (c *AutoscalingV2beta1Client) HorizontalPodAutoscalers(namespace string) HorizontalPodAutoscalerInterface {
+	return newHorizontalPodAutoscalers(c.client, namespace)
+}
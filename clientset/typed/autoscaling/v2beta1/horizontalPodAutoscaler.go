@@ -0,0 +1,99 @@
+package v2beta1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	autoscalingv2beta1 "k8s-client-go/resource/autoscaling/v2beta1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type HorizontalPodAutoscalerInterface interface {
+	Get(ctx context.Context, name string) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error)
+	List(ctx context.Context, opts client.ListOptions) (*HorizontalPodAutoscalerList, error)
+	Create(ctx context.Context, horizontalPodAutoscaler *autoscalingv2beta1.ResHorizontalPodAutoscaler) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error)
+	Update(ctx context.Context, horizontalPodAutoscaler *autoscalingv2beta1.ResHorizontalPodAutoscaler) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type HorizontalPodAutoscalerList struct {
+	Metadata resource.ListMeta
+	Items    []*autoscalingv2beta1.ResHorizontalPodAutoscaler
+}
+
+type horizontalPodAutoscalers struct {
+	client typed.ResourceClient
+}
+
+func newHorizontalPodAutoscalers(c client.Interface, namespace string) *horizontalPodAutoscalers {
+	return &horizontalPodAutoscalers{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "autoscaling/v2beta1",
+		Kind:       "HorizontalPodAutoscaler",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return autoscalingv2beta1.NewResHorizontalPodAutoscaler() },
+	}}
+}
+
+func (c *horizontalPodAutoscalers) Get(ctx context.Context, name string) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv2beta1.ResHorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) List(ctx context.Context, opts client.ListOptions) (*HorizontalPodAutoscalerList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &HorizontalPodAutoscalerList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*autoscalingv2beta1.ResHorizontalPodAutoscaler))
+	}
+	return list, nil
+}
+
+func (c *horizontalPodAutoscalers) Create(ctx context.Context, horizontalPodAutoscaler *autoscalingv2beta1.ResHorizontalPodAutoscaler) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error) {
+	obj, err := c.client.Create(ctx, horizontalPodAutoscaler)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv2beta1.ResHorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) Update(ctx context.Context, horizontalPodAutoscaler *autoscalingv2beta1.ResHorizontalPodAutoscaler) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error) {
+	obj, err := c.client.Update(ctx, horizontalPodAutoscaler)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv2beta1.ResHorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *horizontalPodAutoscalers) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *horizontalPodAutoscalers) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *horizontalPodAutoscalers) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*autoscalingv2beta1.ResHorizontalPodAutoscaler, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv2beta1.ResHorizontalPodAutoscaler), nil
+}
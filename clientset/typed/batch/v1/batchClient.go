@@ -0,0 +1,22 @@
+package v1
+
+import "k8s-client-go/client"
+
+// batch/v1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type BatchV1Interface interface {
+	Jobs(namespace string) JobInterface
+}
+
+type BatchV1Client struct {
+	client client.Interface
+}
+
+var _ BatchV1Interface = &BatchV1Client{}
+
+func NewForClient(c client.Interface) *BatchV1Client {
+	return &BatchV1Client{client: c}
+}
+
+func (c *BatchV1Client) Jobs(namespace string) JobInterface {
+	return newJobs(c.client, namespace)
+}
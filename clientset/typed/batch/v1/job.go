@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	batchv1 "k8s-client-go/resource/batch/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type JobInterface interface {
+	Get(ctx context.Context, name string) (*batchv1.ResJob, error)
+	List(ctx context.Context, opts client.ListOptions) (*JobList, error)
+	Create(ctx context.Context, job *batchv1.ResJob) (*batchv1.ResJob, error)
+	Update(ctx context.Context, job *batchv1.ResJob) (*batchv1.ResJob, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*batchv1.ResJob, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type JobList struct {
+	Metadata resource.ListMeta
+	Items    []*batchv1.ResJob
+}
+
+type jobs struct {
+	client typed.ResourceClient
+}
+
+func newJobs(c client.Interface, namespace string) *jobs {
+	return &jobs{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "batch/v1",
+		Kind:       "Job",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return batchv1.NewResJob() },
+	}}
+}
+
+func (c *jobs) Get(ctx context.Context, name string) (*batchv1.ResJob, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1.ResJob), nil
+}
+
+func (c *jobs) List(ctx context.Context, opts client.ListOptions) (*JobList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &JobList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*batchv1.ResJob))
+	}
+	return list, nil
+}
+
+func (c *jobs) Create(ctx context.Context, job *batchv1.ResJob) (*batchv1.ResJob, error) {
+	obj, err := c.client.Create(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1.ResJob), nil
+}
+
+func (c *jobs) Update(ctx context.Context, job *batchv1.ResJob) (*batchv1.ResJob, error) {
+	obj, err := c.client.Update(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1.ResJob), nil
+}
+
+func (c *jobs) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *jobs) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *jobs) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *jobs) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*batchv1.ResJob, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1.ResJob), nil
+}
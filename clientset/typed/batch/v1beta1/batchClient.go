@@ -0,0 +1,22 @@
+package v1beta1
+
+import "k8s-client-go/client"
+
+// batch/v1beta1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type BatchV1beta1Interface interface {
+	CronJobs(namespace string) CronJobInterface
+}
+
+type BatchV1beta1Client struct {
+	client client.Interface
+}
+
+var _ BatchV1beta1Interface = &BatchV1beta1Client{}
+
+func NewForClient(c client.Interface) *BatchV1beta1Client {
+	return &BatchV1beta1Client{client: c}
+}
+
+func (c *BatchV1beta1Client) CronJobs(namespace string) CronJobInterface {
+	return newCronJobs(c.client, namespace)
+}
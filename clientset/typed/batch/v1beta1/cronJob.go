@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	batchv1beta1 "k8s-client-go/resource/batch/v1beta1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type CronJobInterface interface {
+	Get(ctx context.Context, name string) (*batchv1beta1.ResCronJob, error)
+	List(ctx context.Context, opts client.ListOptions) (*CronJobList, error)
+	Create(ctx context.Context, cronJob *batchv1beta1.ResCronJob) (*batchv1beta1.ResCronJob, error)
+	Update(ctx context.Context, cronJob *batchv1beta1.ResCronJob) (*batchv1beta1.ResCronJob, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*batchv1beta1.ResCronJob, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type CronJobList struct {
+	Metadata resource.ListMeta
+	Items    []*batchv1beta1.ResCronJob
+}
+
+type cronJobs struct {
+	client typed.ResourceClient
+}
+
+func newCronJobs(c client.Interface, namespace string) *cronJobs {
+	return &cronJobs{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "batch/v1beta1",
+		Kind:       "CronJob",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return batchv1beta1.NewResCronJob() },
+	}}
+}
+
+func (c *cronJobs) Get(ctx context.Context, name string) (*batchv1beta1.ResCronJob, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1beta1.ResCronJob), nil
+}
+
+func (c *cronJobs) List(ctx context.Context, opts client.ListOptions) (*CronJobList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &CronJobList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*batchv1beta1.ResCronJob))
+	}
+	return list, nil
+}
+
+func (c *cronJobs) Create(ctx context.Context, cronJob *batchv1beta1.ResCronJob) (*batchv1beta1.ResCronJob, error) {
+	obj, err := c.client.Create(ctx, cronJob)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1beta1.ResCronJob), nil
+}
+
+func (c *cronJobs) Update(ctx context.Context, cronJob *batchv1beta1.ResCronJob) (*batchv1beta1.ResCronJob, error) {
+	obj, err := c.client.Update(ctx, cronJob)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1beta1.ResCronJob), nil
+}
+
+func (c *cronJobs) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *cronJobs) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *cronJobs) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *cronJobs) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*batchv1beta1.ResCronJob, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*batchv1beta1.ResCronJob), nil
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type ConfigMapInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResConfigMap, error)
+	List(ctx context.Context, opts client.ListOptions) (*ConfigMapList, error)
+	Create(ctx context.Context, configMap *corev1.ResConfigMap) (*corev1.ResConfigMap, error)
+	Update(ctx context.Context, configMap *corev1.ResConfigMap) (*corev1.ResConfigMap, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResConfigMap, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type ConfigMapList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResConfigMap
+}
+
+type configMaps struct {
+	client typed.ResourceClient
+}
+
+func newConfigMaps(c client.Interface, namespace string) *configMaps {
+	return &configMaps{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewConfigMap() },
+	}}
+}
+
+func (c *configMaps) Get(ctx context.Context, name string) (*corev1.ResConfigMap, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResConfigMap), nil
+}
+
+func (c *configMaps) List(ctx context.Context, opts client.ListOptions) (*ConfigMapList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &ConfigMapList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResConfigMap))
+	}
+	return list, nil
+}
+
+func (c *configMaps) Create(ctx context.Context, configMap *corev1.ResConfigMap) (*corev1.ResConfigMap, error) {
+	obj, err := c.client.Create(ctx, configMap)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResConfigMap), nil
+}
+
+func (c *configMaps) Update(ctx context.Context, configMap *corev1.ResConfigMap) (*corev1.ResConfigMap, error) {
+	obj, err := c.client.Update(ctx, configMap)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResConfigMap), nil
+}
+
+func (c *configMaps) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *configMaps) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *configMaps) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *configMaps) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResConfigMap, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResConfigMap), nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import "k8s-client-go/client"
+
+// core/v1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type CoreV1Interface interface {
+	Pods(namespace string) PodInterface
+	Services(namespace string) ServiceInterface
+	Endpoints(namespace string) EndpointsInterface
+	ConfigMaps(namespace string) ConfigMapInterface
+	Secrets(namespace string) SecretInterface
+	ServiceAccounts(namespace string) ServiceAccountInterface
+	PersistentVolumeClaims(namespace string) PersistentVolumeClaimInterface
+	ResourceQuotas(namespace string) ResourceQuotaInterface
+	LimitRanges(namespace string) LimitRangeInterface
+	Events(namespace string) EventInterface
+	PersistentVolumes() PersistentVolumeInterface
+	Namespaces() NamespaceInterface
+	Nodes() NodeInterface
+}
+
+type CoreV1Client struct {
+	client client.Interface
+}
+
+var _ CoreV1Interface = &CoreV1Client{}
+
+func NewForClient(c client.Interface) *CoreV1Client {
+	return &CoreV1Client{client: c}
+}
+
+func (c *CoreV1Client) Pods(namespace string) PodInterface {
+	return newPods(c.client, namespace)
+}
+
+func (c *CoreV1Client) Services(namespace string) ServiceInterface {
+	return newServices(c.client, namespace)
+}
+
+func (c *CoreV1Client) Endpoints(namespace string) EndpointsInterface {
+	return newEndpoints(c.client, namespace)
+}
+
+func (c *CoreV1Client) ConfigMaps(namespace string) ConfigMapInterface {
+	return newConfigMaps(c.client, namespace)
+}
+
+func (c *CoreV1Client) Secrets(namespace string) SecretInterface {
+	return newSecrets(c.client, namespace)
+}
+
+func (c *CoreV1Client) ServiceAccounts(namespace string) ServiceAccountInterface {
+	return newServiceAccounts(c.client, namespace)
+}
+
+func (c *CoreV1Client) PersistentVolumeClaims(namespace string) PersistentVolumeClaimInterface {
+	return newPersistentVolumeClaims(c.client, namespace)
+}
+
+func (c *CoreV1Client) ResourceQuotas(namespace string) ResourceQuotaInterface {
+	return newResourceQuotas(c.client, namespace)
+}
+
+func (c *CoreV1Client) LimitRanges(namespace string) LimitRangeInterface {
+	return newLimitRanges(c.client, namespace)
+}
+
+func (c *CoreV1Client) Events(namespace string) EventInterface {
+	return newEvents(c.client, namespace)
+}
+
+func (c *CoreV1Client) PersistentVolumes() PersistentVolumeInterface {
+	return newPersistentVolumes(c.client)
+}
+
+func (c *CoreV1Client) Namespaces() NamespaceInterface {
+	return newNamespaces(c.client)
+}
+
+func (c *CoreV1Client) Nodes() NodeInterface {
+	return newNodes(c.client)
+}
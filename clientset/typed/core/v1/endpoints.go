@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type EndpointsInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResEndpoints, error)
+	List(ctx context.Context, opts client.ListOptions) (*EndpointsList, error)
+	Create(ctx context.Context, endpoints *corev1.ResEndpoints) (*corev1.ResEndpoints, error)
+	Update(ctx context.Context, endpoints *corev1.ResEndpoints) (*corev1.ResEndpoints, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResEndpoints, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type EndpointsList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResEndpoints
+}
+
+type endpoints struct {
+	client typed.ResourceClient
+}
+
+func newEndpoints(c client.Interface, namespace string) *endpoints {
+	return &endpoints{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Endpoints",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResEndpoints() },
+	}}
+}
+
+func (c *endpoints) Get(ctx context.Context, name string) (*corev1.ResEndpoints, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEndpoints), nil
+}
+
+func (c *endpoints) List(ctx context.Context, opts client.ListOptions) (*EndpointsList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &EndpointsList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResEndpoints))
+	}
+	return list, nil
+}
+
+func (c *endpoints) Create(ctx context.Context, endpoints *corev1.ResEndpoints) (*corev1.ResEndpoints, error) {
+	obj, err := c.client.Create(ctx, endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEndpoints), nil
+}
+
+func (c *endpoints) Update(ctx context.Context, endpoints *corev1.ResEndpoints) (*corev1.ResEndpoints, error) {
+	obj, err := c.client.Update(ctx, endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEndpoints), nil
+}
+
+func (c *endpoints) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *endpoints) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *endpoints) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *endpoints) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResEndpoints, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEndpoints), nil
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type EventInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResEvent, error)
+	List(ctx context.Context, opts client.ListOptions) (*EventList, error)
+	Create(ctx context.Context, event *corev1.ResEvent) (*corev1.ResEvent, error)
+	Update(ctx context.Context, event *corev1.ResEvent) (*corev1.ResEvent, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResEvent, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type EventList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResEvent
+}
+
+type events struct {
+	client typed.ResourceClient
+}
+
+func newEvents(c client.Interface, namespace string) *events {
+	return &events{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Event",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResEvent("") },
+	}}
+}
+
+func (c *events) Get(ctx context.Context, name string) (*corev1.ResEvent, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEvent), nil
+}
+
+func (c *events) List(ctx context.Context, opts client.ListOptions) (*EventList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &EventList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResEvent))
+	}
+	return list, nil
+}
+
+func (c *events) Create(ctx context.Context, event *corev1.ResEvent) (*corev1.ResEvent, error) {
+	obj, err := c.client.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEvent), nil
+}
+
+func (c *events) Update(ctx context.Context, event *corev1.ResEvent) (*corev1.ResEvent, error) {
+	obj, err := c.client.Update(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEvent), nil
+}
+
+func (c *events) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *events) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *events) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *events) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResEvent, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResEvent), nil
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type LimitRangeInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResLimitRange, error)
+	List(ctx context.Context, opts client.ListOptions) (*LimitRangeList, error)
+	Create(ctx context.Context, limitRange *corev1.ResLimitRange) (*corev1.ResLimitRange, error)
+	Update(ctx context.Context, limitRange *corev1.ResLimitRange) (*corev1.ResLimitRange, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResLimitRange, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type LimitRangeList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResLimitRange
+}
+
+type limitRanges struct {
+	client typed.ResourceClient
+}
+
+func newLimitRanges(c client.Interface, namespace string) *limitRanges {
+	return &limitRanges{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "LimitRange",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResLimitRange() },
+	}}
+}
+
+func (c *limitRanges) Get(ctx context.Context, name string) (*corev1.ResLimitRange, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResLimitRange), nil
+}
+
+func (c *limitRanges) List(ctx context.Context, opts client.ListOptions) (*LimitRangeList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &LimitRangeList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResLimitRange))
+	}
+	return list, nil
+}
+
+func (c *limitRanges) Create(ctx context.Context, limitRange *corev1.ResLimitRange) (*corev1.ResLimitRange, error) {
+	obj, err := c.client.Create(ctx, limitRange)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResLimitRange), nil
+}
+
+func (c *limitRanges) Update(ctx context.Context, limitRange *corev1.ResLimitRange) (*corev1.ResLimitRange, error) {
+	obj, err := c.client.Update(ctx, limitRange)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResLimitRange), nil
+}
+
+func (c *limitRanges) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *limitRanges) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *limitRanges) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *limitRanges) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResLimitRange, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResLimitRange), nil
+}
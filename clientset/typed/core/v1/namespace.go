@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type NamespaceInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResNamespace, error)
+	List(ctx context.Context, opts client.ListOptions) (*NamespaceList, error)
+	Create(ctx context.Context, ns *corev1.ResNamespace) (*corev1.ResNamespace, error)
+	Update(ctx context.Context, ns *corev1.ResNamespace) (*corev1.ResNamespace, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResNamespace, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type NamespaceList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResNamespace
+}
+
+type namespaces struct {
+	client typed.ResourceClient
+}
+
+func newNamespaces(c client.Interface) *namespaces {
+	return &namespaces{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Namespace",
+		New:        func() resource.IResource { return corev1.NewResNamespace() },
+	}}
+}
+
+func (c *namespaces) Get(ctx context.Context, name string) (*corev1.ResNamespace, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNamespace), nil
+}
+
+func (c *namespaces) List(ctx context.Context, opts client.ListOptions) (*NamespaceList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &NamespaceList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResNamespace))
+	}
+	return list, nil
+}
+
+func (c *namespaces) Create(ctx context.Context, ns *corev1.ResNamespace) (*corev1.ResNamespace, error) {
+	obj, err := c.client.Create(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNamespace), nil
+}
+
+func (c *namespaces) Update(ctx context.Context, ns *corev1.ResNamespace) (*corev1.ResNamespace, error) {
+	obj, err := c.client.Update(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNamespace), nil
+}
+
+func (c *namespaces) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *namespaces) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *namespaces) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *namespaces) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResNamespace, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNamespace), nil
+}
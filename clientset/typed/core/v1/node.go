@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type NodeInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResNode, error)
+	List(ctx context.Context, opts client.ListOptions) (*NodeList, error)
+	Create(ctx context.Context, node *corev1.ResNode) (*corev1.ResNode, error)
+	Update(ctx context.Context, node *corev1.ResNode) (*corev1.ResNode, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResNode, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type NodeList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResNode
+}
+
+type nodes struct {
+	client typed.ResourceClient
+}
+
+func newNodes(c client.Interface) *nodes {
+	return &nodes{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Node",
+		New:        func() resource.IResource { return corev1.NewResNode("") },
+	}}
+}
+
+func (c *nodes) Get(ctx context.Context, name string) (*corev1.ResNode, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNode), nil
+}
+
+func (c *nodes) List(ctx context.Context, opts client.ListOptions) (*NodeList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &NodeList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResNode))
+	}
+	return list, nil
+}
+
+func (c *nodes) Create(ctx context.Context, node *corev1.ResNode) (*corev1.ResNode, error) {
+	obj, err := c.client.Create(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNode), nil
+}
+
+func (c *nodes) Update(ctx context.Context, node *corev1.ResNode) (*corev1.ResNode, error) {
+	obj, err := c.client.Update(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNode), nil
+}
+
+func (c *nodes) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *nodes) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *nodes) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *nodes) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResNode, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResNode), nil
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type PersistentVolumeInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResPersistentVolume, error)
+	List(ctx context.Context, opts client.ListOptions) (*PersistentVolumeList, error)
+	Create(ctx context.Context, persistentVolume *corev1.ResPersistentVolume) (*corev1.ResPersistentVolume, error)
+	Update(ctx context.Context, persistentVolume *corev1.ResPersistentVolume) (*corev1.ResPersistentVolume, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPersistentVolume, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type PersistentVolumeList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResPersistentVolume
+}
+
+type persistentVolumes struct {
+	client typed.ResourceClient
+}
+
+func newPersistentVolumes(c client.Interface) *persistentVolumes {
+	return &persistentVolumes{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "PersistentVolume",
+		New:        func() resource.IResource { return corev1.NewPersistentVolume() },
+	}}
+}
+
+func (c *persistentVolumes) Get(ctx context.Context, name string) (*corev1.ResPersistentVolume, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolume), nil
+}
+
+func (c *persistentVolumes) List(ctx context.Context, opts client.ListOptions) (*PersistentVolumeList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &PersistentVolumeList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResPersistentVolume))
+	}
+	return list, nil
+}
+
+func (c *persistentVolumes) Create(ctx context.Context, persistentVolume *corev1.ResPersistentVolume) (*corev1.ResPersistentVolume, error) {
+	obj, err := c.client.Create(ctx, persistentVolume)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolume), nil
+}
+
+func (c *persistentVolumes) Update(ctx context.Context, persistentVolume *corev1.ResPersistentVolume) (*corev1.ResPersistentVolume, error) {
+	obj, err := c.client.Update(ctx, persistentVolume)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolume), nil
+}
+
+func (c *persistentVolumes) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *persistentVolumes) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *persistentVolumes) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *persistentVolumes) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPersistentVolume, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolume), nil
+}
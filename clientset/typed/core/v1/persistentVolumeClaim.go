@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type PersistentVolumeClaimInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResPersistentVolumeClaim, error)
+	List(ctx context.Context, opts client.ListOptions) (*PersistentVolumeClaimList, error)
+	Create(ctx context.Context, persistentVolumeClaim *corev1.ResPersistentVolumeClaim) (*corev1.ResPersistentVolumeClaim, error)
+	Update(ctx context.Context, persistentVolumeClaim *corev1.ResPersistentVolumeClaim) (*corev1.ResPersistentVolumeClaim, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPersistentVolumeClaim, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type PersistentVolumeClaimList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResPersistentVolumeClaim
+}
+
+type persistentVolumeClaims struct {
+	client typed.ResourceClient
+}
+
+func newPersistentVolumeClaims(c client.Interface, namespace string) *persistentVolumeClaims {
+	return &persistentVolumeClaims{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewPersistentVolumeClaim() },
+	}}
+}
+
+func (c *persistentVolumeClaims) Get(ctx context.Context, name string) (*corev1.ResPersistentVolumeClaim, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) List(ctx context.Context, opts client.ListOptions) (*PersistentVolumeClaimList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &PersistentVolumeClaimList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResPersistentVolumeClaim))
+	}
+	return list, nil
+}
+
+func (c *persistentVolumeClaims) Create(ctx context.Context, persistentVolumeClaim *corev1.ResPersistentVolumeClaim) (*corev1.ResPersistentVolumeClaim, error) {
+	obj, err := c.client.Create(ctx, persistentVolumeClaim)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) Update(ctx context.Context, persistentVolumeClaim *corev1.ResPersistentVolumeClaim) (*corev1.ResPersistentVolumeClaim, error) {
+	obj, err := c.client.Update(ctx, persistentVolumeClaim)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *persistentVolumeClaims) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *persistentVolumeClaims) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *persistentVolumeClaims) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPersistentVolumeClaim, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPersistentVolumeClaim), nil
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"context"
+	"io"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type PodInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResPod, error)
+	List(ctx context.Context, opts client.ListOptions) (*PodList, error)
+	Create(ctx context.Context, pod *corev1.ResPod) (*corev1.ResPod, error)
+	Update(ctx context.Context, pod *corev1.ResPod) (*corev1.ResPod, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPod, error)
+	GetLogs(ctx context.Context, name string, opts client.PodLogOptions) (io.ReadCloser, error)
+	Evict(ctx context.Context, name string, opts *resource.DeleteOptions) error
+}
+
+// Metadata.Continue不为空时还有下一页
+type PodList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResPod
+}
+
+type pods struct {
+	client typed.ResourceClient
+}
+
+func newPods(c client.Interface, namespace string) *pods {
+	return &pods{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResPod("") },
+	}}
+}
+
+func (c *pods) Get(ctx context.Context, name string) (*corev1.ResPod, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPod), nil
+}
+
+func (c *pods) List(ctx context.Context, opts client.ListOptions) (*PodList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &PodList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResPod))
+	}
+	return list, nil
+}
+
+func (c *pods) Create(ctx context.Context, pod *corev1.ResPod) (*corev1.ResPod, error) {
+	obj, err := c.client.Create(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPod), nil
+}
+
+func (c *pods) Update(ctx context.Context, pod *corev1.ResPod) (*corev1.ResPod, error) {
+	obj, err := c.client.Update(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPod), nil
+}
+
+func (c *pods) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *pods) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *pods) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *pods) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResPod, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResPod), nil
+}
+
+// 获取pod的日志，调用方读取完毕后需关闭返回的ReadCloser
+func (c *pods) GetLogs(ctx context.Context, name string, opts client.PodLogOptions) (io.ReadCloser, error) {
+	return c.client.Client.GetLogs(ctx, c.client.Namespace, name, opts)
+}
+
+// 通过Eviction API驱逐pod，被PodDisruptionBudget阻止时返回429，可用rest.IsTooManyRequests判断
+func (c *pods) Evict(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Client.Evict(ctx, c.client.Namespace, name, opts)
+}
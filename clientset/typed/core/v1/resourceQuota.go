@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type ResourceQuotaInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResResourceQuota, error)
+	List(ctx context.Context, opts client.ListOptions) (*ResourceQuotaList, error)
+	Create(ctx context.Context, resourceQuota *corev1.ResResourceQuota) (*corev1.ResResourceQuota, error)
+	Update(ctx context.Context, resourceQuota *corev1.ResResourceQuota) (*corev1.ResResourceQuota, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResResourceQuota, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type ResourceQuotaList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResResourceQuota
+}
+
+type resourceQuotas struct {
+	client typed.ResourceClient
+}
+
+func newResourceQuotas(c client.Interface, namespace string) *resourceQuotas {
+	return &resourceQuotas{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "ResourceQuota",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResResourceQuota() },
+	}}
+}
+
+func (c *resourceQuotas) Get(ctx context.Context, name string) (*corev1.ResResourceQuota, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResResourceQuota), nil
+}
+
+func (c *resourceQuotas) List(ctx context.Context, opts client.ListOptions) (*ResourceQuotaList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &ResourceQuotaList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResResourceQuota))
+	}
+	return list, nil
+}
+
+func (c *resourceQuotas) Create(ctx context.Context, resourceQuota *corev1.ResResourceQuota) (*corev1.ResResourceQuota, error) {
+	obj, err := c.client.Create(ctx, resourceQuota)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResResourceQuota), nil
+}
+
+func (c *resourceQuotas) Update(ctx context.Context, resourceQuota *corev1.ResResourceQuota) (*corev1.ResResourceQuota, error) {
+	obj, err := c.client.Update(ctx, resourceQuota)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResResourceQuota), nil
+}
+
+func (c *resourceQuotas) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *resourceQuotas) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *resourceQuotas) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *resourceQuotas) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResResourceQuota, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResResourceQuota), nil
+}
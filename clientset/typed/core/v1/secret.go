@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type SecretInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResSecret, error)
+	List(ctx context.Context, opts client.ListOptions) (*SecretList, error)
+	Create(ctx context.Context, secret *corev1.ResSecret) (*corev1.ResSecret, error)
+	Update(ctx context.Context, secret *corev1.ResSecret) (*corev1.ResSecret, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResSecret, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type SecretList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResSecret
+}
+
+type secrets struct {
+	client typed.ResourceClient
+}
+
+func newSecrets(c client.Interface, namespace string) *secrets {
+	return &secrets{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewSecret() },
+	}}
+}
+
+func (c *secrets) Get(ctx context.Context, name string) (*corev1.ResSecret, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResSecret), nil
+}
+
+func (c *secrets) List(ctx context.Context, opts client.ListOptions) (*SecretList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &SecretList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResSecret))
+	}
+	return list, nil
+}
+
+func (c *secrets) Create(ctx context.Context, secret *corev1.ResSecret) (*corev1.ResSecret, error) {
+	obj, err := c.client.Create(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResSecret), nil
+}
+
+func (c *secrets) Update(ctx context.Context, secret *corev1.ResSecret) (*corev1.ResSecret, error) {
+	obj, err := c.client.Update(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResSecret), nil
+}
+
+func (c *secrets) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *secrets) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *secrets) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *secrets) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResSecret, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResSecret), nil
+}
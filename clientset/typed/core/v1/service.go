@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type ServiceInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResService, error)
+	List(ctx context.Context, opts client.ListOptions) (*ServiceList, error)
+	Create(ctx context.Context, service *corev1.ResService) (*corev1.ResService, error)
+	Update(ctx context.Context, service *corev1.ResService) (*corev1.ResService, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResService, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type ServiceList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResService
+}
+
+type services struct {
+	client typed.ResourceClient
+}
+
+func newServices(c client.Interface, namespace string) *services {
+	return &services{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "Service",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResService() },
+	}}
+}
+
+func (c *services) Get(ctx context.Context, name string) (*corev1.ResService, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResService), nil
+}
+
+func (c *services) List(ctx context.Context, opts client.ListOptions) (*ServiceList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &ServiceList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResService))
+	}
+	return list, nil
+}
+
+func (c *services) Create(ctx context.Context, service *corev1.ResService) (*corev1.ResService, error) {
+	obj, err := c.client.Create(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResService), nil
+}
+
+func (c *services) Update(ctx context.Context, service *corev1.ResService) (*corev1.ResService, error) {
+	obj, err := c.client.Update(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResService), nil
+}
+
+func (c *services) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *services) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *services) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *services) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResService, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResService), nil
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	corev1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type ServiceAccountInterface interface {
+	Get(ctx context.Context, name string) (*corev1.ResServiceAccount, error)
+	List(ctx context.Context, opts client.ListOptions) (*ServiceAccountList, error)
+	Create(ctx context.Context, serviceAccount *corev1.ResServiceAccount) (*corev1.ResServiceAccount, error)
+	Update(ctx context.Context, serviceAccount *corev1.ResServiceAccount) (*corev1.ResServiceAccount, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResServiceAccount, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type ServiceAccountList struct {
+	Metadata resource.ListMeta
+	Items    []*corev1.ResServiceAccount
+}
+
+type serviceAccounts struct {
+	client typed.ResourceClient
+}
+
+func newServiceAccounts(c client.Interface, namespace string) *serviceAccounts {
+	return &serviceAccounts{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "v1",
+		Kind:       "ServiceAccount",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return corev1.NewResServiceAccount() },
+	}}
+}
+
+func (c *serviceAccounts) Get(ctx context.Context, name string) (*corev1.ResServiceAccount, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResServiceAccount), nil
+}
+
+func (c *serviceAccounts) List(ctx context.Context, opts client.ListOptions) (*ServiceAccountList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &ServiceAccountList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*corev1.ResServiceAccount))
+	}
+	return list, nil
+}
+
+func (c *serviceAccounts) Create(ctx context.Context, serviceAccount *corev1.ResServiceAccount) (*corev1.ResServiceAccount, error) {
+	obj, err := c.client.Create(ctx, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResServiceAccount), nil
+}
+
+func (c *serviceAccounts) Update(ctx context.Context, serviceAccount *corev1.ResServiceAccount) (*corev1.ResServiceAccount, error) {
+	obj, err := c.client.Update(ctx, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResServiceAccount), nil
+}
+
+func (c *serviceAccounts) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *serviceAccounts) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *serviceAccounts) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *serviceAccounts) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*corev1.ResServiceAccount, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ResServiceAccount), nil
+}
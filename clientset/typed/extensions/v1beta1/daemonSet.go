@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	extensionsv1beta1 "k8s-client-go/resource/extensions/v1beta1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type DaemonSetInterface interface {
+	Get(ctx context.Context, name string) (*extensionsv1beta1.ResDaemonSet, error)
+	List(ctx context.Context, opts client.ListOptions) (*DaemonSetList, error)
+	Create(ctx context.Context, daemonSet *extensionsv1beta1.ResDaemonSet) (*extensionsv1beta1.ResDaemonSet, error)
+	Update(ctx context.Context, daemonSet *extensionsv1beta1.ResDaemonSet) (*extensionsv1beta1.ResDaemonSet, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResDaemonSet, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type DaemonSetList struct {
+	Metadata resource.ListMeta
+	Items    []*extensionsv1beta1.ResDaemonSet
+}
+
+type daemonSets struct {
+	client typed.ResourceClient
+}
+
+func newDaemonSets(c client.Interface, namespace string) *daemonSets {
+	return &daemonSets{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "extensions/v1beta1",
+		Kind:       "DaemonSet",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return extensionsv1beta1.NewResDaemonSet() },
+	}}
+}
+
+func (c *daemonSets) Get(ctx context.Context, name string) (*extensionsv1beta1.ResDaemonSet, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDaemonSet), nil
+}
+
+func (c *daemonSets) List(ctx context.Context, opts client.ListOptions) (*DaemonSetList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &DaemonSetList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*extensionsv1beta1.ResDaemonSet))
+	}
+	return list, nil
+}
+
+func (c *daemonSets) Create(ctx context.Context, daemonSet *extensionsv1beta1.ResDaemonSet) (*extensionsv1beta1.ResDaemonSet, error) {
+	obj, err := c.client.Create(ctx, daemonSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDaemonSet), nil
+}
+
+func (c *daemonSets) Update(ctx context.Context, daemonSet *extensionsv1beta1.ResDaemonSet) (*extensionsv1beta1.ResDaemonSet, error) {
+	obj, err := c.client.Update(ctx, daemonSet)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDaemonSet), nil
+}
+
+func (c *daemonSets) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *daemonSets) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *daemonSets) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *daemonSets) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResDaemonSet, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDaemonSet), nil
+}
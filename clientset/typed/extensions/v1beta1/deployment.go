@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	extensionsv1beta1 "k8s-client-go/resource/extensions/v1beta1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type DeploymentInterface interface {
+	Get(ctx context.Context, name string) (*extensionsv1beta1.ResDeployment, error)
+	List(ctx context.Context, opts client.ListOptions) (*DeploymentList, error)
+	Create(ctx context.Context, deployment *extensionsv1beta1.ResDeployment) (*extensionsv1beta1.ResDeployment, error)
+	Update(ctx context.Context, deployment *extensionsv1beta1.ResDeployment) (*extensionsv1beta1.ResDeployment, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResDeployment, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type DeploymentList struct {
+	Metadata resource.ListMeta
+	Items    []*extensionsv1beta1.ResDeployment
+}
+
+type deployments struct {
+	client typed.ResourceClient
+}
+
+func newDeployments(c client.Interface, namespace string) *deployments {
+	return &deployments{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "extensions/v1beta1",
+		Kind:       "Deployment",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return extensionsv1beta1.NewResDeployment() },
+	}}
+}
+
+func (c *deployments) Get(ctx context.Context, name string) (*extensionsv1beta1.ResDeployment, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDeployment), nil
+}
+
+func (c *deployments) List(ctx context.Context, opts client.ListOptions) (*DeploymentList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &DeploymentList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*extensionsv1beta1.ResDeployment))
+	}
+	return list, nil
+}
+
+func (c *deployments) Create(ctx context.Context, deployment *extensionsv1beta1.ResDeployment) (*extensionsv1beta1.ResDeployment, error) {
+	obj, err := c.client.Create(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDeployment), nil
+}
+
+func (c *deployments) Update(ctx context.Context, deployment *extensionsv1beta1.ResDeployment) (*extensionsv1beta1.ResDeployment, error) {
+	obj, err := c.client.Update(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDeployment), nil
+}
+
+func (c *deployments) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *deployments) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *deployments) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *deployments) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResDeployment, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResDeployment), nil
+}
@@ -0,0 +1,32 @@
+package v1beta1
+
+import "k8s-client-go/client"
+
+// extensions/v1beta1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type ExtensionsV1beta1Interface interface {
+	Deployments(namespace string) DeploymentInterface
+	DaemonSets(namespace string) DaemonSetInterface
+	Ingresses(namespace string) IngressInterface
+}
+
+type ExtensionsV1beta1Client struct {
+	client client.Interface
+}
+
+var _ ExtensionsV1beta1Interface = &ExtensionsV1beta1Client{}
+
+func NewForClient(c client.Interface) *ExtensionsV1beta1Client {
+	return &ExtensionsV1beta1Client{client: c}
+}
+
+func (c *ExtensionsV1beta1Client) Deployments(namespace string) DeploymentInterface {
+	return newDeployments(c.client, namespace)
+}
+
+func (c *ExtensionsV1beta1Client) DaemonSets(namespace string) DaemonSetInterface {
+	return newDaemonSets(c.client, namespace)
+}
+
+func (c *ExtensionsV1beta1Client) Ingresses(namespace string) IngressInterface {
+	return newIngresses(c.client, namespace)
+}
@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	extensionsv1beta1 "k8s-client-go/resource/extensions/v1beta1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type IngressInterface interface {
+	Get(ctx context.Context, name string) (*extensionsv1beta1.ResIngress, error)
+	List(ctx context.Context, opts client.ListOptions) (*IngressList, error)
+	Create(ctx context.Context, ingress *extensionsv1beta1.ResIngress) (*extensionsv1beta1.ResIngress, error)
+	Update(ctx context.Context, ingress *extensionsv1beta1.ResIngress) (*extensionsv1beta1.ResIngress, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResIngress, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type IngressList struct {
+	Metadata resource.ListMeta
+	Items    []*extensionsv1beta1.ResIngress
+}
+
+type ingresses struct {
+	client typed.ResourceClient
+}
+
+func newIngresses(c client.Interface, namespace string) *ingresses {
+	return &ingresses{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "extensions/v1beta1",
+		Kind:       "Ingress",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return extensionsv1beta1.NewIngress() },
+	}}
+}
+
+func (c *ingresses) Get(ctx context.Context, name string) (*extensionsv1beta1.ResIngress, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResIngress), nil
+}
+
+func (c *ingresses) List(ctx context.Context, opts client.ListOptions) (*IngressList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &IngressList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*extensionsv1beta1.ResIngress))
+	}
+	return list, nil
+}
+
+func (c *ingresses) Create(ctx context.Context, ingress *extensionsv1beta1.ResIngress) (*extensionsv1beta1.ResIngress, error) {
+	obj, err := c.client.Create(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResIngress), nil
+}
+
+func (c *ingresses) Update(ctx context.Context, ingress *extensionsv1beta1.ResIngress) (*extensionsv1beta1.ResIngress, error) {
+	obj, err := c.client.Update(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResIngress), nil
+}
+
+func (c *ingresses) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *ingresses) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *ingresses) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *ingresses) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*extensionsv1beta1.ResIngress, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*extensionsv1beta1.ResIngress), nil
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	networkingv1 "k8s-client-go/resource/networking/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type NetworkPolicyInterface interface {
+	Get(ctx context.Context, name string) (*networkingv1.ResNetworkPolicy, error)
+	List(ctx context.Context, opts client.ListOptions) (*NetworkPolicyList, error)
+	Create(ctx context.Context, networkPolicy *networkingv1.ResNetworkPolicy) (*networkingv1.ResNetworkPolicy, error)
+	Update(ctx context.Context, networkPolicy *networkingv1.ResNetworkPolicy) (*networkingv1.ResNetworkPolicy, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*networkingv1.ResNetworkPolicy, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type NetworkPolicyList struct {
+	Metadata resource.ListMeta
+	Items    []*networkingv1.ResNetworkPolicy
+}
+
+type networkPolicies struct {
+	client typed.ResourceClient
+}
+
+func newNetworkPolicies(c client.Interface, namespace string) *networkPolicies {
+	return &networkPolicies{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return networkingv1.NewResNetworkPolicy() },
+	}}
+}
+
+func (c *networkPolicies) Get(ctx context.Context, name string) (*networkingv1.ResNetworkPolicy, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*networkingv1.ResNetworkPolicy), nil
+}
+
+func (c *networkPolicies) List(ctx context.Context, opts client.ListOptions) (*NetworkPolicyList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &NetworkPolicyList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*networkingv1.ResNetworkPolicy))
+	}
+	return list, nil
+}
+
+func (c *networkPolicies) Create(ctx context.Context, networkPolicy *networkingv1.ResNetworkPolicy) (*networkingv1.ResNetworkPolicy, error) {
+	obj, err := c.client.Create(ctx, networkPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*networkingv1.ResNetworkPolicy), nil
+}
+
+func (c *networkPolicies) Update(ctx context.Context, networkPolicy *networkingv1.ResNetworkPolicy) (*networkingv1.ResNetworkPolicy, error) {
+	obj, err := c.client.Update(ctx, networkPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*networkingv1.ResNetworkPolicy), nil
+}
+
+func (c *networkPolicies) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *networkPolicies) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *networkPolicies) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *networkPolicies) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*networkingv1.ResNetworkPolicy, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*networkingv1.ResNetworkPolicy), nil
+}
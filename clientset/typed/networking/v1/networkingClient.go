@@ -0,0 +1,22 @@
+package v1
+
+import "k8s-client-go/client"
+
+// networking.k8s.io/v1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type NetworkingV1Interface interface {
+	NetworkPolicies(namespace string) NetworkPolicyInterface
+}
+
+type NetworkingV1Client struct {
+	client client.Interface
+}
+
+var _ NetworkingV1Interface = &NetworkingV1Client{}
+
+func NewForClient(c client.Interface) *NetworkingV1Client {
+	return &NetworkingV1Client{client: c}
+}
+
+func (c *NetworkingV1Client) NetworkPolicies(namespace string) NetworkPolicyInterface {
+	return newNetworkPolicies(c.client, namespace)
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	settingsv1alpha1 "k8s-client-go/resource/settings/v1alpha1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type PodPresetInterface interface {
+	Get(ctx context.Context, name string) (*settingsv1alpha1.ResPodPreset, error)
+	List(ctx context.Context, opts client.ListOptions) (*PodPresetList, error)
+	Create(ctx context.Context, podPreset *settingsv1alpha1.ResPodPreset) (*settingsv1alpha1.ResPodPreset, error)
+	Update(ctx context.Context, podPreset *settingsv1alpha1.ResPodPreset) (*settingsv1alpha1.ResPodPreset, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*settingsv1alpha1.ResPodPreset, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type PodPresetList struct {
+	Metadata resource.ListMeta
+	Items    []*settingsv1alpha1.ResPodPreset
+}
+
+type podPresets struct {
+	client typed.ResourceClient
+}
+
+func newPodPresets(c client.Interface, namespace string) *podPresets {
+	return &podPresets{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "settings.k8s.io/v1alpha1",
+		Kind:       "PodPreset",
+		Namespace:  namespace,
+		New:        func() resource.IResource { return settingsv1alpha1.NewResPodPreset("") },
+	}}
+}
+
+func (c *podPresets) Get(ctx context.Context, name string) (*settingsv1alpha1.ResPodPreset, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*settingsv1alpha1.ResPodPreset), nil
+}
+
+func (c *podPresets) List(ctx context.Context, opts client.ListOptions) (*PodPresetList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &PodPresetList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*settingsv1alpha1.ResPodPreset))
+	}
+	return list, nil
+}
+
+func (c *podPresets) Create(ctx context.Context, podPreset *settingsv1alpha1.ResPodPreset) (*settingsv1alpha1.ResPodPreset, error) {
+	obj, err := c.client.Create(ctx, podPreset)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*settingsv1alpha1.ResPodPreset), nil
+}
+
+func (c *podPresets) Update(ctx context.Context, podPreset *settingsv1alpha1.ResPodPreset) (*settingsv1alpha1.ResPodPreset, error) {
+	obj, err := c.client.Update(ctx, podPreset)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*settingsv1alpha1.ResPodPreset), nil
+}
+
+func (c *podPresets) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *podPresets) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *podPresets) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *podPresets) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*settingsv1alpha1.ResPodPreset, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*settingsv1alpha1.ResPodPreset), nil
+}
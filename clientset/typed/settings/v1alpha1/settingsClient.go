@@ -0,0 +1,22 @@
+package v1alpha1
+
+import "k8s-client-go/client"
+
+// settings.k8s.io/v1alpha1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type SettingsV1alpha1Interface interface {
+	PodPresets(namespace string) PodPresetInterface
+}
+
+type SettingsV1alpha1Client struct {
+	client client.Interface
+}
+
+var _ SettingsV1alpha1Interface = &SettingsV1alpha1Client{}
+
+func NewForClient(c client.Interface) *SettingsV1alpha1Client {
+	return &SettingsV1alpha1Client{client: c}
+}
+
+func (c *SettingsV1alpha1Client) PodPresets(namespace string) PodPresetInterface {
+	return newPodPresets(c.client, namespace)
+}
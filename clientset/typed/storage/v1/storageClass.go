@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+
+	"k8s-client-go/client"
+	"k8s-client-go/clientset/typed"
+	"k8s-client-go/resource"
+	storagev1 "k8s-client-go/resource/storage/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+type StorageClassInterface interface {
+	Get(ctx context.Context, name string) (*storagev1.ResStorageClass, error)
+	List(ctx context.Context, opts client.ListOptions) (*StorageClassList, error)
+	Create(ctx context.Context, storageClass *storagev1.ResStorageClass) (*storagev1.ResStorageClass, error)
+	Update(ctx context.Context, storageClass *storagev1.ResStorageClass) (*storagev1.ResStorageClass, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error
+	Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*storagev1.ResStorageClass, error)
+}
+
+// Metadata.Continue不为空时还有下一页
+type StorageClassList struct {
+	Metadata resource.ListMeta
+	Items    []*storagev1.ResStorageClass
+}
+
+type storageClasses struct {
+	client typed.ResourceClient
+}
+
+func newStorageClasses(c client.Interface) *storageClasses {
+	return &storageClasses{client: typed.ResourceClient{
+		Client:     c,
+		ApiVersion: "storage.k8s.io/v1",
+		Kind:       "StorageClass",
+		New:        func() resource.IResource { return storagev1.NewStorageClass() },
+	}}
+}
+
+func (c *storageClasses) Get(ctx context.Context, name string) (*storagev1.ResStorageClass, error) {
+	obj, err := c.client.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*storagev1.ResStorageClass), nil
+}
+
+func (c *storageClasses) List(ctx context.Context, opts client.ListOptions) (*StorageClassList, error) {
+	items, meta, err := c.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &StorageClassList{Metadata: meta}
+	for _, item := range items {
+		list.Items = append(list.Items, item.(*storagev1.ResStorageClass))
+	}
+	return list, nil
+}
+
+func (c *storageClasses) Create(ctx context.Context, storageClass *storagev1.ResStorageClass) (*storagev1.ResStorageClass, error) {
+	obj, err := c.client.Create(ctx, storageClass)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*storagev1.ResStorageClass), nil
+}
+
+func (c *storageClasses) Update(ctx context.Context, storageClass *storagev1.ResStorageClass) (*storagev1.ResStorageClass, error) {
+	obj, err := c.client.Update(ctx, storageClass)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*storagev1.ResStorageClass), nil
+}
+
+func (c *storageClasses) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+func (c *storageClasses) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	return c.client.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (c *storageClasses) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(ctx, opts)
+}
+
+func (c *storageClasses) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (*storagev1.ResStorageClass, error) {
+	obj, err := c.client.Patch(ctx, name, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*storagev1.ResStorageClass), nil
+}
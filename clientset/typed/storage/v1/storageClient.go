@@ -0,0 +1,22 @@
+package v1
+
+import "k8s-client-go/client"
+
+// storage.k8s.io/v1组的客户端，命名空间为空时list和watch返回所有命名空间的对象
+type StorageV1Interface interface {
+	StorageClasses() StorageClassInterface
+}
+
+type StorageV1Client struct {
+	client client.Interface
+}
+
+var _ StorageV1Interface = &StorageV1Client{}
+
+func NewForClient(c client.Interface) *StorageV1Client {
+	return &StorageV1Client{client: c}
+}
+
+func (c *StorageV1Client) StorageClasses() StorageClassInterface {
+	return newStorageClasses(c.client)
+}
@@ -0,0 +1,202 @@
+package typed
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 各类型客户端共用的实现，对象在请求前转换为Unstructured，返回时解码为New创建的结构体
+type ResourceClient struct {
+	Client     client.Interface
+	ApiVersion string
+	Kind       string
+	Namespace  string // 集群级资源为空
+	New        func() resource.IResource
+}
+
+func (c *ResourceClient) key(name string) resource.ObjectKey {
+	return resource.ObjectKey{ApiVersion: c.ApiVersion, Kind: c.Kind, Namespace: c.Namespace, Name: name}
+}
+
+func (c *ResourceClient) Get(ctx context.Context, name string) (resource.IResource, error) {
+	u := &resource.Unstructured{}
+	if _, err := c.Client.Get(ctx, c.key(name), u); err != nil {
+		return nil, err
+	}
+	return c.decode(u)
+}
+
+// 返回一页对象及list的metadata
+func (c *ResourceClient) List(ctx context.Context, opts client.ListOptions) ([]resource.IResource, resource.ListMeta, error) {
+	opts.Namespace = c.Namespace
+	page, err := c.Client.List(ctx, c.ApiVersion, c.Kind, opts)
+	if err != nil {
+		return nil, resource.ListMeta{}, err
+	}
+	items := make([]resource.IResource, 0, len(page.Items))
+	for i := range page.Items {
+		u := &resource.Unstructured{}
+		if _, err := page.DecodeItem(i, u); err != nil {
+			return nil, resource.ListMeta{}, err
+		}
+		obj, err := c.decode(u)
+		if err != nil {
+			return nil, resource.ListMeta{}, err
+		}
+		items = append(items, obj)
+	}
+	return items, page.Metadata, nil
+}
+
+func (c *ResourceClient) Create(ctx context.Context, obj resource.IResource) (resource.IResource, error) {
+	u, err := c.encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Client.Create(ctx, u); err != nil {
+		return nil, err
+	}
+	return c.decode(u)
+}
+
+func (c *ResourceClient) Update(ctx context.Context, obj resource.IResource) (resource.IResource, error) {
+	u, err := c.encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Client.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return c.decode(u)
+}
+
+func (c *ResourceClient) Delete(ctx context.Context, name string, opts *resource.DeleteOptions) error {
+	return c.Client.Delete(ctx, c.key(name), opts)
+}
+
+// 删除命名空间中匹配listOpts选择器的对象
+func (c *ResourceClient) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts client.ListOptions) error {
+	listOpts.Namespace = c.Namespace
+	return c.Client.DeleteCollection(ctx, c.ApiVersion, c.Kind, opts, listOpts)
+}
+
+func (c *ResourceClient) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte) (resource.IResource, error) {
+	u := &resource.Unstructured{}
+	if _, err := c.Client.Patch(ctx, c.key(name), pt, data, u); err != nil {
+		return nil, err
+	}
+	return c.decode(u)
+}
+
+// 只用于支持scale子资源的类型
+func (c *ResourceClient) GetScale(ctx context.Context, name string) (*autoscalingv1.Scale, error) {
+	return c.Client.GetScale(ctx, c.key(name))
+}
+
+func (c *ResourceClient) UpdateScale(ctx context.Context, name string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	return c.Client.UpdateScale(ctx, c.key(name), scale)
+}
+
+// 事件中的对象为New创建的结构体，Error事件不变
+func (c *ResourceClient) Watch(ctx context.Context, opts client.ListOptions) (watch.Interface, error) {
+	opts.Namespace = c.Namespace
+	w, err := c.Client.Watch(ctx, c.ApiVersion, c.Kind, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newTypedWatcher(w, c.decode), nil
+}
+
+// 转换为Unstructured并设置命名空间，对象的命名空间与客户端不一致时返回错误
+func (c *ResourceClient) encode(obj resource.IResource) (*resource.Unstructured, error) {
+	if obj == nil {
+		return nil, errors.New("object is nil")
+	}
+	m, err := resource.ToManifest(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &resource.Unstructured{Object: m}
+	if u.GetApiVersion() == "" {
+		u.SetApiVersion(c.ApiVersion)
+	}
+	if u.GetKind() == "" {
+		u.SetKind(c.Kind)
+	}
+	if c.Namespace != "" {
+		if ns := u.GetNamespace(); ns != "" && ns != c.Namespace {
+			return nil, errors.New("the namespace of the object does not match the namespace on the request")
+		}
+		u.SetNamespace(c.Namespace)
+	}
+	return u, nil
+}
+
+// 服务端返回的部分字段与结构体定义不一致时返回*resource.PartialDecodeError，同时返回已解码其它字段的对象
+func (c *ResourceClient) decode(u *resource.Unstructured) (resource.IResource, error) {
+	obj := c.New()
+	if err := resource.FromUnstructured(u, obj); err != nil {
+		if !resource.IsPartialDecode(err) {
+			return nil, err
+		}
+		return obj, err
+	}
+	return obj, nil
+}
+
+// 将事件中的Unstructured转换为结构体
+type typedWatcher struct {
+	source watch.Interface
+	decode func(u *resource.Unstructured) (resource.IResource, error)
+	result chan watch.Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newTypedWatcher(source watch.Interface, decode func(u *resource.Unstructured) (resource.IResource, error)) *typedWatcher {
+	w := &typedWatcher{
+		source: source,
+		decode: decode,
+		result: make(chan watch.Event),
+		done:   make(chan struct{}),
+	}
+	go w.receive()
+	return w
+}
+
+func (w *typedWatcher) Stop() {
+	w.once.Do(func() {
+		close(w.done)
+		w.source.Stop()
+	})
+}
+
+func (w *typedWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *typedWatcher) receive() {
+	defer close(w.result)
+	for event := range w.source.ResultChan() {
+		if u, ok := event.Object.(*resource.Unstructured); ok {
+			obj, err := w.decode(u)
+			if err != nil {
+				event = watch.Event{Type: watch.Error, Object: &resource.Status{Status: "Failure", Message: err.Error()}}
+			} else {
+				event.Object = obj
+			}
+		}
+		select {
+		case w.result <- event:
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package discovery
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s-client-go/rest"
+)
+
+// 默认的缓存有效期，与kubectl一致
+const DefaultCacheTTL = 10 * time.Minute
+
+// 将发现的结果缓存在磁盘上，过期后重新获取
+type CachedDiscoveryClient struct {
+	delegate DiscoveryInterface
+	cacheDir string
+	ttl      time.Duration
+
+	lock sync.Mutex
+}
+
+// cacheDir为该集群独占的缓存目录，ttl不大于0时使用DefaultCacheTTL
+func NewCachedDiscoveryClient(delegate DiscoveryInterface, cacheDir string, ttl time.Duration) *CachedDiscoveryClient {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachedDiscoveryClient{delegate: delegate, cacheDir: cacheDir, ttl: ttl}
+}
+
+// 缓存目录为 cacheDir/<apiserver地址>，如 ~/.kube/cache/discovery/192.168.1.10_6443
+func NewCachedDiscoveryClientForConfig(config *rest.Config, cacheDir string, ttl time.Duration) (*CachedDiscoveryClient, error) {
+	restClient, err := rest.NewRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(cacheDir, hostDir(restClient.Host().Host))
+	return NewCachedDiscoveryClient(NewDiscoveryClient(restClient), dir, ttl), nil
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]`)
+
+func hostDir(host string) string {
+	return unsafeChars.ReplaceAllString(host, "_")
+}
+
+func (d *CachedDiscoveryClient) ServerGroups() (*APIGroupList, error) {
+	groups := &APIGroupList{}
+	file := filepath.Join(d.cacheDir, "servergroups.json")
+	if d.readCache(file, groups) {
+		return groups, nil
+	}
+	groups, err := d.delegate.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	d.writeCache(file, groups)
+	return groups, nil
+}
+
+func (d *CachedDiscoveryClient) ServerResourcesForGroupVersion(groupVersion string) (*APIResourceList, error) {
+	resources := &APIResourceList{}
+	file := filepath.Join(d.cacheDir, filepath.FromSlash(groupVersion), "serverresources.json")
+	if d.readCache(file, resources) {
+		return resources, nil
+	}
+	resources, err := d.delegate.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return nil, err
+	}
+	d.writeCache(file, resources)
+	return resources, nil
+}
+
+// 删除所有缓存，下次请求时重新获取
+func (d *CachedDiscoveryClient) Invalidate() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return os.RemoveAll(d.cacheDir)
+}
+
+// 读取未过期的缓存
+func (d *CachedDiscoveryClient) readCache(file string, out interface{}) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > d.ttl {
+		return false
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
+
+// 写入缓存，先写临时文件再重命名，避免其它进程读到不完整的文件；写入失败不影响结果
+func (d *CachedDiscoveryClient) writeCache(file string, in interface{}) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	data, err := json.Marshal(in)
+	if err != nil {
+		return
+	}
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(dir, strings.TrimSuffix(filepath.Base(file), ".json")+".")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,199 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s-client-go/rest"
+)
+
+// 获取服务端支持的API组和资源
+type DiscoveryInterface interface {
+	// 所有API组，核心组（名称为空）排在最前
+	ServerGroups() (*APIGroupList, error)
+	// 某个组版本下的资源，groupVersion如 v1、apps/v1
+	ServerResourcesForGroupVersion(groupVersion string) (*APIResourceList, error)
+}
+
+type DiscoveryClient struct {
+	rest *rest.RESTClient
+}
+
+func NewDiscoveryClient(restClient *rest.RESTClient) *DiscoveryClient {
+	return &DiscoveryClient{rest: restClient}
+}
+
+func NewDiscoveryClientForConfig(config *rest.Config) (*DiscoveryClient, error) {
+	restClient, err := rest.NewRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewDiscoveryClient(restClient), nil
+}
+
+func (d *DiscoveryClient) ServerGroups() (*APIGroupList, error) {
+	versions := &APIVersions{}
+	if err := d.get("/api", versions); err != nil && !rest.IsNotFound(err) {
+		return nil, err
+	}
+	groups := &APIGroupList{}
+	if err := d.get("/apis", groups); err != nil && !rest.IsNotFound(err) {
+		return nil, err
+	}
+
+	if len(versions.Versions) > 0 {
+		legacy := APIGroup{}
+		for _, v := range versions.Versions {
+			legacy.Versions = append(legacy.Versions, GroupVersionForDiscovery{GroupVersion: v, Version: v})
+		}
+		legacy.PreferredVersion = legacy.Versions[0]
+		groups.Groups = append([]APIGroup{legacy}, groups.Groups...)
+	}
+	return groups, nil
+}
+
+func (d *DiscoveryClient) ServerResourcesForGroupVersion(groupVersion string) (*APIResourceList, error) {
+	// 核心组的版本不带组名，路径为 /api/v1
+	path := "/apis/" + groupVersion
+	if !strings.Contains(groupVersion, "/") {
+		path = "/api/" + groupVersion
+	}
+	resources := &APIResourceList{GroupVersion: groupVersion}
+	if err := d.get(path, resources); err != nil {
+		return nil, err
+	}
+	// 部分服务端返回的列表中不带groupVersion
+	if resources.GroupVersion == "" {
+		resources.GroupVersion = groupVersion
+	}
+	return resources, nil
+}
+
+func (d *DiscoveryClient) get(path string, out interface{}) error {
+	resp, err := d.rest.NewRequest(path).Get()
+	if err != nil {
+		return err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// 部分组版本的资源获取失败，其余组版本的结果仍然可用
+type GroupDiscoveryFailedError struct {
+	Groups map[string]error
+}
+
+func (e *GroupDiscoveryFailedError) Error() string {
+	var groups []string
+	for gv, err := range e.Groups {
+		groups = append(groups, fmt.Sprintf("%s: %v", gv, err))
+	}
+	sort.Strings(groups)
+	return "unable to retrieve the complete list of server APIs: " + strings.Join(groups, ", ")
+}
+
+func IsGroupDiscoveryFailedError(err error) bool {
+	_, ok := err.(*GroupDiscoveryFailedError)
+	return ok
+}
+
+// 获取所有组以及每个组版本下的资源
+// 部分组版本失败时返回已获取的结果和GroupDiscoveryFailedError
+func ServerGroupsAndResources(d DiscoveryInterface) ([]APIGroup, []*APIResourceList, error) {
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+	var lists []*APIResourceList
+	failed := map[string]error{}
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			list, err := d.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				failed[version.GroupVersion] = err
+				continue
+			}
+			lists = append(lists, list)
+		}
+	}
+	if len(failed) > 0 {
+		return groups.Groups, lists, &GroupDiscoveryFailedError{Groups: failed}
+	}
+	return groups.Groups, lists, nil
+}
+
+// 每个组只取首选版本下的资源
+func ServerPreferredResources(d DiscoveryInterface) ([]*APIResourceList, error) {
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	var lists []*APIResourceList
+	failed := map[string]error{}
+	for _, group := range groups.Groups {
+		gv := preferredVersion(group)
+		if gv == "" {
+			continue
+		}
+		list, err := d.ServerResourcesForGroupVersion(gv)
+		if err != nil {
+			failed[gv] = err
+			continue
+		}
+		lists = append(lists, list)
+	}
+	if len(failed) > 0 {
+		return lists, &GroupDiscoveryFailedError{Groups: failed}
+	}
+	return lists, nil
+}
+
+// 服务端是否提供某个组版本，如 networking.k8s.io/v1
+func IsGroupVersionSupported(d DiscoveryInterface, groupVersion string) (bool, error) {
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			if version.GroupVersion == groupVersion {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// 服务端是否在某个组版本下提供kind，如 IsResourceSupported(d, "networking.k8s.io/v1", "Ingress")
+func IsResourceSupported(d DiscoveryInterface, groupVersion, kind string) (bool, error) {
+	supported, err := IsGroupVersionSupported(d, groupVersion)
+	if err != nil || !supported {
+		return false, err
+	}
+	list, err := d.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range list.Resources {
+		if r.Kind == kind && !r.IsSubresource() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 组的首选版本，服务端未指定时使用第一个版本
+func preferredVersion(group APIGroup) string {
+	if group.PreferredVersion.GroupVersion != "" {
+		return group.PreferredVersion.GroupVersion
+	}
+	if len(group.Versions) > 0 {
+		return group.Versions[0].GroupVersion
+	}
+	return ""
+}
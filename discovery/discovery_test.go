@@ -0,0 +1,173 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-client-go/rest"
+)
+
+var discoveryDocs = map[string]string{
+	"/api": `{"kind":"APIVersions","versions":["v1"]}`,
+	"/apis": `{"kind":"APIGroupList","groups":[
+		{"name":"apps","versions":[{"groupVersion":"apps/v1","version":"v1"}],"preferredVersion":{"groupVersion":"apps/v1","version":"v1"}},
+		{"name":"extensions","versions":[{"groupVersion":"extensions/v1beta1","version":"v1beta1"}],"preferredVersion":{"groupVersion":"extensions/v1beta1","version":"v1beta1"}},
+		{"name":"networking.k8s.io","versions":[{"groupVersion":"networking.k8s.io/v1","version":"v1"},{"groupVersion":"networking.k8s.io/v1beta1","version":"v1beta1"}],"preferredVersion":{"groupVersion":"networking.k8s.io/v1","version":"v1"}}
+	]}`,
+	"/api/v1": `{"kind":"APIResourceList","groupVersion":"v1","resources":[
+		{"name":"pods","singularName":"","namespaced":true,"kind":"Pod","verbs":["create","delete","get","list","patch","update","watch"],"shortNames":["po"]},
+		{"name":"pods/log","singularName":"","namespaced":true,"kind":"Pod","verbs":["get"]},
+		{"name":"nodes","singularName":"","namespaced":false,"kind":"Node","verbs":["get","list"],"shortNames":["no"]}
+	]}`,
+	"/apis/apps/v1": `{"kind":"APIResourceList","groupVersion":"apps/v1","resources":[
+		{"name":"deployments","singularName":"","namespaced":true,"kind":"Deployment","verbs":["get","list","patch"],"shortNames":["deploy"]}
+	]}`,
+	"/apis/extensions/v1beta1": `{"kind":"APIResourceList","groupVersion":"extensions/v1beta1","resources":[
+		{"name":"ingresses","singularName":"","namespaced":true,"kind":"Ingress","verbs":["get","list"],"shortNames":["ing"]}
+	]}`,
+	"/apis/networking.k8s.io/v1": `{"kind":"APIResourceList","groupVersion":"networking.k8s.io/v1","resources":[
+		{"name":"networkpolicies","singularName":"","namespaced":true,"kind":"NetworkPolicy","verbs":["get","list"],"shortNames":["netpol"]}
+	]}`,
+	"/apis/networking.k8s.io/v1beta1": `{"kind":"APIResourceList","groupVersion":"networking.k8s.io/v1beta1","resources":[
+		{"name":"ingresses","singularName":"","namespaced":true,"kind":"Ingress","verbs":["get","list"],"shortNames":["ing"]}
+	]}`,
+}
+
+func newDiscoveryServer(t *testing.T, requests *int) *rest.Config {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		doc, ok := discoveryDocs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+	t.Cleanup(server.Close)
+	return &rest.Config{Host: server.URL}
+}
+
+func TestDiscoveryClient(t *testing.T) {
+	requests := 0
+	d, err := NewDiscoveryClientForConfig(newDiscoveryServer(t, &requests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups, lists, err := ServerGroupsAndResources(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 4 || groups[0].Name != "" || len(lists) != 5 {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+	pods := lists[0].Resources[0]
+	if pods.Name != "pods" || !pods.Namespaced || !pods.SupportsVerb("watch") || pods.ShortNames[0] != "po" {
+		t.Fatalf("unexpected resource %+v", pods)
+	}
+
+	preferred, err := ServerPreferredResources(d)
+	if err != nil || len(preferred) != 4 || preferred[3].GroupVersion != "networking.k8s.io/v1" {
+		t.Fatalf("unexpected preferred resources %v, err %v", preferred, err)
+	}
+
+	// networking.k8s.io/v1 已提供但其中没有Ingress
+	if ok, _ := IsGroupVersionSupported(d, "networking.k8s.io/v1"); !ok {
+		t.Fatal("expected networking.k8s.io/v1 to be supported")
+	}
+	if ok, _ := IsResourceSupported(d, "networking.k8s.io/v1", "Ingress"); ok {
+		t.Fatal("expected Ingress not to be served in networking.k8s.io/v1")
+	}
+	if ok, _ := IsResourceSupported(d, "networking.k8s.io/v1beta1", "Ingress"); !ok {
+		t.Fatal("expected Ingress in networking.k8s.io/v1beta1")
+	}
+}
+
+func TestCachedDiscoveryClient(t *testing.T) {
+	requests := 0
+	dir, err := ioutil.TempDir("", "discovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	d, err := NewCachedDiscoveryClientForConfig(newDiscoveryServer(t, &requests), dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ServerGroupsAndResources(d); err != nil {
+		t.Fatal(err)
+	}
+	first := requests
+	if _, _, err := ServerGroupsAndResources(d); err != nil {
+		t.Fatal(err)
+	}
+	if requests != first {
+		t.Fatalf("expected cached results, got %d new requests", requests-first)
+	}
+	if _, err := os.Stat(filepath.Join(d.cacheDir, "apps", "v1", "serverresources.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 过期后重新请求
+	d.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, err := d.ServerGroups(); err != nil || requests == first {
+		t.Fatalf("expected refresh after ttl, err %v", err)
+	}
+}
+
+func TestDiscoveryRESTMapper(t *testing.T) {
+	requests := 0
+	d, _ := NewDiscoveryClientForConfig(newDiscoveryServer(t, &requests))
+	mapper := NewRESTMapper(d)
+
+	mapping, err := mapper.RESTMapping("apps/v1", "Deployment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mapping.ResourcePath("default", "web"); got != "/apis/apps/v1/namespaces/default/deployments/web" {
+		t.Fatalf("unexpected path %s", got)
+	}
+
+	mapping, resource, err := mapper.ResourceFor("ing")
+	if err != nil || mapping.GroupVersion() != "extensions/v1beta1" || resource.Kind != "Ingress" {
+		t.Fatalf("unexpected mapping %+v, err %v", mapping, err)
+	}
+	mapping, _, err = mapper.ResourceFor("ingresses.networking.k8s.io")
+	if err != nil || mapping.GroupVersion() != "networking.k8s.io/v1beta1" {
+		t.Fatalf("unexpected mapping %+v, err %v", mapping, err)
+	}
+	if mapping, _, err = mapper.ResourceFor("no"); err != nil || mapping.Namespaced {
+		t.Fatalf("unexpected node mapping %+v, err %v", mapping, err)
+	}
+	if gv, _ := mapper.PreferredVersion("networking.k8s.io"); gv != "networking.k8s.io/v1" {
+		t.Fatalf("unexpected preferred version %s", gv)
+	}
+
+	// 刚加载过，反复查找不存在的kind不会重新发现
+	loaded := requests
+	for i := 0; i < 3; i++ {
+		if _, err := mapper.RESTMapping("networking.k8s.io/v1", "Ingress"); err == nil {
+			t.Fatal("expected no match")
+		}
+	}
+	if requests != loaded {
+		t.Fatalf("expected no refresh within the reload interval, got %d new requests", requests-loaded)
+	}
+
+	mapper.lastLoad = time.Now().Add(-mapperReloadInterval)
+	if _, err := mapper.RESTMapping("networking.k8s.io/v1", "Ingress"); err == nil {
+		t.Fatal("expected no match")
+	}
+	if requests == loaded {
+		t.Fatal("expected a refresh on miss after the reload interval")
+	}
+	refreshed := requests
+	if _, err := mapper.RESTMapping("networking.k8s.io/v1", "Ingress"); err == nil || requests != refreshed {
+		t.Fatalf("expected no second refresh, got %d new requests, err %v", requests-refreshed, err)
+	}
+}
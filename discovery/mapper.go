@@ -0,0 +1,202 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s-client-go/rest"
+)
+
+// 根据发现的结果确定kind对应的资源路径，首次使用时才请求服务端
+// 找不到时会重新获取一次，以便识别新注册的CRD，两次重新获取至少间隔mapperReloadInterval
+type DiscoveryRESTMapper struct {
+	client DiscoveryInterface
+
+	lock      sync.Mutex
+	loaded    bool
+	lastLoad  time.Time     // 上次从服务端加载的时间
+	entries   []mapperEntry // 按组的顺序排列，每个组的首选版本在前
+	preferred map[string]string
+}
+
+type mapperEntry struct {
+	mapping  rest.RESTMapping
+	resource APIResource
+}
+
+// 找不到kind时重新发现的最小间隔，避免反复查找未安装的CRD时每次都重新请求全部API
+var mapperReloadInterval = 30 * time.Second
+
+func NewRESTMapper(client DiscoveryInterface) *DiscoveryRESTMapper {
+	return &DiscoveryRESTMapper{client: client}
+}
+
+// 实现rest.RESTMapper，apiVersion需要是服务端提供的组版本
+func (m *DiscoveryRESTMapper) RESTMapping(apiVersion, kind string) (*rest.RESTMapping, error) {
+	group, version, err := rest.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := m.find(func(e *mapperEntry) bool {
+		return e.mapping.Group == group && e.mapping.Version == version && e.mapping.Kind == kind
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("no matches for kind %q in version %q", kind, apiVersion)
+	}
+	return &entry.mapping, nil
+}
+
+// 组内kind的映射，优先使用组的首选版本
+func (m *DiscoveryRESTMapper) RESTMappingForGroupKind(group, kind string) (*rest.RESTMapping, error) {
+	entry, err := m.find(func(e *mapperEntry) bool {
+		return e.mapping.Group == group && e.mapping.Kind == kind
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("no matches for kind %q in group %q", kind, group)
+	}
+	return &entry.mapping, nil
+}
+
+// 按资源名查找，支持复数、单数、简称和kind，可带组名，如 deploy、deployments.apps、ing
+func (m *DiscoveryRESTMapper) ResourceFor(name string) (*rest.RESTMapping, *APIResource, error) {
+	name = strings.ToLower(name)
+	group, hasGroup := "", false
+	if i := strings.Index(name, "."); i > 0 {
+		name, group, hasGroup = name[:i], name[i+1:], true
+	}
+	entry, err := m.find(func(e *mapperEntry) bool {
+		if hasGroup && e.mapping.Group != group {
+			return false
+		}
+		r := e.resource
+		if r.Name == name || r.SingularName == name || strings.ToLower(r.Kind) == name {
+			return true
+		}
+		for _, short := range r.ShortNames {
+			if short == name {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry == nil {
+		return nil, nil, fmt.Errorf("the server doesn't have a resource type %q", name)
+	}
+	resource := entry.resource
+	return &entry.mapping, &resource, nil
+}
+
+// 组的首选版本，如 apps/v1
+func (m *DiscoveryRESTMapper) PreferredVersion(group string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.load(); err != nil {
+		return "", err
+	}
+	gv, ok := m.preferred[group]
+	if !ok {
+		return "", fmt.Errorf("the server doesn't have group %q", group)
+	}
+	return gv, nil
+}
+
+// 清除已加载的映射，缓存的发现客户端会同时清除磁盘缓存
+func (m *DiscoveryRESTMapper) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.reset()
+}
+
+func (m *DiscoveryRESTMapper) reset() {
+	m.loaded = false
+	m.entries = nil
+	m.preferred = nil
+	if invalidator, ok := m.client.(interface{ Invalidate() error }); ok {
+		invalidator.Invalidate()
+	}
+}
+
+// 查找第一个匹配的映射，找不到且距上次加载超过mapperReloadInterval时重新加载后再找一次
+func (m *DiscoveryRESTMapper) find(match func(e *mapperEntry) bool) (*mapperEntry, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for attempt := 0; attempt < 2; attempt++ {
+		if attempt > 0 {
+			if time.Since(m.lastLoad) < mapperReloadInterval {
+				break
+			}
+			m.reset()
+		}
+		if err := m.load(); err != nil {
+			return nil, err
+		}
+		for i := range m.entries {
+			if match(&m.entries[i]) {
+				entry := m.entries[i]
+				return &entry, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *DiscoveryRESTMapper) load() error {
+	if m.loaded {
+		return nil
+	}
+	groups, err := m.client.ServerGroups()
+	if err != nil {
+		return err
+	}
+	var entries []mapperEntry
+	preferred := map[string]string{}
+	failed := map[string]error{}
+	for _, group := range groups.Groups {
+		preferred[group.Name] = preferredVersion(group)
+		// 首选版本排在最前
+		versions := []string{preferred[group.Name]}
+		for _, v := range group.Versions {
+			if v.GroupVersion != preferred[group.Name] {
+				versions = append(versions, v.GroupVersion)
+			}
+		}
+		for _, gv := range versions {
+			list, err := m.client.ServerResourcesForGroupVersion(gv)
+			if err != nil {
+				failed[gv] = err
+				continue
+			}
+			g, v, err := rest.ParseGroupVersion(gv)
+			if err != nil {
+				return err
+			}
+			for _, r := range list.Resources {
+				if r.IsSubresource() {
+					continue
+				}
+				mapping := rest.RESTMapping{Group: g, Version: v, Resource: r.Name, Kind: r.Kind, Namespaced: r.Namespaced}
+				entries = append(entries, mapperEntry{mapping: mapping, resource: r})
+			}
+		}
+	}
+	// 部分组版本失败时仍使用其余的结果，全部失败时返回错误
+	if len(entries) == 0 && len(failed) > 0 {
+		return &GroupDiscoveryFailedError{Groups: failed}
+	}
+	m.entries = entries
+	m.preferred = preferred
+	m.loaded = true
+	m.lastLoad = time.Now()
+	return nil
+}
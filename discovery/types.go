@@ -0,0 +1,60 @@
+package discovery
+
+import "strings"
+
+// /api 返回的核心组版本
+type APIVersions struct {
+	Kind     string
+	Versions []string
+}
+
+// /apis 返回的API组列表
+type APIGroupList struct {
+	Kind   string
+	Groups []APIGroup
+}
+
+type APIGroup struct {
+	Name             string
+	Versions         []GroupVersionForDiscovery
+	PreferredVersion GroupVersionForDiscovery `json:"preferredVersion"`
+}
+
+type GroupVersionForDiscovery struct {
+	GroupVersion string `json:"groupVersion"` // 如 apps/v1
+	Version      string
+}
+
+// /apis/<group>/<version> 返回的资源列表
+type APIResourceList struct {
+	Kind         string
+	GroupVersion string `json:"groupVersion"`
+	Resources    []APIResource
+}
+
+type APIResource struct {
+	Name         string // 复数形式，子资源如 pods/log
+	SingularName string `json:"singularName"`
+	Namespaced   bool
+	Group        string // 为空时与所在列表的组相同
+	Version      string
+	Kind         string
+	Verbs        []string
+	ShortNames   []string `json:"shortNames"`
+	Categories   []string
+}
+
+// 是否支持某个操作，如 list、watch、patch
+func (r *APIResource) SupportsVerb(verb string) bool {
+	for _, v := range r.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// 是否为子资源，如 pods/log、deployments/scale
+func (r *APIResource) IsSubresource() bool {
+	return strings.Contains(r.Name, "/")
+}
@@ -0,0 +1,314 @@
+package drain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/wait"
+)
+
+// kubelet为静态pod创建的镜像pod带有这个注解，无法通过apiserver删除
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// 驱逐被PodDisruptionBudget拒绝后重试的间隔
+var evictionRetryInterval = 5 * time.Second
+
+func nodeKey(name string) resource.ObjectKey {
+	return resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_NODE, Name: name}
+}
+
+// 将节点标记为不可调度，已标记时不做修改
+func Cordon(ctx context.Context, c client.Interface, nodeName string) error {
+	return setUnschedulable(ctx, c, nodeName, true)
+}
+
+// 取消节点的不可调度标记
+func Uncordon(ctx context.Context, c client.Interface, nodeName string) error {
+	return setUnschedulable(ctx, c, nodeName, false)
+}
+
+func setUnschedulable(ctx context.Context, c client.Interface, nodeName string, unschedulable bool) error {
+	node := &resource.Unstructured{}
+	if _, err := c.Get(ctx, nodeKey(nodeName), node); err != nil {
+		return err
+	}
+	if current, _, _ := resource.NestedBool(node.Object, "spec", "unschedulable"); current == unschedulable {
+		return nil
+	}
+	data, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"unschedulable": unschedulable}})
+	if err != nil {
+		return err
+	}
+	_, err = c.Patch(ctx, nodeKey(nodeName), rest.MergePatchType, data, nil)
+	return err
+}
+
+// 驱逐节点上的pod，与 kubectl drain 的参数对应
+type Helper struct {
+	Client client.Interface
+	// 删除不受控制器管理的pod
+	Force bool
+	// 小于0时使用pod自身的terminationGracePeriodSeconds
+	GracePeriodSeconds int64
+	// 忽略DaemonSet管理的pod，为false时节点上有这类pod则报错
+	IgnoreAllDaemonSets bool
+	// 删除使用emptyDir的pod，其中的数据会丢失
+	DeleteEmptyDirData bool
+	// 驱逐并等待pod删除的总时间，0表示不限制
+	Timeout time.Duration
+	// 直接删除pod而不是通过Eviction API驱逐，不检查PodDisruptionBudget
+	DisableEviction bool
+	// 只驱逐匹配该标签选择器的pod
+	PodSelector string
+
+	Out    io.Writer
+	ErrOut io.Writer
+	// 每个pod被删除后调用
+	OnPodDeletedOrEvicted func(pod *resource.Unstructured, usingEviction bool)
+
+	outLock sync.Mutex
+}
+
+func NewHelper(c client.Interface) *Helper {
+	return &Helper{Client: c, GracePeriodSeconds: -1}
+}
+
+func (h *Helper) printf(out io.Writer, format string, args ...interface{}) {
+	if out == nil {
+		return
+	}
+	h.outLock.Lock()
+	defer h.outLock.Unlock()
+	fmt.Fprintf(out, format, args...)
+}
+
+// 标记节点不可调度后驱逐其上的pod，等待pod全部删除
+func (h *Helper) Drain(ctx context.Context, nodeName string) error {
+	if err := Cordon(ctx, h.Client, nodeName); err != nil {
+		return err
+	}
+	h.printf(h.Out, "node/%s cordoned\n", nodeName)
+	pods, warnings, err := h.GetPodsForDeletion(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	if warnings != "" {
+		h.printf(h.ErrOut, "WARNING: %s\n", warnings)
+	}
+	if err := h.DeleteOrEvictPods(ctx, pods); err != nil {
+		return err
+	}
+	h.printf(h.Out, "node/%s drained\n", nodeName)
+	return nil
+}
+
+// 按原因汇总的pod列表，输出为 "原因: ns/a, ns/b; 原因: ns/c"
+type podMessages struct {
+	order []string
+	pods  map[string][]string
+}
+
+func (m *podMessages) add(message string, pod *resource.Unstructured) {
+	if m.pods == nil {
+		m.pods = map[string][]string{}
+	}
+	if _, ok := m.pods[message]; !ok {
+		m.order = append(m.order, message)
+	}
+	m.pods[message] = append(m.pods[message], pod.GetNamespace()+"/"+pod.GetName())
+}
+
+func (m *podMessages) String() string {
+	var msgs []string
+	for _, message := range m.order {
+		msgs = append(msgs, message+": "+strings.Join(m.pods[message], ", "))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// 列出节点上需要驱逐的pod，跳过镜像pod和被忽略的DaemonSet pod
+// 返回的warnings汇总了被跳过或强制删除的pod；存在不允许删除的pod时返回错误
+func (h *Helper) GetPodsForDeletion(ctx context.Context, nodeName string) ([]*resource.Unstructured, string, error) {
+	pager := client.NewListPager(h.Client, "v1", resource.RESOURCE_POD, client.ListOptions{
+		LabelSelector: h.PodSelector,
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	list, err := pager.List(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	var pods []*resource.Unstructured
+	warnings, errs := &podMessages{}, &podMessages{}
+	for i := range list.Items {
+		pod := &resource.Unstructured{}
+		if _, err := list.DecodeItem(i, pod); err != nil {
+			return nil, "", err
+		}
+		if _, ok := pod.GetAnnotations()[mirrorPodAnnotation]; ok {
+			continue
+		}
+		finished := podFinished(pod)
+		controller := controllerRef(pod)
+		if controller != nil && controller["kind"] == resource.RESOURCE_DAEMONSET {
+			if !h.IgnoreAllDaemonSets {
+				errs.add("cannot delete DaemonSet-managed Pods (use --ignore-daemonsets to ignore)", pod)
+			} else {
+				warnings.add("ignoring DaemonSet-managed Pods", pod)
+			}
+			continue
+		}
+		allowed := true
+		if hasEmptyDir(pod) && !finished {
+			if h.DeleteEmptyDirData {
+				warnings.add("deleting Pods with local storage", pod)
+			} else {
+				errs.add("cannot delete Pods with local storage (use --delete-emptydir-data to override)", pod)
+				allowed = false
+			}
+		}
+		if controller == nil && !finished {
+			if h.Force {
+				warnings.add("deleting Pods that declare no controller", pod)
+			} else {
+				errs.add("cannot delete Pods declare no controller (use --force to override)", pod)
+				allowed = false
+			}
+		}
+		if !allowed {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	if len(errs.order) > 0 {
+		return nil, "", errors.New(errs.String())
+	}
+	return pods, warnings.String(), nil
+}
+
+func podFinished(pod *resource.Unstructured) bool {
+	phase, _, _ := resource.NestedString(pod.Object, "status", "phase")
+	return phase == "Succeeded" || phase == "Failed"
+}
+
+func controllerRef(pod *resource.Unstructured) map[string]interface{} {
+	refs, _, _ := resource.NestedSlice(pod.Object, "metadata", "ownerReferences")
+	for _, ref := range refs {
+		if m, ok := ref.(map[string]interface{}); ok && m["controller"] == true {
+			return m
+		}
+	}
+	return nil
+}
+
+func hasEmptyDir(pod *resource.Unstructured) bool {
+	volumes, _, _ := resource.NestedSlice(pod.Object, "spec", "volumes")
+	for _, volume := range volumes {
+		if m, ok := volume.(map[string]interface{}); ok && m["emptyDir"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// 并发驱逐（或删除）pods并等待其被删除，返回所有失败的pod的错误
+func (h *Helper) DeleteOrEvictPods(ctx context.Context, pods []*resource.Unstructured) error {
+	if len(pods) == 0 {
+		return nil
+	}
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+	errCh := make(chan error, len(pods))
+	for _, pod := range pods {
+		go func(pod *resource.Unstructured) {
+			errCh <- h.deleteOrEvictPod(ctx, pod)
+		}(pod)
+	}
+	var msgs []string
+	for range pods {
+		if err := <-errCh; err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func (h *Helper) deleteOrEvictPod(ctx context.Context, pod *resource.Unstructured) error {
+	key := pod.GetObjectKey()
+	key.ApiVersion, key.Kind = "v1", resource.RESOURCE_POD
+	if h.DisableEviction {
+		h.printf(h.Out, "deleting pod %s/%s\n", key.Namespace, key.Name)
+		if err := h.Client.Delete(ctx, key, h.deleteOptions()); err != nil && !rest.IsNotFound(err) {
+			return fmt.Errorf("error when deleting pod %s/%s: %v", key.Namespace, key.Name, err)
+		}
+	} else if err := h.evictPod(ctx, pod); err != nil {
+		return err
+	}
+
+	// pod被删除或已被同名的新pod替换
+	uid := pod.GetUid()
+	_, err := wait.WaitForKey(ctx, h.Client, key, func(obj *resource.Unstructured) (bool, error) {
+		return obj == nil || obj.GetUid() != uid, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when waiting for pod %s/%s to be deleted: %v", key.Namespace, key.Name, err)
+	}
+	if h.OnPodDeletedOrEvicted != nil {
+		h.OnPodDeletedOrEvicted(pod, !h.DisableEviction)
+	}
+	if h.DisableEviction {
+		h.printf(h.Out, "pod/%s deleted\n", key.Name)
+	} else {
+		h.printf(h.Out, "pod/%s evicted\n", key.Name)
+	}
+	return nil
+}
+
+func (h *Helper) deleteOptions() *resource.DeleteOptions {
+	opts := &resource.DeleteOptions{}
+	if h.GracePeriodSeconds >= 0 {
+		gracePeriod := h.GracePeriodSeconds
+		opts.GracePeriodSeconds = &gracePeriod
+	}
+	return opts
+}
+
+// 被PodDisruptionBudget拒绝（429）时按服务端建议的间隔或evictionRetryInterval重试，直到成功或ctx结束
+func (h *Helper) evictPod(ctx context.Context, pod *resource.Unstructured) error {
+	opts := h.deleteOptions()
+	namespace, name := pod.GetNamespace(), pod.GetName()
+	h.printf(h.Out, "evicting pod %s/%s\n", namespace, name)
+	for {
+		err := h.Client.Evict(ctx, namespace, name, opts)
+		if err == nil || rest.IsNotFound(err) {
+			return nil
+		}
+		if !rest.IsTooManyRequests(err) {
+			return fmt.Errorf("error when evicting pod %s/%s: %v", namespace, name, err)
+		}
+		interval := evictionRetryInterval
+		if seconds, ok := rest.SuggestsClientDelay(err); ok {
+			interval = time.Duration(seconds) * time.Second
+		}
+		h.printf(h.ErrOut, "error when evicting pod %s/%s (will retry after %v): %v\n", namespace, name, interval, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error when evicting pod %s/%s: %v", namespace, name, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package drain
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-client-go/client/fake"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+func newNode(name string) *resource.Unstructured {
+	node := resource.NewUnstructured("v1", resource.RESOURCE_NODE)
+	node.SetName(name)
+	return node
+}
+
+func newNodePod(name, ownerKind string) *resource.Unstructured {
+	pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+	pod.SetName(name)
+	pod.SetNamespace("default")
+	resource.SetNestedField(pod.Object, name+"-uid", "metadata", "uid")
+	resource.SetNestedField(pod.Object, "node-1", "spec", "nodeName")
+	if ownerKind != "" {
+		resource.SetNestedSlice(pod.Object, []interface{}{map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": ownerKind, "name": "owner", "uid": "owner-uid", "controller": true,
+		}}, "metadata", "ownerReferences")
+	}
+	return pod
+}
+
+func TestCordon(t *testing.T) {
+	c := fake.NewSimpleClient(newNode("node-1"))
+	ctx := context.Background()
+	unschedulable := func() bool {
+		node := &resource.Unstructured{}
+		c.Get(ctx, nodeKey("node-1"), node)
+		v, _, _ := resource.NestedBool(node.Object, "spec", "unschedulable")
+		return v
+	}
+
+	if err := Cordon(ctx, c, "node-1"); err != nil || !unschedulable() {
+		t.Fatalf("node not cordoned, err %v", err)
+	}
+	c.ClearActions()
+	if err := Cordon(ctx, c, "node-1"); err != nil || len(c.Actions()) != 1 {
+		t.Fatalf("expected no patch for a cordoned node, actions %v, err %v", c.Actions(), err)
+	}
+	if err := Uncordon(ctx, c, "node-1"); err != nil || unschedulable() {
+		t.Fatalf("node not uncordoned, err %v", err)
+	}
+	if err := Cordon(ctx, c, "node-2"); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestGetPodsForDeletion(t *testing.T) {
+	mirror := newNodePod("mirror", "")
+	mirror.SetAnnotations(map[string]string{mirrorPodAnnotation: "hash"})
+	local := newNodePod("local", resource.RESOURCE_REPLICASET)
+	resource.SetNestedSlice(local.Object, []interface{}{map[string]interface{}{"name": "cache", "emptyDir": map[string]interface{}{}}}, "spec", "volumes")
+	finished := newNodePod("finished", "")
+	resource.SetNestedField(finished.Object, "Succeeded", "status", "phase")
+	other := newNodePod("other", resource.RESOURCE_REPLICASET)
+	resource.SetNestedField(other.Object, "node-2", "spec", "nodeName")
+
+	c := fake.NewSimpleClient(newNodePod("web", resource.RESOURCE_REPLICASET), newNodePod("agent", resource.RESOURCE_DAEMONSET),
+		newNodePod("bare", ""), mirror, local, finished, other)
+	ctx := context.Background()
+
+	h := NewHelper(c)
+	_, _, err := h.GetPodsForDeletion(ctx, "node-1")
+	for _, expected := range []string{"use --ignore-daemonsets to ignore): default/agent", "use --delete-emptydir-data to override): default/local", "use --force to override): default/bare"} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected error containing %q, got %v", expected, err)
+		}
+	}
+
+	h.IgnoreAllDaemonSets, h.DeleteEmptyDirData, h.Force = true, true, true
+	pods, warnings, err := h.GetPodsForDeletion(ctx, "node-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.GetName())
+	}
+	if strings.Join(names, ",") != "bare,finished,local,web" {
+		t.Fatalf("unexpected pods %v", names)
+	}
+	if !strings.Contains(warnings, "ignoring DaemonSet-managed Pods: default/agent") || strings.Contains(warnings, "mirror") {
+		t.Fatalf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	evictionRetryInterval = 10 * time.Millisecond
+	c := fake.NewSimpleClient(newNode("node-1"), newNodePod("web", resource.RESOURCE_REPLICASET),
+		newNodePod("db", resource.RESOURCE_STATEFULE_SET), newNodePod("agent", resource.RESOURCE_DAEMONSET))
+	blocked := true
+	c.PrependReactor("create", "pods", func(action fake.Action) (bool, interface{}, error) {
+		if action.Subresource == "eviction" && action.Name == "db" && blocked {
+			blocked = false
+			return true, nil, &rest.StatusError{Status: resource.Status{Code: 429, Reason: rest.StatusReasonTooManyRequests,
+				Message: "Cannot evict pod as it would violate the pod's disruption budget."}}
+		}
+		return false, nil, nil
+	})
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	h := NewHelper(c)
+	h.IgnoreAllDaemonSets = true
+	h.Timeout = 5 * time.Second
+	h.Out, h.ErrOut = out, errOut
+	evicted := make(chan string, 2)
+	h.OnPodDeletedOrEvicted = func(pod *resource.Unstructured, usingEviction bool) {
+		if usingEviction {
+			evicted <- pod.GetName()
+		}
+	}
+	if err := h.Drain(context.Background(), "node-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(evicted) != 2 {
+		t.Fatalf("expected 2 evicted pods, got %d", len(evicted))
+	}
+	if !strings.Contains(out.String(), "pod/db evicted") || !strings.Contains(out.String(), "node/node-1 drained") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "will retry after") || !strings.Contains(errOut.String(), "default/agent") {
+		t.Fatalf("unexpected error output:\n%s", errOut.String())
+	}
+	pod := &resource.Unstructured{}
+	if _, err := c.Get(context.Background(), resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: "default", Name: "agent"}, pod); err != nil {
+		t.Fatalf("DaemonSet pod should be kept, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 按组、版本和资源名操作任意资源，对象以Unstructured表示
+type Interface interface {
+	Resource(gvr resource.GroupVersionResource) NamespaceableResourceInterface
+}
+
+type ResourceInterface interface {
+	Create(ctx context.Context, obj *resource.Unstructured, subresources ...string) (*resource.Unstructured, error)
+	Update(ctx context.Context, obj *resource.Unstructured, subresources ...string) (*resource.Unstructured, error)
+	UpdateStatus(ctx context.Context, obj *resource.Unstructured) (*resource.Unstructured, error)
+	Delete(ctx context.Context, name string, opts *resource.DeleteOptions, subresources ...string) error
+	DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts ListOptions) error
+	Get(ctx context.Context, name string, subresources ...string) (*resource.Unstructured, error)
+	List(ctx context.Context, opts ListOptions) (*resource.UnstructuredList, error)
+	Watch(ctx context.Context, opts ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte, subresources ...string) (*resource.Unstructured, error)
+}
+
+// 集群级资源直接使用，命名空间级资源通过Namespace指定命名空间，不指定时表示所有命名空间
+type NamespaceableResourceInterface interface {
+	Namespace(namespace string) ResourceInterface
+	ResourceInterface
+}
+
+// list和watch的参数
+type ListOptions struct {
+	LabelSelector   string
+	FieldSelector   string
+	Limit           int64
+	Continue        string
+	ResourceVersion string
+	TimeoutSeconds  int64 // watch的超时时间，0表示由服务端决定
+}
+
+type DynamicClient struct {
+	rest *rest.RESTClient
+}
+
+func NewDynamicClient(restClient *rest.RESTClient) *DynamicClient {
+	return &DynamicClient{rest: restClient}
+}
+
+func NewForConfig(config *rest.Config) (*DynamicClient, error) {
+	restClient, err := rest.NewRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewDynamicClient(restClient), nil
+}
+
+func (c *DynamicClient) Resource(gvr resource.GroupVersionResource) NamespaceableResourceInterface {
+	return &dynamicResource{client: c, gvr: gvr}
+}
+
+type dynamicResource struct {
+	client    *DynamicClient
+	gvr       resource.GroupVersionResource
+	namespace string
+}
+
+func (r *dynamicResource) Namespace(namespace string) ResourceInterface {
+	return &dynamicResource{client: r.client, gvr: r.gvr, namespace: namespace}
+}
+
+// 资源路径，如 /apis/example.com/v1/namespaces/default/crontabs/test/status
+func (r *dynamicResource) path(name string, subresources ...string) string {
+	mapping := rest.RESTMapping{
+		Group:      r.gvr.Group,
+		Version:    r.gvr.Version,
+		Resource:   r.gvr.Resource,
+		Namespaced: r.namespace != "",
+	}
+	path := mapping.ResourcePath(r.namespace, name)
+	if len(subresources) > 0 {
+		path += "/" + strings.Join(subresources, "/")
+	}
+	return path
+}
+
+func (r *dynamicResource) request(ctx context.Context, path string) rest.IHttpClient {
+	req := r.client.rest.NewRequest(path)
+	req.SetContext(ctx)
+	return req
+}
+
+func (r *dynamicResource) Create(ctx context.Context, obj *resource.Unstructured, subresources ...string) (*resource.Unstructured, error) {
+	name := ""
+	if len(subresources) > 0 {
+		if name = obj.GetName(); name == "" {
+			return nil, errors.New("name is required")
+		}
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.request(ctx, r.path(name, subresources...)).Post(body, jsonHeaders)
+	return decodeUnstructured(resp, err)
+}
+
+func (r *dynamicResource) Update(ctx context.Context, obj *resource.Unstructured, subresources ...string) (*resource.Unstructured, error) {
+	name := obj.GetName()
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.request(ctx, r.path(name, subresources...)).Put(body, jsonHeaders)
+	return decodeUnstructured(resp, err)
+}
+
+func (r *dynamicResource) UpdateStatus(ctx context.Context, obj *resource.Unstructured) (*resource.Unstructured, error) {
+	return r.Update(ctx, obj, "status")
+}
+
+// opts为nil时使用服务端的默认值
+func (r *dynamicResource) Delete(ctx context.Context, name string, opts *resource.DeleteOptions, subresources ...string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	body, err := resource.EncodeDeleteOptions(opts)
+	if err != nil {
+		return err
+	}
+	_, err = readBody(r.request(ctx, r.path(name, subresources...)).Delete(body, jsonHeaders))
+	return err
+}
+
+// 删除匹配listOpts中标签和字段选择器的全部对象
+func (r *dynamicResource) DeleteCollection(ctx context.Context, opts *resource.DeleteOptions, listOpts ListOptions) error {
+	body, err := resource.EncodeDeleteOptions(opts)
+	if err != nil {
+		return err
+	}
+	req := r.request(ctx, r.path(""))
+	setListOptions(req, listOpts)
+	_, err = readBody(req.Delete(body, jsonHeaders))
+	return err
+}
+
+func (r *dynamicResource) Get(ctx context.Context, name string, subresources ...string) (*resource.Unstructured, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	resp, err := r.request(ctx, r.path(name, subresources...)).Get()
+	return decodeUnstructured(resp, err)
+}
+
+func (r *dynamicResource) List(ctx context.Context, opts ListOptions) (*resource.UnstructuredList, error) {
+	req := r.request(ctx, r.path(""))
+	setListOptions(req, opts)
+	data, err := readBody(req.Get())
+	if err != nil {
+		return nil, err
+	}
+	list := &resource.UnstructuredList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// 监听资源的变化，事件中的对象为*resource.Unstructured
+// 请求使用RESTClient的http.Client，若设置了Timeout会导致watch提前结束
+func (r *dynamicResource) Watch(ctx context.Context, opts ListOptions) (watch.Interface, error) {
+	req := r.request(ctx, r.path(""))
+	setListOptions(req, opts)
+	req.SetQuery("watch", "true")
+	resp, err := req.Get()
+	if err != nil {
+		return nil, err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	return watch.NewStreamWatcher(resp.Body, func(_ watch.EventType, raw json.RawMessage) (interface{}, error) {
+		obj := &resource.Unstructured{}
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}), nil
+}
+
+func (r *dynamicResource) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte, subresources ...string) (*resource.Unstructured, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	resp, err := r.request(ctx, r.path(name, subresources...)).Patch(pt, data, nil)
+	return decodeUnstructured(resp, err)
+}
+
+var jsonHeaders = map[string]string{"Content-Type": "application/json"}
+
+func setListOptions(req rest.IHttpClient, opts ListOptions) {
+	req.SetLabelSelector(opts.LabelSelector)
+	req.SetFieldSelector(opts.FieldSelector)
+	if opts.Limit > 0 {
+		req.SetQuery("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	req.SetQuery("continue", opts.Continue)
+	req.SetQuery("resourceVersion", opts.ResourceVersion)
+	if opts.TimeoutSeconds > 0 {
+		req.SetQuery("timeoutSeconds", strconv.FormatInt(opts.TimeoutSeconds, 10))
+	}
+}
+
+func readBody(resp *http.Response, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := rest.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+func decodeUnstructured(resp *http.Response, err error) (*resource.Unstructured, error) {
+	data, err := readBody(resp, err)
+	if err != nil {
+		return nil, err
+	}
+	obj := &resource.Unstructured{}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,133 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+var crontabs = resource.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "crontabs"}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *DynamicClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestDynamicClient_CRUD(t *testing.T) {
+	const base = "/apis/stable.example.com/v1/namespaces/default/crontabs"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + base, "PUT " + base + "/job/status":
+			w.Write(body)
+		case "GET " + base + "/job":
+			w.Write([]byte(`{"apiVersion":"stable.example.com/v1","kind":"CronTab","metadata":{"name":"job","namespace":"default","resourceVersion":"3"},"spec":{"cronSpec":"* * * * */5"}}`))
+		case "GET " + base:
+			if r.URL.Query().Get("labelSelector") != "app=cron" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"apiVersion":"stable.example.com/v1","kind":"CronTabList","metadata":{"resourceVersion":"9","continue":"next"},"items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]}`))
+		case "PATCH " + base + "/job":
+			if r.Header.Get("Content-Type") != string(rest.MergePatchType) {
+				t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+			}
+			w.Write([]byte(`{"metadata":{"name":"job"},"spec":{"replicas":2}}`))
+		case "DELETE " + base + "/job":
+			w.Write([]byte(`{"kind":"Status","status":"Success"}`))
+		case "DELETE " + base + "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+	crontab := c.Resource(crontabs).Namespace("default")
+
+	obj := resource.NewUnstructured("stable.example.com/v1", "CronTab")
+	obj.SetName("job")
+	resource.SetNestedField(obj.Object, "* * * * */5", "spec", "cronSpec")
+	created, err := crontab.Create(ctx, obj)
+	if err != nil || created.GetName() != "job" {
+		t.Fatalf("unexpected create result %v, err %v", created, err)
+	}
+
+	got, err := crontab.Get(ctx, "job")
+	if err != nil || got.GetResourceVersion() != "3" {
+		t.Fatalf("unexpected get result %v, err %v", got, err)
+	}
+	if spec, _, _ := resource.NestedString(got.Object, "spec", "cronSpec"); spec != "* * * * */5" {
+		t.Fatalf("unexpected spec %s", spec)
+	}
+
+	resource.SetNestedField(got.Object, "Running", "status", "phase")
+	if updated, err := crontab.UpdateStatus(ctx, got); err != nil || updated.GetResourceVersion() != "3" {
+		t.Fatalf("unexpected update result %v, err %v", updated, err)
+	}
+
+	list, err := crontab.List(ctx, ListOptions{LabelSelector: "app=cron"})
+	if err != nil || len(list.Items) != 2 || list.Items[1].GetName() != "b" || list.GetContinue() != "next" {
+		t.Fatalf("unexpected list %+v, err %v", list, err)
+	}
+
+	patched, err := crontab.Patch(ctx, "job", rest.MergePatchType, []byte(`{"spec":{"replicas":2}}`))
+	if n, _, _ := resource.NestedInt64(patched.Object, "spec", "replicas"); err != nil || n != 2 {
+		t.Fatalf("unexpected patch result %v, err %v", patched, err)
+	}
+
+	if err := crontab.Delete(ctx, "job", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := crontab.Delete(ctx, "missing", nil); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestDynamicClient_Watch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/stable.example.com/v1/crontabs" || r.URL.Query().Get("watch") != "true" || r.URL.Query().Get("resourceVersion") != "9" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		for i, eventType := range []string{"ADDED", "MODIFIED", "DELETED"} {
+			fmt.Fprintf(w, `{"type":%q,"object":{"kind":"CronTab","metadata":{"name":"job","resourceVersion":"%d"}}}`+"\n", eventType, 10+i)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprintln(w, `{"type":"ERROR","object":{"kind":"Status","status":"Failure","reason":"Expired","code":410,"message":"too old resource version"}}`)
+	})
+
+	watcher, err := c.Resource(crontabs).Watch(context.Background(), ListOptions{ResourceVersion: "9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+	var got []string
+	for event := range watcher.ResultChan() {
+		switch obj := event.Object.(type) {
+		case *resource.Unstructured:
+			got = append(got, string(event.Type)+":"+obj.GetResourceVersion())
+		case *resource.Status:
+			got = append(got, string(event.Type)+":"+obj.Reason)
+		}
+	}
+	data, _ := json.Marshal(got)
+	if want := `["ADDED:10","MODIFIED:11","DELETED:12","ERROR:Expired"]`; string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	// 停止后通道关闭
+	stopped, _ := c.Resource(crontabs).Watch(context.Background(), ListOptions{ResourceVersion: "9"})
+	stopped.Stop()
+	for range stopped.ResultChan() {
+	}
+}
@@ -0,0 +1,1001 @@
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/fields"
+	"k8s-client-go/labels"
+	"k8s-client-go/patch"
+	"k8s-client-go/resource"
+	autoscalingv1 "k8s-client-go/resource/autoscaling/v1"
+	policyv1 "k8s-client-go/resource/policy/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 进程内的apiserver，对象保存在内存中，用于离线测试
+// 支持内置资源及注册的自定义资源的增删改查、按标签和字段过滤、分页、watch和发现接口
+type Server struct {
+	URL string
+
+	server *httptest.Server
+	store  *store
+
+	lock     sync.Mutex
+	mappings []rest.RESTMapping
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// 启动服务并创建default、kube-system和kube-public命名空间
+func NewServer() *Server {
+	s := &Server{
+		store:    newStore(),
+		mappings: rest.DefaultRESTMapper.Mappings(),
+		stopCh:   make(chan struct{}),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	for _, ns := range []string{"default", "kube-system", "kube-public"} {
+		namespace := resource.NewUnstructured("v1", resource.RESOURCE_NAMESPACE)
+		namespace.SetName(ns)
+		if _, err := s.Add(namespace); err != nil {
+			panic(err)
+		}
+	}
+	return s
+}
+
+// 关闭服务，进行中的watch会被断开
+func (s *Server) Close() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	s.server.Close()
+}
+
+// 连接该服务的配置
+func (s *Server) Config() *rest.Config {
+	return &rest.Config{Host: s.URL}
+}
+
+// 包含注册的自定义资源的映射
+func (s *Server) RESTMapper() *rest.StaticRESTMapper {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return rest.NewStaticRESTMapper(s.mappings...)
+}
+
+// 注册资源，如自定义资源；创建CustomResourceDefinition时会自动注册
+func (s *Server) AddMapping(mapping rest.RESTMapping) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, m := range s.mappings {
+		if m.Group == mapping.Group && m.Version == mapping.Version && m.Resource == mapping.Resource {
+			return
+		}
+	}
+	s.mappings = append(s.mappings, mapping)
+}
+
+// 直接向存储中写入对象，obj可以是资源结构体、Unstructured或map，返回保存后的对象
+func (s *Server) Add(obj interface{}) (map[string]interface{}, error) {
+	m, err := resource.ToMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &resource.Unstructured{Object: m}
+	mapping, err := s.RESTMapper().RESTMapping(u.GetApiVersion(), u.GetKind())
+	if err != nil {
+		return nil, err
+	}
+	namespace := u.GetNamespace()
+	if mapping.Namespaced && namespace == "" {
+		namespace = "default"
+	}
+	created, status := s.create(mapping, namespace, m)
+	if status != nil {
+		return nil, &rest.StatusError{Status: *status}
+	}
+	return created, nil
+}
+
+// 读取存储中的对象
+func (s *Server) Object(apiVersion, kind, namespace, name string) (map[string]interface{}, bool) {
+	mapping, err := s.RESTMapper().RESTMapping(apiVersion, kind)
+	if err != nil {
+		return nil, false
+	}
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	obj, ok := s.store.get(mapping.Group, mapping.Resource, scopedNamespace(mapping, namespace), name)
+	if !ok {
+		return nil, false
+	}
+	return (&resource.Unstructured{Object: obj}).DeepCopy().Object, true
+}
+
+// 清除变更记录，之后使用旧版本的watch和continue会返回410
+func (s *Server) Compact() {
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	s.store.history = nil
+	s.store.compacted = s.store.rv
+}
+
+// 请求的目标
+type requestInfo struct {
+	mapping     *rest.RESTMapping
+	namespace   string
+	name        string
+	subresource string
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var group, version string
+	var parts []string
+	switch {
+	case len(segments) == 1 && segments[0] == "api":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"kind": "APIVersions", "versions": []string{"v1"}})
+		return
+	case len(segments) == 1 && segments[0] == "apis":
+		writeJSON(w, http.StatusOK, s.groupList())
+		return
+	case len(segments) >= 2 && segments[0] == "api":
+		version, parts = segments[1], segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		group, version, parts = segments[1], segments[2], segments[3:]
+	default:
+		writeStatus(w, notFound("", ""))
+		return
+	}
+	if len(parts) == 0 {
+		if list := s.resourceList(group, version); list != nil {
+			writeJSON(w, http.StatusOK, list)
+			return
+		}
+		writeStatus(w, notFound("", ""))
+		return
+	}
+
+	info := &requestInfo{}
+	if parts[0] == "namespaces" && len(parts) >= 3 && s.findMapping(group, version, parts[2]) != nil {
+		info.namespace = parts[1]
+		parts = parts[2:]
+	}
+	if info.mapping = s.findMapping(group, version, parts[0]); info.mapping == nil {
+		writeStatus(w, notFound("", ""))
+		return
+	}
+	if len(parts) > 1 {
+		info.name = parts[1]
+	}
+	if len(parts) > 2 {
+		info.subresource = strings.Join(parts[2:], "/")
+	}
+	switch info.subresource {
+	case "", "status":
+	case "scale":
+		s.serveScale(w, r, info)
+		return
+	case "eviction":
+		s.serveEviction(w, r, info)
+		return
+	default:
+		writeStatus(w, notFound("", ""))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && info.name == "" && isWatch(r):
+		s.serveWatch(w, r, info)
+	case r.Method == http.MethodGet && info.name == "":
+		s.serveList(w, r, info)
+	case r.Method == http.MethodGet:
+		s.serveGet(w, info)
+	case r.Method == http.MethodPost && info.name == "":
+		s.serveCreate(w, r, info)
+	case r.Method == http.MethodPut && info.name != "":
+		s.serveUpdate(w, r, info)
+	case r.Method == http.MethodPatch && info.name != "":
+		s.servePatch(w, r, info)
+	case r.Method == http.MethodDelete && info.name != "":
+		s.serveDelete(w, r, info)
+	case r.Method == http.MethodDelete && info.subresource == "":
+		s.serveDeleteCollection(w, r, info)
+	default:
+		writeStatus(w, newStatus(http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported on %s", r.Method, r.URL.Path)))
+	}
+}
+
+func (s *Server) findMapping(group, version, res string) *rest.RESTMapping {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := range s.mappings {
+		m := s.mappings[i]
+		if m.Group == group && m.Version == version && m.Resource == res {
+			return &m
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveGet(w http.ResponseWriter, info *requestInfo) {
+	s.store.lock.Lock()
+	obj, ok := s.store.get(info.mapping.Group, info.mapping.Resource, scopedNamespace(info.mapping, info.namespace), info.name)
+	s.store.lock.Unlock()
+	if !ok {
+		writeStatus(w, notFound(info.mapping.Resource, info.name))
+		return
+	}
+	writeJSON(w, http.StatusOK, withVersion(info.mapping, obj))
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	label, field, status := parseSelectors(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	offset := 0
+	if token := query.Get("continue"); token != "" {
+		rv, start, err := decodeContinue(token)
+		if err != nil {
+			writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", "invalid continue token"))
+			return
+		}
+		if rv <= s.store.compacted {
+			writeStatus(w, newStatus(http.StatusGone, rest.StatusReasonExpired, "The provided continue parameter is too old to display a consistent list result. You can start a new list without the continue parameter."))
+			return
+		}
+		offset = start
+	}
+
+	var items []interface{}
+	for _, obj := range s.store.list(info.mapping.Group, info.mapping.Resource, info.namespace) {
+		if matchesSelectors(obj, label, field) {
+			items = append(items, withVersion(info.mapping, obj))
+		}
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	metadata := map[string]interface{}{"resourceVersion": strconv.FormatInt(s.store.rv, 10)}
+	if limit > 0 && len(items) > limit {
+		metadata["continue"] = encodeContinue(s.store.rv, offset+limit)
+		metadata["remainingItemCount"] = len(items) - limit
+		items = items[:limit]
+	}
+	if items == nil {
+		items = []interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"apiVersion": info.mapping.GroupVersion(),
+		"kind":       info.mapping.Kind + "List",
+		"metadata":   metadata,
+		"items":      items,
+	})
+}
+
+func (s *Server) serveCreate(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	obj, status := readObject(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	u := &resource.Unstructured{Object: obj}
+	if info.mapping.Namespaced && u.GetNamespace() != "" && info.namespace != "" && u.GetNamespace() != info.namespace {
+		writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", "the namespace of the provided object does not match the namespace sent on the request"))
+		return
+	}
+	namespace := info.namespace
+	if namespace == "" {
+		namespace = u.GetNamespace()
+	}
+	if info.mapping.Namespaced && namespace == "" {
+		writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", "the namespace of the object is required"))
+		return
+	}
+	created, status := s.create(info.mapping, namespace, obj)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusCreated, withVersion(info.mapping, created))
+}
+
+// 创建对象，补充名称、uid、创建时间和版本等字段
+func (s *Server) create(mapping *rest.RESTMapping, namespace string, obj map[string]interface{}) (map[string]interface{}, *resource.Status) {
+	u := &resource.Unstructured{Object: obj}
+	if u.GetName() == "" {
+		generateName, _, _ := resource.NestedString(obj, "metadata", "generateName")
+		if generateName == "" {
+			return nil, newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, "metadata.name: Required value: name or generateName is required")
+		}
+		u.SetName(generateName + randomString(5))
+	}
+	u.SetApiVersion(mapping.GroupVersion())
+	u.SetKind(mapping.Kind)
+	if mapping.Namespaced {
+		u.SetNamespace(namespace)
+	} else {
+		resource.RemoveNestedField(obj, "metadata", "namespace")
+	}
+
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	ns := scopedNamespace(mapping, namespace)
+	if _, exists := s.store.get(mapping.Group, mapping.Resource, ns, u.GetName()); exists {
+		return nil, alreadyExists(mapping, u.GetName())
+	}
+	rv := s.store.nextVersion()
+	u.SetResourceVersion(strconv.FormatInt(rv, 10))
+	resource.SetNestedField(obj, newUid(), "metadata", "uid")
+	resource.SetNestedField(obj, time.Now().UTC().Format(time.RFC3339), "metadata", "creationTimestamp")
+	resource.SetNestedField(obj, int64(1), "metadata", "generation")
+	s.store.commit(watch.Added, mapping.Group, mapping.Resource, ns, u.GetName(), obj, rv)
+
+	if mapping.Group == "apiextensions.k8s.io" && mapping.Kind == resource.RESOURCE_CUSTOM_RESOURCE_DEFINITION {
+		for _, m := range crdMappings(obj) {
+			s.AddMapping(m)
+		}
+	}
+	return (&resource.Unstructured{Object: obj}).DeepCopy().Object, nil
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	obj, status := readObject(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	updated, status := s.update(info, obj)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, withVersion(info.mapping, updated))
+}
+
+func (s *Server) servePatch(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", err.Error()))
+		return
+	}
+	pt := rest.PatchType(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+
+	s.store.lock.Lock()
+	ns := scopedNamespace(info.mapping, info.namespace)
+	current, exists := s.store.get(info.mapping.Group, info.mapping.Resource, ns, info.name)
+	if !exists && pt != rest.ApplyPatchType {
+		s.store.lock.Unlock()
+		writeStatus(w, notFound(info.mapping.Resource, info.name))
+		return
+	}
+	if pt == rest.ApplyPatchType {
+		// 服务端apply简化为merge patch，不跟踪字段所有权
+		applied, err := resource.DecodeMap(body)
+		if err == nil {
+			body, err = json.Marshal(applied)
+		}
+		if err != nil {
+			s.store.lock.Unlock()
+			writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", err.Error()))
+			return
+		}
+		if !exists {
+			s.store.lock.Unlock()
+			created, status := s.create(info.mapping, info.namespace, applied)
+			if status != nil {
+				writeStatus(w, status)
+				return
+			}
+			writeJSON(w, http.StatusCreated, withVersion(info.mapping, created))
+			return
+		}
+		pt = rest.MergePatchType
+	}
+	defer s.store.lock.Unlock()
+
+	doc, _ := json.Marshal(current)
+	patched, err := patch.Apply(pt, doc, body)
+	if err == patch.ErrUnsupportedPatchType {
+		writeStatus(w, newStatus(http.StatusUnsupportedMediaType, "UnsupportedMediaType", fmt.Sprintf("the body of the request was in an unknown format - accepted media types include: %s, %s, %s, %s", rest.JSONPatchType, rest.MergePatchType, rest.StrategicMergePatchType, rest.ApplyPatchType)))
+		return
+	}
+	if err != nil {
+		writeStatus(w, newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, err.Error()))
+		return
+	}
+	obj, err := resource.DecodeMap(patched)
+	if err != nil {
+		writeStatus(w, newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, err.Error()))
+		return
+	}
+	updated, status := s.update(info, obj)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, withVersion(info.mapping, updated))
+}
+
+// 替换对象，需在持有store.lock时调用
+// obj中带有resourceVersion时必须与当前版本一致；更新status子资源时只替换status，否则保留原有的status
+func (s *Server) update(info *requestInfo, obj map[string]interface{}) (map[string]interface{}, *resource.Status) {
+	mapping := info.mapping
+	ns := scopedNamespace(mapping, info.namespace)
+	current, exists := s.store.get(mapping.Group, mapping.Resource, ns, info.name)
+	if !exists {
+		return nil, notFound(mapping.Resource, info.name)
+	}
+	u := &resource.Unstructured{Object: obj}
+	if u.GetName() != "" && u.GetName() != info.name {
+		return nil, newStatus(http.StatusBadRequest, "BadRequest", "the name of the object does not match the name on the URL")
+	}
+	cur := &resource.Unstructured{Object: current}
+	if rv := u.GetResourceVersion(); rv != "" && rv != cur.GetResourceVersion() {
+		return nil, conflict(mapping, info.name)
+	}
+
+	next := (&resource.Unstructured{Object: obj}).DeepCopy()
+	if info.subresource == "status" {
+		next = cur.DeepCopy()
+		if status, ok := obj["status"]; ok {
+			next.Object["status"] = status
+		} else {
+			delete(next.Object, "status")
+		}
+	} else if status, ok := current["status"]; ok {
+		next.Object["status"] = status
+	} else {
+		delete(next.Object, "status")
+	}
+	next.SetName(info.name)
+	next.SetApiVersion(mapping.GroupVersion())
+	next.SetKind(mapping.Kind)
+	if mapping.Namespaced {
+		next.SetNamespace(ns)
+	}
+	// 以下字段由服务端维护
+	for _, field := range []string{"uid", "creationTimestamp", "generation"} {
+		if v, ok := current["metadata"].(map[string]interface{})[field]; ok {
+			resource.SetNestedField(next.Object, v, "metadata", field)
+		}
+	}
+	next.SetResourceVersion(cur.GetResourceVersion())
+	if reflect.DeepEqual(normalize(next.Object), normalize(current)) {
+		return cur.DeepCopy().Object, nil
+	}
+
+	// metadata和status以外的字段变化时generation加1
+	if !reflect.DeepEqual(normalize(specOf(next.Object)), normalize(specOf(current))) {
+		generation, _, _ := resource.NestedInt64(current, "metadata", "generation")
+		resource.SetNestedField(next.Object, generation+1, "metadata", "generation")
+	}
+	rv := s.store.nextVersion()
+	next.SetResourceVersion(strconv.FormatInt(rv, 10))
+	s.store.commit(watch.Modified, mapping.Group, mapping.Resource, ns, info.name, next.Object, rv)
+	return next.DeepCopy().Object, nil
+}
+
+// scale子资源读写对象的spec.replicas，没有控制器，status.replicas需由测试更新
+func (s *Server) serveScale(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	var obj map[string]interface{}
+	if r.Method == http.MethodPut {
+		var status *resource.Status
+		if obj, status = readObject(r); status != nil {
+			writeStatus(w, status)
+			return
+		}
+	} else if r.Method != http.MethodGet {
+		writeStatus(w, newStatus(http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported on %s", r.Method, r.URL.Path)))
+		return
+	}
+
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	current, ok := s.store.get(info.mapping.Group, info.mapping.Resource, scopedNamespace(info.mapping, info.namespace), info.name)
+	if !ok {
+		writeStatus(w, notFound(info.mapping.Resource, info.name))
+		return
+	}
+	if obj == nil {
+		writeJSON(w, http.StatusOK, scaleObject(current))
+		return
+	}
+	next := (&resource.Unstructured{Object: current}).DeepCopy()
+	replicas, _, _ := resource.NestedInt64(obj, "spec", "replicas")
+	resource.SetNestedField(next.Object, replicas, "spec", "replicas")
+	rv, _, _ := resource.NestedString(obj, "metadata", "resourceVersion")
+	next.SetResourceVersion(rv)
+	updated, status := s.update(&requestInfo{mapping: info.mapping, namespace: info.namespace, name: info.name}, next.Object)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, scaleObject(updated))
+}
+
+// 转换为map，使键名与yaml标签一致
+func scaleObject(obj map[string]interface{}) map[string]interface{} {
+	m, _ := resource.ToMap(autoscalingv1.ScaleFromObject(obj))
+	return m
+}
+
+// 检查preconditions，dryRun时不删除；propagationPolicy只做校验，不会级联删除依赖对象
+func (s *Server) serveDelete(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	opts, status := readDeleteOptions(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	s.store.lock.Lock()
+	deleted, status := s.delete(info.mapping, scopedNamespace(info.mapping, info.namespace), info.name, opts)
+	s.store.lock.Unlock()
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, withVersion(info.mapping, deleted))
+}
+
+// 删除匹配选择器的对象，返回被删除对象的列表
+func (s *Server) serveDeleteCollection(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	label, field, status := parseSelectors(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	opts, status := readDeleteOptions(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	s.store.lock.Lock()
+	defer s.store.lock.Unlock()
+	items := []interface{}{}
+	for _, obj := range s.store.list(info.mapping.Group, info.mapping.Resource, info.namespace) {
+		if !matchesSelectors(obj, label, field) {
+			continue
+		}
+		u := &resource.Unstructured{Object: obj}
+		deleted, status := s.delete(info.mapping, u.GetNamespace(), u.GetName(), opts)
+		if status != nil {
+			writeStatus(w, status)
+			return
+		}
+		items = append(items, withVersion(info.mapping, deleted))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"apiVersion": info.mapping.GroupVersion(),
+		"kind":       info.mapping.Kind + "List",
+		"metadata":   map[string]interface{}{"resourceVersion": strconv.FormatInt(s.store.rv, 10)},
+		"items":      items,
+	})
+}
+
+// 驱逐时直接删除pod，不检查PodDisruptionBudget
+func (s *Server) serveEviction(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	if r.Method != http.MethodPost || info.mapping.Resource != "pods" {
+		writeStatus(w, newStatus(http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported on %s", r.Method, r.URL.Path)))
+		return
+	}
+	obj, status := readObject(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	eviction := policyv1.Eviction{}
+	if err := resource.FromMap(obj, &eviction); err != nil {
+		writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", err.Error()))
+		return
+	}
+	if status := validateDeleteOptions(eviction.DeleteOptions); status != nil {
+		writeStatus(w, status)
+		return
+	}
+	s.store.lock.Lock()
+	_, status = s.delete(info.mapping, scopedNamespace(info.mapping, info.namespace), info.name, eviction.DeleteOptions)
+	s.store.lock.Unlock()
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"apiVersion": "v1", "kind": "Status", "status": "Success", "code": http.StatusCreated})
+}
+
+// 调用方需持有store的锁，dryRun时返回当前对象
+func (s *Server) delete(mapping *rest.RESTMapping, namespace, name string, opts *resource.DeleteOptions) (map[string]interface{}, *resource.Status) {
+	current, exists := s.store.get(mapping.Group, mapping.Resource, namespace, name)
+	if !exists {
+		return nil, notFound(mapping.Resource, name)
+	}
+	if opts != nil {
+		if err := opts.Preconditions.Check(current); err != nil {
+			return nil, newStatus(http.StatusConflict, rest.StatusReasonConflict, fmt.Sprintf("Operation cannot be fulfilled on %s %q: %v", qualifiedResource(mapping), name, err))
+		}
+	}
+	deleted := (&resource.Unstructured{Object: current}).DeepCopy()
+	if opts.IsDryRun() {
+		return deleted.Object, nil
+	}
+	rv := s.store.nextVersion()
+	deleted.SetResourceVersion(strconv.FormatInt(rv, 10))
+	s.store.commit(watch.Deleted, mapping.Group, mapping.Resource, namespace, name, deleted.Object, rv)
+	return deleted.Object, nil
+}
+
+// 请求体为空时返回nil
+func readDeleteOptions(r *http.Request) (*resource.DeleteOptions, *resource.Status) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, newStatus(http.StatusBadRequest, "BadRequest", err.Error())
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+	opts := &resource.DeleteOptions{}
+	if err := yaml.Unmarshal(body, opts); err != nil {
+		return nil, newStatus(http.StatusBadRequest, "BadRequest", err.Error())
+	}
+	return opts, validateDeleteOptions(opts)
+}
+
+func validateDeleteOptions(opts *resource.DeleteOptions) *resource.Status {
+	if opts == nil {
+		return nil
+	}
+	switch opts.PropagationPolicy {
+	case "", resource.DeletePropagationForeground, resource.DeletePropagationBackground, resource.DeletePropagationOrphan:
+	default:
+		return newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, fmt.Sprintf("propagationPolicy: Unsupported value: %q", opts.PropagationPolicy))
+	}
+	for _, v := range opts.DryRun {
+		if v != resource.DryRunAll {
+			return newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, fmt.Sprintf("dryRun: Unsupported value: %q", v))
+		}
+	}
+	if opts.GracePeriodSeconds != nil && *opts.GracePeriodSeconds < 0 {
+		return newStatus(http.StatusUnprocessableEntity, rest.StatusReasonInvalid, "gracePeriodSeconds: Invalid value: must be greater than or equal to 0")
+	}
+	return nil
+}
+
+func (s *Server) serveWatch(w http.ResponseWriter, r *http.Request, info *requestInfo) {
+	label, field, status := parseSelectors(r)
+	if status != nil {
+		writeStatus(w, status)
+		return
+	}
+	query := r.URL.Query()
+	watcher := &watcher{
+		group:     info.mapping.Group,
+		resource:  info.mapping.Resource,
+		namespace: info.namespace,
+		label:     label,
+		field:     field,
+		result:    make(chan event, watchBufferSize),
+	}
+
+	// 注册watch与读取初始事件在同一把锁内完成，保证不遗漏事件
+	s.store.lock.Lock()
+	var initial []event
+	expired := false
+	switch rv := query.Get("resourceVersion"); rv {
+	case "", "0":
+		for _, obj := range s.store.list(watcher.group, watcher.resource, watcher.namespace) {
+			if matchesSelectors(obj, label, field) {
+				initial = append(initial, event{Type: watch.Added, object: (&resource.Unstructured{Object: obj}).DeepCopy().Object})
+			}
+		}
+	default:
+		version, err := strconv.ParseInt(rv, 10, 64)
+		if err != nil {
+			s.store.lock.Unlock()
+			writeStatus(w, newStatus(http.StatusBadRequest, "BadRequest", "invalid resourceVersion "+rv))
+			return
+		}
+		if version < s.store.compacted {
+			expired = true
+		} else {
+			initial = s.store.eventsSince(watcher, version)
+		}
+	}
+	if !expired {
+		s.store.addWatcher(watcher)
+	}
+	s.store.lock.Unlock()
+	defer func() {
+		s.store.lock.Lock()
+		s.store.removeWatcher(watcher)
+		s.store.lock.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	send := func(eventType watch.EventType, obj interface{}) bool {
+		if err := encoder.Encode(map[string]interface{}{"type": eventType, "object": obj}); err != nil {
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+	if expired {
+		send(watch.Error, statusObject(newStatus(http.StatusGone, rest.StatusReasonExpired, "too old resource version: "+query.Get("resourceVersion"))))
+		return
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	for _, e := range initial {
+		if !send(e.Type, withVersion(info.mapping, e.object)) {
+			return
+		}
+	}
+
+	var timeout <-chan time.Time
+	if seconds, _ := strconv.Atoi(query.Get("timeoutSeconds")); seconds > 0 {
+		timer := time.NewTimer(time.Duration(seconds) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		select {
+		case e, ok := <-watcher.result:
+			if !ok || !send(e.Type, withVersion(info.mapping, e.object)) {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// 发现接口的API组列表
+func (s *Server) groupList() map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var names []string
+	versions := map[string][]interface{}{}
+	seen := map[string]bool{}
+	for _, m := range s.mappings {
+		if m.Group == "" || seen[m.GroupVersion()] {
+			continue
+		}
+		seen[m.GroupVersion()] = true
+		if _, ok := versions[m.Group]; !ok {
+			names = append(names, m.Group)
+		}
+		versions[m.Group] = append(versions[m.Group], map[string]interface{}{"groupVersion": m.GroupVersion(), "version": m.Version})
+	}
+	groups := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, map[string]interface{}{
+			"name":             name,
+			"versions":         versions[name],
+			"preferredVersion": versions[name][0],
+		})
+	}
+	return map[string]interface{}{"kind": "APIGroupList", "apiVersion": "v1", "groups": groups}
+}
+
+// 发现接口中某个组版本的资源列表，组版本不存在时返回nil
+func (s *Server) resourceList(group, version string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var resources []interface{}
+	groupVersion := ""
+	for _, m := range s.mappings {
+		if m.Group != group || m.Version != version {
+			continue
+		}
+		groupVersion = m.GroupVersion()
+		resources = append(resources, map[string]interface{}{
+			"name":         m.Resource,
+			"singularName": "",
+			"namespaced":   m.Namespaced,
+			"kind":         m.Kind,
+			"verbs":        []string{"create", "delete", "get", "list", "patch", "update", "watch"},
+		})
+	}
+	if groupVersion == "" {
+		return nil
+	}
+	return map[string]interface{}{"kind": "APIResourceList", "apiVersion": "v1", "groupVersion": groupVersion, "resources": resources}
+}
+
+// CustomResourceDefinition中声明的资源
+func crdMappings(obj map[string]interface{}) []rest.RESTMapping {
+	group, _, _ := resource.NestedString(obj, "spec", "group")
+	plural, _, _ := resource.NestedString(obj, "spec", "names", "plural")
+	kind, _, _ := resource.NestedString(obj, "spec", "names", "kind")
+	scope, _, _ := resource.NestedString(obj, "spec", "scope")
+	var versions []string
+	for _, field := range []string{"versions", "version"} {
+		switch v := obj["spec"].(map[string]interface{})[field].(type) {
+		case string:
+			versions = append(versions, v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					if name, ok := m["name"].(string); ok {
+						versions = append(versions, name)
+					}
+				}
+			}
+		}
+	}
+	var mappings []rest.RESTMapping
+	for _, version := range versions {
+		mappings = append(mappings, rest.RESTMapping{Group: group, Version: version, Resource: plural, Kind: kind, Namespaced: scope != "Cluster"})
+	}
+	return mappings
+}
+
+func isWatch(r *http.Request) bool {
+	v := r.URL.Query().Get("watch")
+	return v == "true" || v == "1"
+}
+
+func parseSelectors(r *http.Request) (labels.Selector, fields.Selector, *resource.Status) {
+	query := r.URL.Query()
+	label, err := labels.Parse(query.Get(labels.QueryParam))
+	if err != nil {
+		return nil, nil, newStatus(http.StatusBadRequest, "BadRequest", "unable to parse requirement: "+err.Error())
+	}
+	field, err := fields.ParseSelector(query.Get(fields.QueryParam))
+	if err != nil {
+		return nil, nil, newStatus(http.StatusBadRequest, "BadRequest", err.Error())
+	}
+	return label, field, nil
+}
+
+func readObject(r *http.Request) (map[string]interface{}, *resource.Status) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, newStatus(http.StatusBadRequest, "BadRequest", err.Error())
+	}
+	obj, err := resource.DecodeMap(body)
+	if err != nil {
+		return nil, newStatus(http.StatusBadRequest, "BadRequest", err.Error())
+	}
+	return obj, nil
+}
+
+// 命名空间级资源使用请求中的命名空间，集群级资源忽略命名空间
+func scopedNamespace(mapping *rest.RESTMapping, namespace string) string {
+	if !mapping.Namespaced {
+		return ""
+	}
+	return namespace
+}
+
+// 按请求的版本返回对象的副本
+func withVersion(mapping *rest.RESTMapping, obj map[string]interface{}) map[string]interface{} {
+	u := (&resource.Unstructured{Object: obj}).DeepCopy()
+	u.SetApiVersion(mapping.GroupVersion())
+	u.SetKind(mapping.Kind)
+	return u.Object
+}
+
+func specOf(obj map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != "metadata" && k != "status" {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// 统一数值类型后比较
+func normalize(obj map[string]interface{}) interface{} {
+	data, _ := json.Marshal(obj)
+	var v interface{}
+	json.Unmarshal(data, &v)
+	return v
+}
+
+func encodeContinue(rv int64, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%d", rv, offset)))
+}
+
+func decodeContinue(token string) (int64, int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, err
+	}
+	var rv int64
+	var offset int
+	if _, err := fmt.Sscanf(string(data), "%d/%d", &rv, &offset); err != nil {
+		return 0, 0, err
+	}
+	return rv, offset, nil
+}
+
+func newStatus(code int, reason, message string) *resource.Status {
+	return &resource.Status{ApiVersion: "v1", Kind: "Status", Status: "Failure", Code: code, Reason: reason, Message: message}
+}
+
+func notFound(res, name string) *resource.Status {
+	if res == "" {
+		return newStatus(http.StatusNotFound, rest.StatusReasonNotFound, "the server could not find the requested resource")
+	}
+	return newStatus(http.StatusNotFound, rest.StatusReasonNotFound, fmt.Sprintf("%s %q not found", res, name))
+}
+
+func alreadyExists(mapping *rest.RESTMapping, name string) *resource.Status {
+	return newStatus(http.StatusConflict, rest.StatusReasonAlreadyExists, fmt.Sprintf("%s %q already exists", qualifiedResource(mapping), name))
+}
+
+func conflict(mapping *rest.RESTMapping, name string) *resource.Status {
+	return newStatus(http.StatusConflict, rest.StatusReasonConflict, fmt.Sprintf("Operation cannot be fulfilled on %s %q: the object has been modified; please apply your changes to the latest version and try again", qualifiedResource(mapping), name))
+}
+
+func qualifiedResource(mapping *rest.RESTMapping) string {
+	if mapping.Group == "" {
+		return mapping.Resource
+	}
+	return mapping.Resource + "." + mapping.Group
+}
+
+func writeStatus(w http.ResponseWriter, status *resource.Status) {
+	writeJSON(w, status.Code, statusObject(status))
+}
+
+// Status结构体没有json标签，按yaml标签转换后再输出
+func statusObject(status *resource.Status) map[string]interface{} {
+	m, _ := resource.ToManifest(status)
+	return m
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+const randomChars = "bcdfghjklmnpqrstvwxz2456789"
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randomChars[rand.Intn(len(randomChars))]
+	}
+	return string(b)
+}
+
+func newUid() string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", rand.Uint32(), rand.Intn(1<<16), rand.Intn(1<<16), rand.Intn(1<<16), rand.Int63n(1<<48))
+}
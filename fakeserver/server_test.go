@@ -0,0 +1,345 @@
+package fakeserver_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-client-go/client"
+	"k8s-client-go/dynamic"
+	"k8s-client-go/fakeserver"
+	"k8s-client-go/resource"
+	appsv1 "k8s-client-go/resource/apps/v1"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+func newPod(name string, labels map[string]string) *resource.Unstructured {
+	pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+	pod.SetName(name)
+	pod.SetLabels(labels)
+	resource.SetNestedField(pod.Object, "node-1", "spec", "nodeName")
+	return pod
+}
+
+func TestServer_CRUD(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	d, err := dynamic.NewForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	pods := d.Resource(resource.GroupVersionResource{Version: "v1", Resource: "pods"})
+
+	created, err := pods.Namespace("default").Create(ctx, newPod("web", map[string]string{"app": "web"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.GetNamespace() != "default" || created.GetResourceVersion() == "" || created.GetUid() == "" {
+		t.Fatalf("unexpected created object %v", created.Object)
+	}
+	if _, err := pods.Namespace("default").Create(ctx, newPod("web", nil)); !rest.IsAlreadyExists(err) {
+		t.Fatalf("expected already exists, got %v", err)
+	}
+	pods.Namespace("kube-system").Create(ctx, newPod("dns", map[string]string{"app": "dns"}))
+	pods.Namespace("default").Create(ctx, newPod("db", map[string]string{"app": "db"}))
+
+	// 标签过滤与命名空间
+	list, err := pods.List(ctx, dynamic.ListOptions{LabelSelector: "app in (web,dns)"})
+	if err != nil || len(list.Items) != 2 {
+		t.Fatalf("unexpected list %+v, err %v", list, err)
+	}
+	list, _ = pods.Namespace("default").List(ctx, dynamic.ListOptions{FieldSelector: "metadata.name!=web,spec.nodeName=node-1"})
+	if len(list.Items) != 1 || list.Items[0].GetName() != "db" {
+		t.Fatalf("unexpected field selector result %+v", list.Items)
+	}
+
+	// 更新时检查resourceVersion
+	stale := created.DeepCopy()
+	resource.SetNestedField(created.Object, "node-2", "spec", "nodeName")
+	updated, err := pods.Namespace("default").Update(ctx, created)
+	if err != nil || updated.GetResourceVersion() == created.GetResourceVersion() {
+		t.Fatalf("unexpected update %v, err %v", updated, err)
+	}
+	if generation, _, _ := resource.NestedInt64(updated.Object, "metadata", "generation"); generation != 2 {
+		t.Fatalf("expected generation 2, got %d", generation)
+	}
+	if _, err := pods.Namespace("default").Update(ctx, stale); !rest.IsConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+
+	// status子资源只更新status
+	resource.SetNestedField(updated.Object, "Running", "status", "phase")
+	resource.SetNestedField(updated.Object, "ignored", "spec", "nodeName")
+	if _, err := pods.Namespace("default").UpdateStatus(ctx, updated); err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := s.Object("v1", "Pod", "default", "web")
+	if phase, _, _ := resource.NestedString(stored, "status", "phase"); phase != "Running" {
+		t.Fatalf("unexpected status %v", stored["status"])
+	}
+	if node, _, _ := resource.NestedString(stored, "spec", "nodeName"); node != "node-2" {
+		t.Fatalf("status update changed spec: %v", stored["spec"])
+	}
+
+	patched, err := pods.Namespace("default").Patch(ctx, "web", rest.StrategicMergePatchType, []byte(`{"metadata":{"labels":{"tier":"front"}}}`))
+	if err != nil || patched.GetLabels()["tier"] != "front" || patched.GetLabels()["app"] != "web" {
+		t.Fatalf("unexpected patch result %v, err %v", patched, err)
+	}
+
+	if err := pods.Namespace("default").Delete(ctx, "web", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pods.Namespace("default").Get(ctx, "web"); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := d.Resource(resource.GroupVersionResource{Version: "v1", Resource: "widgets"}).List(ctx, dynamic.ListOptions{}); !rest.IsNotFound(err) {
+		t.Fatalf("expected unknown resource to be not found, got %v", err)
+	}
+}
+
+func TestServer_TypedClient(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, err := client.NewClientForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deploy := appsv1.NewResDeployment()
+	deploy.SetMetadataName("web")
+	replicas := int32(1)
+	deploy.Spec.Replicas = &replicas
+	deploy.AddContainer(resource.NewContainer("web", "nginx:1.17"))
+	if _, err := c.ClientSideApply(context.Background(), deploy); err != nil {
+		t.Fatal(err)
+	}
+
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}
+	meta, err := c.UpdateWithRetry(context.Background(), key, appsv1.NewResDeployment(), func(obj resource.IResource) error {
+		replicas := int32(3)
+		obj.(*appsv1.ResDeployment).Spec.Replicas = &replicas
+		return nil
+	})
+	if err != nil || meta.Generation != 2 {
+		t.Fatalf("unexpected update result %+v, err %v", meta, err)
+	}
+
+	pager := c.NewPager("v1", "Namespace", client.ListOptions{})
+	pager.PageSize = 2
+	list, err := pager.List(context.Background())
+	if err != nil || len(list.Items) != 3 {
+		t.Fatalf("unexpected namespaces %v, err %v", list, err)
+	}
+}
+
+func TestServer_Watch(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	d, _ := dynamic.NewForConfig(s.Config())
+	ctx := context.Background()
+	pods := d.Resource(resource.GroupVersionResource{Version: "v1", Resource: "pods"}).Namespace("default")
+
+	first, _ := pods.Create(ctx, newPod("a", map[string]string{"app": "web"}))
+	w, err := pods.Watch(ctx, dynamic.ListOptions{LabelSelector: "app=web", ResourceVersion: first.GetResourceVersion()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	pods.Create(ctx, newPod("b", map[string]string{"app": "web"}))
+	pods.Create(ctx, newPod("c", map[string]string{"app": "db"}))
+	pods.Delete(ctx, "a", nil)
+
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for len(got) < 2 {
+		select {
+		case e := <-w.ResultChan():
+			got = append(got, string(e.Type)+":"+e.Object.(*resource.Unstructured).GetName())
+		case <-timeout:
+			t.Fatalf("timed out, got %v", got)
+		}
+	}
+	if got[0] != "ADDED:b" || got[1] != "DELETED:a" {
+		t.Fatalf("unexpected events %v", got)
+	}
+
+	// 变更记录清除后从旧版本watch返回410
+	s.Compact()
+	expired, err := pods.Watch(ctx, dynamic.ListOptions{ResourceVersion: first.GetResourceVersion()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := <-expired.ResultChan()
+	if status, ok := e.Object.(*resource.Status); e.Type != watch.Error || !ok || status.Code != 410 {
+		t.Fatalf("expected expired error, got %+v", e)
+	}
+}
+
+func TestServer_CustomResources(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, _ := client.NewClientForConfig(s.Config())
+
+	crd := resource.NewCustomResourceDefinition()
+	crd.SetMetadataName("crontabs.stable.example.com")
+	crd.Spec = &resource.CustomResourceDefinitionSpec{
+		Group:   "stable.example.com",
+		Version: []*resource.CrdVersion{{Name: "v1", Served: true, Storage: true}},
+		Scope:   "Namespaced",
+		Names:   &resource.CrdNames{Plural: "crontabs", Kind: "CronTab"},
+	}
+	if _, err := c.ClientSideApply(context.Background(), crd); err != nil {
+		t.Fatal(err)
+	}
+	gvr, _ := crd.GroupVersionResource()
+	d, _ := dynamic.NewForConfig(s.Config())
+	crontab := resource.NewUnstructured("stable.example.com/v1", "CronTab")
+	crontab.SetName("job")
+	if _, err := d.Resource(gvr).Namespace("default").Create(context.Background(), crontab); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Object("stable.example.com/v1", "CronTab", "default", "job"); !ok {
+		t.Fatal("custom resource was not stored")
+	}
+}
+
+func TestServer_Scale(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, err := client.NewClientForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, _ := dynamic.NewForConfig(s.Config())
+	ctx := context.Background()
+	deployments := d.Resource(resource.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("default")
+	deploy := resource.NewUnstructured("apps/v1", resource.RESOURCE_DEPLOYMENT)
+	deploy.SetName("web")
+	resource.SetNestedField(deploy.Object, int64(2), "spec", "replicas")
+	resource.SetNestedStringMap(deploy.Object, map[string]string{"app": "web", "tier": "frontend"}, "spec", "selector", "matchLabels")
+	if _, err := deployments.Create(ctx, deploy); err != nil {
+		t.Fatal(err)
+	}
+
+	key := resource.ObjectKey{Kind: resource.RESOURCE_DEPLOYMENT, Namespace: "default", Name: "web"}
+	scale, err := c.GetScale(ctx, key)
+	if err != nil || scale.Spec.Replicas != 2 || scale.Status.Selector != "app=web,tier=frontend" {
+		t.Fatalf("unexpected scale %+v, err %v", scale, err)
+	}
+	stale := *scale
+	scale.Spec.Replicas = 0
+	if scale, err = c.UpdateScale(ctx, key, scale); err != nil || scale.Spec.Replicas != 0 {
+		t.Fatalf("unexpected scale %+v, err %v", scale, err)
+	}
+	if _, err := c.UpdateScale(ctx, key, &stale); !rest.IsConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if _, err := c.GetScale(ctx, resource.ObjectKey{Kind: resource.RESOURCE_POD, Name: "web"}); err == nil {
+		t.Fatal("expected error for kind without scale")
+	}
+
+	// 模拟控制器在副本数变化后更新status
+	go func() {
+		for {
+			obj, err := deployments.Get(ctx, "web")
+			if err != nil {
+				return
+			}
+			if replicas, _, _ := resource.NestedInt64(obj.Object, "spec", "replicas"); replicas == 5 {
+				resource.SetNestedField(obj.Object, replicas, "status", "replicas")
+				deployments.UpdateStatus(ctx, obj)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := client.ScaleTo(waitCtx, c, resource.RESOURCE_DEPLOYMENT, "default", "web", 5); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_Evict(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, err := client.NewClientForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+	pod.SetName("web")
+	pod.SetNamespace("default")
+	if _, err := c.Create(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	grace := int64(0)
+	if err := c.Evict(ctx, "default", "web", &resource.DeleteOptions{GracePeriodSeconds: &grace}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, pod.GetObjectKey(), &resource.Unstructured{}); !rest.IsNotFound(err) {
+		t.Fatalf("expected evicted pod to be deleted, got %v", err)
+	}
+	if err := c.Evict(ctx, "default", "web", nil); !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestServer_DeleteOptions(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, err := client.NewClientForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, name := range []string{"web-1", "web-2", "db"} {
+		pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+		pod.SetName(name)
+		pod.SetNamespace("default")
+		pod.SetLabels(map[string]string{"app": strings.Split(name, "-")[0]})
+		if _, err := c.Create(ctx, pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	key := resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: "default", Name: "db"}
+	current := &resource.Unstructured{}
+	if _, err := c.Get(ctx, key, current); err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Delete(ctx, key, &resource.DeleteOptions{Preconditions: &resource.Preconditions{Uid: "other"}})
+	if !rest.IsConflict(err) || !strings.Contains(err.Error(), "Precondition failed") {
+		t.Fatalf("expected precondition conflict, got %v", err)
+	}
+	if err := c.Delete(ctx, key, &resource.DeleteOptions{PropagationPolicy: "Later"}); !rest.IsInvalid(err) {
+		t.Fatalf("expected invalid propagation policy, got %v", err)
+	}
+	if err := c.Delete(ctx, key, &resource.DeleteOptions{DryRun: []string{resource.DryRunAll}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, key, &resource.Unstructured{}); err != nil {
+		t.Fatalf("dry run should keep the pod, got %v", err)
+	}
+	grace := int64(0)
+	opts := &resource.DeleteOptions{
+		GracePeriodSeconds: &grace,
+		PropagationPolicy:  resource.DeletePropagationForeground,
+		Preconditions:      &resource.Preconditions{Uid: current.GetUid(), ResourceVersion: current.GetResourceVersion()},
+	}
+	if err := c.Delete(ctx, key, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteCollection(ctx, "v1", resource.RESOURCE_POD, nil, client.ListOptions{LabelSelector: "app=web"}); err != nil {
+		t.Fatal(err)
+	}
+	page, err := c.List(ctx, "v1", resource.RESOURCE_POD, client.ListOptions{Namespace: "default"})
+	if err != nil || len(page.Items) != 0 {
+		t.Fatalf("expected all pods to be deleted, got %d, err %v", len(page.Items), err)
+	}
+}
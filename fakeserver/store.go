@@ -0,0 +1,182 @@
+package fakeserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s-client-go/fields"
+	"k8s-client-go/labels"
+	"k8s-client-go/resource"
+	"k8s-client-go/watch"
+)
+
+// 每个watch缓存的事件数，接收方处理不及时导致缓存满时关闭该watch
+const watchBufferSize = 100
+
+// 对象的变更记录，用于watch
+type event struct {
+	Type      watch.EventType
+	group     string
+	resource  string
+	namespace string
+	object    map[string]interface{}
+	rv        int64
+}
+
+type watcher struct {
+	group     string
+	resource  string
+	namespace string // 为空时监听所有命名空间
+	label     labels.Selector
+	field     fields.Selector
+	result    chan event
+}
+
+func (w *watcher) matches(e event) bool {
+	if e.group != w.group || e.resource != w.resource {
+		return false
+	}
+	if w.namespace != "" && e.namespace != w.namespace {
+		return false
+	}
+	return matchesSelectors(e.object, w.label, w.field)
+}
+
+// 内存中的对象存储，所有对象共享一个递增的resourceVersion
+type store struct {
+	lock      sync.Mutex
+	rv        int64
+	objects   map[string]map[string]interface{}
+	history   []event
+	compacted int64 // 小于等于该版本的变更记录已被清除
+	watchers  map[*watcher]bool
+}
+
+func newStore() *store {
+	return &store{
+		objects:  map[string]map[string]interface{}{},
+		watchers: map[*watcher]bool{},
+	}
+}
+
+func storeKey(group, resource, namespace, name string) string {
+	return group + "/" + resource + "/" + namespace + "/" + name
+}
+
+// 以下方法需在持有lock时调用
+
+func (s *store) nextVersion() int64 {
+	s.rv++
+	return s.rv
+}
+
+func (s *store) get(group, resource, namespace, name string) (map[string]interface{}, bool) {
+	obj, ok := s.objects[storeKey(group, resource, namespace, name)]
+	return obj, ok
+}
+
+// 按键排序返回对象，namespace为空时返回所有命名空间的对象
+func (s *store) list(group, resource, namespace string) []map[string]interface{} {
+	prefix := group + "/" + resource + "/"
+	if namespace != "" {
+		prefix += namespace + "/"
+	}
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	objs := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		objs = append(objs, s.objects[key])
+	}
+	return objs
+}
+
+// 保存或删除对象并通知watch
+func (s *store) commit(eventType watch.EventType, group, res, namespace, name string, obj map[string]interface{}, rv int64) {
+	key := storeKey(group, res, namespace, name)
+	if eventType == watch.Deleted {
+		delete(s.objects, key)
+	} else {
+		s.objects[key] = obj
+	}
+	e := event{
+		Type:      eventType,
+		group:     group,
+		resource:  res,
+		namespace: namespace,
+		object:    (&resource.Unstructured{Object: obj}).DeepCopy().Object,
+		rv:        rv,
+	}
+	s.history = append(s.history, e)
+	for w := range s.watchers {
+		if !w.matches(e) {
+			continue
+		}
+		select {
+		case w.result <- e:
+		default:
+			s.removeWatcher(w)
+		}
+	}
+}
+
+func (s *store) addWatcher(w *watcher) {
+	s.watchers[w] = true
+}
+
+func (s *store) removeWatcher(w *watcher) {
+	if s.watchers[w] {
+		delete(s.watchers, w)
+		close(w.result)
+	}
+}
+
+// 变更记录中版本大于rv且匹配w的事件
+func (s *store) eventsSince(w *watcher, rv int64) []event {
+	var events []event
+	for _, e := range s.history {
+		if e.rv > rv && w.matches(e) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func matchesSelectors(obj map[string]interface{}, label labels.Selector, field fields.Selector) bool {
+	u := &resource.Unstructured{Object: obj}
+	if label != nil && !label.Matches(labels.Set(u.GetLabels())) {
+		return false
+	}
+	if field != nil && !field.Matches(objectFields(obj)) {
+		return false
+	}
+	return true
+}
+
+// 对象中所有非列表的标量字段，如 metadata.name、spec.nodeName、status.phase
+func objectFields(obj map[string]interface{}) fields.Set {
+	set := fields.Set{}
+	flattenFields("", obj, set)
+	return set
+}
+
+func flattenFields(prefix string, v interface{}, set fields.Set) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			flattenFields(k, item, set)
+		}
+	case []interface{}, nil:
+	default:
+		set[prefix] = fmt.Sprint(val)
+	}
+}
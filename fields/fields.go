@@ -0,0 +1,43 @@
+package fields
+
+import (
+	"sort"
+	"strings"
+)
+
+// 字段集合的只读接口
+type Fields interface {
+	Has(field string) bool
+	Get(field string) string
+}
+
+// 字段路径与值，如 {spec.nodeName: node-1, status.phase: Running}
+type Set map[string]string
+
+func (s Set) Has(field string) bool {
+	_, ok := s[field]
+	return ok
+}
+
+func (s Set) Get(field string) string {
+	return s[field]
+}
+
+// 按字段排序输出，值中的特殊字符会被转义
+func (s Set) String() string {
+	selector := make([]string, 0, len(s))
+	for k, v := range s {
+		selector = append(selector, k+"="+EscapeValue(v))
+	}
+	sort.Strings(selector)
+	return strings.Join(selector, ",")
+}
+
+func (s Set) AsSelector() Selector {
+	return SelectorFromSet(s)
+}
+
+// 可按字段过滤的对象
+type Object interface {
+	Fields() Set
+}
@@ -0,0 +1,274 @@
+package fields
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 查询参数名
+const QueryParam = "fieldSelector"
+
+type Operator string
+
+const (
+	Equals       Operator = "="
+	DoubleEquals Operator = "=="
+	NotEquals    Operator = "!="
+)
+
+// 字段选择器
+type Selector interface {
+	// 字段是否满足全部条件
+	Matches(Fields) bool
+	// 是否为空选择器（匹配所有）
+	Empty() bool
+	// 字段要求精确匹配时返回对应的值
+	RequiresExactMatch(field string) (value string, found bool)
+	// 返回全部条件
+	Requirements() Requirements
+	// 规范化的字符串形式，可直接用于fieldSelector参数
+	String() string
+}
+
+// 单个匹配条件，如 spec.nodeName=node-1
+type Requirement struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+type Requirements []Requirement
+
+func (r Requirement) Matches(ls Fields) bool {
+	switch r.Operator {
+	case Equals, DoubleEquals:
+		return ls.Has(r.Field) && ls.Get(r.Field) == r.Value
+	case NotEquals:
+		return ls.Get(r.Field) != r.Value
+	}
+	return false
+}
+
+func (r Requirement) String() string {
+	return r.Field + string(r.Operator) + EscapeValue(r.Value)
+}
+
+// 按字段排序的条件列表，条件之间为与关系
+type internalSelector []Requirement
+
+func (s internalSelector) Matches(ls Fields) bool {
+	for _, r := range s {
+		if !r.Matches(ls) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s internalSelector) Empty() bool {
+	return len(s) == 0
+}
+
+func (s internalSelector) RequiresExactMatch(field string) (string, bool) {
+	for _, r := range s {
+		if r.Field == field && (r.Operator == Equals || r.Operator == DoubleEquals) {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
+func (s internalSelector) Requirements() Requirements {
+	return Requirements(s)
+}
+
+func (s internalSelector) String() string {
+	terms := make([]string, 0, len(s))
+	for _, r := range s {
+		terms = append(terms, r.String())
+	}
+	return strings.Join(terms, ",")
+}
+
+func newSelector(reqs []Requirement) Selector {
+	s := make(internalSelector, len(reqs))
+	copy(s, reqs)
+	sort.SliceStable(s, func(i, j int) bool { return s[i].Field < s[j].Field })
+	return s
+}
+
+// 匹配所有对象
+func Everything() Selector {
+	return internalSelector{}
+}
+
+func OneTermEqualSelector(field, value string) Selector {
+	return internalSelector{{Field: field, Operator: Equals, Value: value}}
+}
+
+func OneTermNotEqualSelector(field, value string) Selector {
+	return internalSelector{{Field: field, Operator: NotEquals, Value: value}}
+}
+
+// 合并多个选择器，条件之间为与关系
+func AndSelectors(selectors ...Selector) Selector {
+	var reqs []Requirement
+	for _, s := range selectors {
+		if s == nil {
+			continue
+		}
+		reqs = append(reqs, s.Requirements()...)
+	}
+	return newSelector(reqs)
+}
+
+// 等值匹配选择器
+func SelectorFromSet(ls Set) Selector {
+	reqs := make([]Requirement, 0, len(ls))
+	for k, v := range ls {
+		reqs = append(reqs, Requirement{Field: k, Operator: Equals, Value: v})
+	}
+	return newSelector(reqs)
+}
+
+// 解析失败时panic，仅用于常量
+func ParseSelectorOrDie(selector string) Selector {
+	s, err := ParseSelector(selector)
+	if err != nil {
+		panic("cannot parse '" + selector + "': " + err.Error())
+	}
+	return s
+}
+
+// 解析字符串形式的选择器，如 spec.nodeName=node-1,status.phase!=Pending
+func ParseSelector(selector string) (Selector, error) {
+	var reqs []Requirement
+	for _, term := range splitTerms(selector) {
+		if term == "" {
+			continue
+		}
+		field, op, value, ok := splitTerm(term)
+		if !ok {
+			return nil, fmt.Errorf("invalid selector: '%s'; can't understand '%s'", selector, term)
+		}
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, fmt.Errorf("invalid selector: '%s'; field name is empty in '%s'", selector, term)
+		}
+		unescaped, err := UnescapeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, Requirement{Field: field, Operator: op, Value: unescaped})
+	}
+	return newSelector(reqs), nil
+}
+
+// 按未转义的逗号拆分
+func splitTerms(selector string) []string {
+	if len(selector) == 0 {
+		return nil
+	}
+	var terms []string
+	start := 0
+	escaped := false
+	for i := 0; i < len(selector); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case selector[i] == '\\':
+			escaped = true
+		case selector[i] == ',':
+			terms = append(terms, selector[start:i])
+			start = i + 1
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+// 按第一个未转义的操作符拆分字段和值
+func splitTerm(term string) (field string, op Operator, value string, ok bool) {
+	escaped := false
+	for i := 0; i < len(term); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case term[i] == '\\':
+			escaped = true
+		case term[i] == '!' && strings.HasPrefix(term[i:], string(NotEquals)):
+			return term[:i], NotEquals, term[i+2:], true
+		case strings.HasPrefix(term[i:], string(DoubleEquals)):
+			return term[:i], DoubleEquals, term[i+2:], true
+		case term[i] == '=':
+			return term[:i], Equals, term[i+1:], true
+		}
+	}
+	return "", "", "", false
+}
+
+var valueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `=`, `\=`)
+
+// 转义值中的 \ , =
+func EscapeValue(s string) string {
+	return valueEscaper.Replace(s)
+}
+
+func UnescapeValue(s string) (string, error) {
+	if !strings.ContainsAny(s, `\,=`) {
+		return s, nil
+	}
+	var buf strings.Builder
+	escaped := false
+	for _, c := range s {
+		if escaped {
+			switch c {
+			case '\\', ',', '=':
+				buf.WriteRune(c)
+			default:
+				return "", fmt.Errorf("invalid escape sequence '\\%c' in field selector value %q", c, s)
+			}
+			escaped = false
+			continue
+		}
+		switch c {
+		case '\\':
+			escaped = true
+		case ',', '=':
+			return "", fmt.Errorf("invalid field selector value %q: unescaped '%c'", s, c)
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	if escaped {
+		return "", errors.New("invalid field selector value " + s + ": trailing '\\'")
+	}
+	return buf.String(), nil
+}
+
+// 在对象上求值，选择器引用对象不支持的字段时返回错误
+func Match(selector Selector, obj Object) (bool, error) {
+	set := obj.Fields()
+	for _, r := range selector.Requirements() {
+		if !set.Has(r.Field) {
+			return false, fmt.Errorf("field label not supported: %s", r.Field)
+		}
+	}
+	return selector.Matches(set), nil
+}
+
+// 过滤出匹配的对象
+func Filter(selector Selector, objs []Object) ([]Object, error) {
+	var matched []Object
+	for _, obj := range objs {
+		ok, err := Match(selector, obj)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, obj)
+		}
+	}
+	return matched, nil
+}
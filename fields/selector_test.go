@@ -0,0 +1,96 @@
+package fields_test
+
+import (
+	"testing"
+
+	"k8s-client-go/fields"
+	v1 "k8s-client-go/resource/core/v1"
+)
+
+func TestParseSelector(t *testing.T) {
+	cases := map[string]string{
+		"":                     "",
+		"spec.nodeName=node-1": "spec.nodeName=node-1",
+		"status.phase==Running,spec.nodeName=node-1": "spec.nodeName=node-1,status.phase==Running",
+		"metadata.name!=a":                           "metadata.name!=a",
+		`metadata.name=a\,b\=c\\d`:                   `metadata.name=a\,b\=c\\d`,
+		"type=":                                      "type=",
+	}
+	for in, want := range cases {
+		selector, err := fields.ParseSelector(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if got := selector.String(); got != want {
+			t.Errorf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+
+	for _, in := range []string{"spec.nodeName", "=node-1", `a=b\x`, "a=b=c", `a=b\`} {
+		if _, err := fields.ParseSelector(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+
+	selector := fields.ParseSelectorOrDie(`metadata.name=a\,b`)
+	if v, ok := selector.RequiresExactMatch("metadata.name"); !ok || v != "a,b" {
+		t.Fatalf("unexpected exact match %q", v)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("status.phase", "Running"),
+		fields.OneTermNotEqualSelector("metadata.namespace", "kube-system"),
+		fields.Set{"spec.nodeName": "node-1"}.AsSelector(),
+	)
+	if got := selector.String(); got != "metadata.namespace!=kube-system,spec.nodeName=node-1,status.phase=Running" {
+		t.Fatalf("unexpected selector %s", got)
+	}
+	if !fields.Everything().Empty() || selector.Empty() {
+		t.Fatal("unexpected empty state")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	pod := v1.NewResPod("web-0")
+	pod.Metadata.Namespace = "default"
+	pod.Spec.NodeName = "node-1"
+	pod.Status.Phase = "Running"
+
+	selector := fields.ParseSelectorOrDie("spec.nodeName=node-1,status.phase=Running")
+	if ok, err := fields.Match(selector, pod); err != nil || !ok {
+		t.Fatalf("pod should match: %v", err)
+	}
+	pod.Status.Phase = "Pending"
+	if ok, _ := fields.Match(selector, pod); ok {
+		t.Fatal("pending pod should not match")
+	}
+	if _, err := fields.Match(fields.OneTermEqualSelector("spec.hostname", "x"), pod); err == nil {
+		t.Fatal("expected unsupported field error")
+	}
+
+	secret := v1.NewSecret()
+	secret.Metadata.Name = "token"
+	secret.Type = "kubernetes.io/service-account-token"
+	opaque := v1.NewSecret()
+	opaque.Metadata.Name = "opaque"
+	matched, err := fields.Filter(fields.OneTermEqualSelector("type", "Opaque"), []fields.Object{secret, opaque})
+	if err != nil || len(matched) != 1 || matched[0] != opaque {
+		t.Fatalf("unexpected secrets %v %v", matched, err)
+	}
+
+	node := v1.NewResNode("node-1")
+	node.Spec.Unschedulable = true
+	if ok, _ := fields.Match(fields.OneTermEqualSelector("spec.unschedulable", "true"), node); !ok {
+		t.Fatal("cordoned node should match")
+	}
+
+	event := v1.NewResEvent("web-0.1")
+	event.InvolvedObject = v1.ObjectReference{Kind: "Pod", Name: "web-0", Namespace: "default"}
+	event.Type = "Warning"
+	if ok, _ := fields.Match(fields.ParseSelectorOrDie("involvedObject.kind=Pod,involvedObject.name=web-0,type=Warning"), event); !ok {
+		t.Fatal("event should match")
+	}
+}
@@ -0,0 +1,51 @@
+package labels
+
+import (
+	"sort"
+	"strings"
+)
+
+// 标签集合的只读接口
+type Labels interface {
+	Has(label string) bool
+	Get(label string) string
+}
+
+// 标签键值对，如 {app: web, tier: frontend}
+type Set map[string]string
+
+func (s Set) Has(label string) bool {
+	_, ok := s[label]
+	return ok
+}
+
+func (s Set) Get(label string) string {
+	return s[label]
+}
+
+// 按键排序输出，如 app=web,tier=frontend
+func (s Set) String() string {
+	pairs := make([]string, 0, len(s))
+	for k, v := range s {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// 转换为等值匹配的选择器
+func (s Set) AsSelector() Selector {
+	return SelectorFromSet(s)
+}
+
+// 合并两个标签集合，相同键以labels2为准
+func Merge(labels1, labels2 Set) Set {
+	merged := Set{}
+	for k, v := range labels1 {
+		merged[k] = v
+	}
+	for k, v := range labels2 {
+		merged[k] = v
+	}
+	return merged
+}
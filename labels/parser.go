@@ -0,0 +1,231 @@
+package labels
+
+import (
+	"fmt"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEnd tokenType = iota
+	tokenIdentifier
+	tokenComma
+	tokenOpenParen
+	tokenCloseParen
+	tokenEquals
+	tokenDoubleEquals
+	tokenNotEquals
+	tokenNot
+	tokenIn
+	tokenNotIn
+)
+
+type token struct {
+	typ   tokenType
+	value string
+}
+
+func (t token) String() string {
+	if t.typ == tokenEnd {
+		return "EOS"
+	}
+	return t.value
+}
+
+// 特殊字符，不能出现在标识符中
+const specialChars = "=!(),"
+
+type lexer struct {
+	s   string
+	pos int
+}
+
+func (l *lexer) next() token {
+	for l.pos < len(l.s) && unicode.IsSpace(rune(l.s[l.pos])) {
+		l.pos++
+	}
+	if l.pos >= len(l.s) {
+		return token{typ: tokenEnd}
+	}
+
+	switch c := l.s[l.pos]; c {
+	case ',':
+		l.pos++
+		return token{typ: tokenComma, value: ","}
+	case '(':
+		l.pos++
+		return token{typ: tokenOpenParen, value: "("}
+	case ')':
+		l.pos++
+		return token{typ: tokenCloseParen, value: ")"}
+	case '=':
+		l.pos++
+		if l.pos < len(l.s) && l.s[l.pos] == '=' {
+			l.pos++
+			return token{typ: tokenDoubleEquals, value: "=="}
+		}
+		return token{typ: tokenEquals, value: "="}
+	case '!':
+		l.pos++
+		if l.pos < len(l.s) && l.s[l.pos] == '=' {
+			l.pos++
+			return token{typ: tokenNotEquals, value: "!="}
+		}
+		return token{typ: tokenNot, value: "!"}
+	}
+
+	start := l.pos
+	for l.pos < len(l.s) && !unicode.IsSpace(rune(l.s[l.pos])) && !isSpecialChar(l.s[l.pos]) {
+		l.pos++
+	}
+	value := l.s[start:l.pos]
+	switch value {
+	case "in":
+		return token{typ: tokenIn, value: value}
+	case "notin":
+		return token{typ: tokenNotIn, value: value}
+	}
+	return token{typ: tokenIdentifier, value: value}
+}
+
+func isSpecialChar(c byte) bool {
+	for i := 0; i < len(specialChars); i++ {
+		if specialChars[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+type parser struct {
+	l      *lexer
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) consume() token {
+	t := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parse() ([]Requirement, error) {
+	for {
+		t := p.l.next()
+		p.tokens = append(p.tokens, t)
+		if t.typ == tokenEnd {
+			break
+		}
+	}
+
+	var requirements []Requirement
+	if p.peek().typ == tokenEnd {
+		return requirements, nil
+	}
+	for {
+		r, err := p.parseRequirement()
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *r)
+
+		switch t := p.consume(); t.typ {
+		case tokenEnd:
+			return requirements, nil
+		case tokenComma:
+			if p.peek().typ == tokenEnd {
+				return nil, fmt.Errorf("found '%s', expected: identifier after ','", p.peek())
+			}
+		default:
+			return nil, fmt.Errorf("found '%s', expected: ',' or 'end of string'", t)
+		}
+	}
+}
+
+// key | !key | key op value | key in (v1,v2)
+func (p *parser) parseRequirement() (*Requirement, error) {
+	t := p.consume()
+	if t.typ == tokenNot {
+		key := p.consume()
+		if key.typ != tokenIdentifier {
+			return nil, fmt.Errorf("found '%s', expected: identifier after '!'", key)
+		}
+		return NewRequirement(key.value, DoesNotExist, nil)
+	}
+	if t.typ != tokenIdentifier {
+		return nil, fmt.Errorf("found '%s', expected: !, identifier", t)
+	}
+	key := t.value
+
+	switch p.peek().typ {
+	case tokenEnd, tokenComma:
+		return NewRequirement(key, Exists, nil)
+	}
+
+	var op Operator
+	switch t := p.consume(); t.typ {
+	case tokenEquals:
+		op = Equals
+	case tokenDoubleEquals:
+		op = DoubleEquals
+	case tokenNotEquals:
+		op = NotEquals
+	case tokenIn:
+		op = In
+	case tokenNotIn:
+		op = NotIn
+	default:
+		return nil, fmt.Errorf("found '%s', expected: '=', '!=', '==', 'in', 'notin'", t)
+	}
+
+	if op == In || op == NotIn {
+		values, err := p.parseValues()
+		if err != nil {
+			return nil, err
+		}
+		return NewRequirement(key, op, values)
+	}
+
+	// 值可以为空，如 app=
+	value := ""
+	switch p.peek().typ {
+	case tokenIdentifier, tokenIn, tokenNotIn:
+		value = p.consume().value
+	case tokenEnd, tokenComma:
+	default:
+		return nil, fmt.Errorf("found '%s', expected: identifier", p.peek())
+	}
+	return NewRequirement(key, op, []string{value})
+}
+
+// (v1,v2,...)
+func (p *parser) parseValues() ([]string, error) {
+	if t := p.consume(); t.typ != tokenOpenParen {
+		return nil, fmt.Errorf("found '%s', expected: '('", t)
+	}
+	var values []string
+	value := ""
+	for {
+		switch t := p.consume(); t.typ {
+		case tokenIdentifier, tokenIn, tokenNotIn:
+			if value != "" {
+				return nil, fmt.Errorf("found '%s', expected: ',' or ')'", t)
+			}
+			value = t.value
+		case tokenComma:
+			values = append(values, value)
+			value = ""
+		case tokenCloseParen:
+			return append(values, value), nil
+		default:
+			return nil, fmt.Errorf("found '%s', expected: ',', ')' or identifier", t)
+		}
+	}
+}
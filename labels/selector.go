@@ -0,0 +1,299 @@
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 查询参数名
+const QueryParam = "labelSelector"
+
+type Operator string
+
+const (
+	Equals       Operator = "="
+	DoubleEquals Operator = "=="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+const (
+	qualifiedNameMaxLength = 63
+	labelValueMaxLength    = 63
+	dns1123SubdomainMaxLen = 253
+)
+
+var (
+	dns1123SubdomainRegexp = regexp.MustCompile("^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$")
+	qualifiedNameRegexp    = regexp.MustCompile("^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$")
+	labelValueRegexp       = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$")
+)
+
+// 标签选择器
+type Selector interface {
+	// 标签是否满足全部条件
+	Matches(Labels) bool
+	// 是否为空选择器（匹配所有）
+	Empty() bool
+	// 规范化的字符串形式，可直接用于labelSelector参数
+	String() string
+	// 追加条件，返回新的选择器
+	Add(r ...Requirement) Selector
+	// 返回全部条件，第二个返回值表示是否可选中对象
+	Requirements() (requirements Requirements, selectable bool)
+}
+
+// 单个匹配条件，如 tier in (a,b)
+type Requirement struct {
+	key      string
+	operator Operator
+	values   []string
+}
+
+type Requirements []Requirement
+
+// 创建条件并校验键、操作符和值
+func NewRequirement(key string, op Operator, vals []string) (*Requirement, error) {
+	if err := validateLabelKey(key); err != nil {
+		return nil, err
+	}
+	switch op {
+	case In, NotIn:
+		if len(vals) == 0 {
+			return nil, errors.New("for 'in', 'notin' operators, values set can't be empty")
+		}
+	case Equals, DoubleEquals, NotEquals:
+		if len(vals) != 1 {
+			return nil, errors.New("exact-match compatibility requires one single value")
+		}
+	case Exists, DoesNotExist:
+		if len(vals) != 0 {
+			return nil, errors.New("values set must be empty for exists and does not exist")
+		}
+	default:
+		return nil, fmt.Errorf("operator '%v' is not recognized", op)
+	}
+
+	for _, v := range vals {
+		if err := validateLabelValue(key, v); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if !containsString(values, v) {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return &Requirement{key: key, operator: op, values: values}, nil
+}
+
+func (r *Requirement) Key() string {
+	return r.key
+}
+
+func (r *Requirement) Operator() Operator {
+	return r.operator
+}
+
+// 排序后的值
+func (r *Requirement) Values() []string {
+	values := make([]string, len(r.values))
+	copy(values, r.values)
+	return values
+}
+
+func (r *Requirement) Matches(ls Labels) bool {
+	switch r.operator {
+	case In, Equals, DoubleEquals:
+		if !ls.Has(r.key) {
+			return false
+		}
+		return containsString(r.values, ls.Get(r.key))
+	case NotIn, NotEquals:
+		if !ls.Has(r.key) {
+			return true
+		}
+		return !containsString(r.values, ls.Get(r.key))
+	case Exists:
+		return ls.Has(r.key)
+	case DoesNotExist:
+		return !ls.Has(r.key)
+	}
+	return false
+}
+
+func (r *Requirement) String() string {
+	var buf strings.Builder
+	if r.operator == DoesNotExist {
+		buf.WriteString("!")
+	}
+	buf.WriteString(r.key)
+
+	switch r.operator {
+	case Equals:
+		buf.WriteString("=")
+	case DoubleEquals:
+		buf.WriteString("==")
+	case NotEquals:
+		buf.WriteString("!=")
+	case In:
+		buf.WriteString(" in ")
+	case NotIn:
+		buf.WriteString(" notin ")
+	case Exists, DoesNotExist:
+		return buf.String()
+	}
+
+	switch r.operator {
+	case In, NotIn:
+		buf.WriteString("(" + strings.Join(r.values, ",") + ")")
+	default:
+		buf.WriteString(r.values[0])
+	}
+	return buf.String()
+}
+
+// 按键排序的条件列表，条件之间为与关系
+type internalSelector []Requirement
+
+func (s internalSelector) Matches(ls Labels) bool {
+	for i := range s {
+		if !s[i].Matches(ls) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s internalSelector) Empty() bool {
+	return len(s) == 0
+}
+
+func (s internalSelector) String() string {
+	reqs := make([]string, 0, len(s))
+	for i := range s {
+		reqs = append(reqs, s[i].String())
+	}
+	return strings.Join(reqs, ",")
+}
+
+func (s internalSelector) Add(reqs ...Requirement) Selector {
+	ret := make(internalSelector, 0, len(s)+len(reqs))
+	ret = append(ret, s...)
+	ret = append(ret, reqs...)
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].key < ret[j].key })
+	return ret
+}
+
+func (s internalSelector) Requirements() (Requirements, bool) {
+	return Requirements(s), true
+}
+
+// 不匹配任何对象的选择器
+type nothingSelector struct{}
+
+func (n nothingSelector) Matches(_ Labels) bool              { return false }
+func (n nothingSelector) Empty() bool                        { return false }
+func (n nothingSelector) String() string                     { return "" }
+func (n nothingSelector) Add(_ ...Requirement) Selector      { return n }
+func (n nothingSelector) Requirements() (Requirements, bool) { return nil, false }
+
+// 匹配所有对象
+func Everything() Selector {
+	return internalSelector{}
+}
+
+// 不匹配任何对象
+func Nothing() Selector {
+	return nothingSelector{}
+}
+
+// 等值匹配选择器，键值不合法的条目会被忽略
+func SelectorFromSet(ls Set) Selector {
+	selector, err := ValidatedSelectorFromSet(ls)
+	if err != nil {
+		return Nothing()
+	}
+	return selector
+}
+
+func ValidatedSelectorFromSet(ls Set) (Selector, error) {
+	if len(ls) == 0 {
+		return internalSelector{}, nil
+	}
+	requirements := make([]Requirement, 0, len(ls))
+	for k, v := range ls {
+		r, err := NewRequirement(k, Equals, []string{v})
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *r)
+	}
+	sort.Slice(requirements, func(i, j int) bool { return requirements[i].key < requirements[j].key })
+	return internalSelector(requirements), nil
+}
+
+// 解析失败时panic，仅用于常量
+func MustParse(selector string) Selector {
+	s, err := Parse(selector)
+	if err != nil {
+		panic("cannot parse '" + selector + "': " + err.Error())
+	}
+	return s
+}
+
+// 解析字符串形式的选择器，如 app=web,tier in (a,b),!canary,env!=prod
+func Parse(selector string) (Selector, error) {
+	p := &parser{l: &lexer{s: selector}}
+	items, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].key < items[j].key })
+	return internalSelector(items), nil
+}
+
+func validateLabelKey(key string) error {
+	parts := strings.Split(key, "/")
+	name := parts[0]
+	switch len(parts) {
+	case 1:
+	case 2:
+		prefix := parts[0]
+		name = parts[1]
+		if len(prefix) == 0 || len(prefix) > dns1123SubdomainMaxLen || !dns1123SubdomainRegexp.MatchString(prefix) {
+			return fmt.Errorf("invalid label key %q: prefix part must be a valid DNS subdomain", key)
+		}
+	default:
+		return fmt.Errorf("invalid label key %q: a qualified name must have an optional DNS subdomain prefix and '/'", key)
+	}
+	if len(name) == 0 || len(name) > qualifiedNameMaxLength || !qualifiedNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid label key %q: name part must be no more than %d characters, consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character", key, qualifiedNameMaxLength)
+	}
+	return nil
+}
+
+func validateLabelValue(key, value string) error {
+	if len(value) > labelValueMaxLength || !labelValueRegexp.MatchString(value) {
+		return fmt.Errorf("invalid label value %q for key %q: must be no more than %d characters, consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character", value, key, labelValueMaxLength)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
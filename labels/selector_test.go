@@ -0,0 +1,98 @@
+package labels
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"":              "",
+		"app=web":       "app=web",
+		"app==web":      "app==web",
+		" env != prod ": "env!=prod",
+		"app=web,tier in (b, a),!canary,env!=prod": "app=web,!canary,env!=prod,tier in (a,b)",
+		"tier notin (a,a)":                         "tier notin (a)",
+		"example.com/owner":                        "example.com/owner",
+		"app=":                                     "app=",
+		"x in (a,)":                                "x in (,a)",
+	}
+	for in, want := range cases {
+		selector, err := Parse(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if got := selector.String(); got != want {
+			t.Errorf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+
+	for _, in := range []string{"app=web,", ",app", "app=(web)", "tier in a", "tier in (a b)", "!", "!app=web", "app web", "-app=web", "app=-web", "a/b/c"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	selector := MustParse("app=web,tier in (a,b),!canary,env!=prod")
+	cases := []struct {
+		labels Set
+		match  bool
+	}{
+		{Set{"app": "web", "tier": "a"}, true},
+		{Set{"app": "web", "tier": "b", "env": "dev"}, true},
+		{Set{"app": "web", "tier": "c"}, false},
+		{Set{"app": "web"}, false},
+		{Set{"app": "web", "tier": "a", "canary": ""}, false},
+		{Set{"app": "web", "tier": "a", "env": "prod"}, false},
+		{Set{"app": "db", "tier": "a"}, false},
+	}
+	for _, c := range cases {
+		if got := selector.Matches(c.labels); got != c.match {
+			t.Errorf("%v: expected %v, got %v", c.labels, c.match, got)
+		}
+	}
+
+	if !Everything().Matches(Set{"a": "b"}) || !Everything().Empty() {
+		t.Error("everything selector should match all labels")
+	}
+	if Nothing().Matches(Set{}) {
+		t.Error("nothing selector should not match")
+	}
+	if !MustParse("tier notin (a)").Matches(Set{}) {
+		t.Error("notin should match missing key")
+	}
+}
+
+func TestNewRequirement(t *testing.T) {
+	if _, err := NewRequirement("app", In, nil); err == nil {
+		t.Error("in requires values")
+	}
+	if _, err := NewRequirement("app", Exists, []string{"x"}); err == nil {
+		t.Error("exists must not have values")
+	}
+	if _, err := NewRequirement("app", Operator("like"), []string{"x"}); err == nil {
+		t.Error("unknown operator should fail")
+	}
+	r, err := NewRequirement("app", In, []string{"b", "a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "app in (a,b)" {
+		t.Fatalf("unexpected requirement %s", r.String())
+	}
+}
+
+func TestSelectorFromSet(t *testing.T) {
+	selector := Set{"tier": "frontend", "app": "web"}.AsSelector()
+	if selector.String() != "app=web,tier=frontend" {
+		t.Fatalf("unexpected selector %s", selector.String())
+	}
+	if !selector.Matches(Set{"app": "web", "tier": "frontend", "x": "y"}) {
+		t.Fatal("selector should match superset")
+	}
+	if _, err := ValidatedSelectorFromSet(Set{"app": "not valid"}); err == nil {
+		t.Fatal("expected invalid value error")
+	}
+}
@@ -0,0 +1,337 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RFC 6902 操作
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// add、replace、test需要保留值为null的value
+func (o Operation) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{"op": o.Op, "path": o.Path}
+	switch o.Op {
+	case OpAdd, OpReplace, OpTest:
+		m["value"] = o.Value
+	case OpMove, OpCopy:
+		m["from"] = o.From
+	}
+	return json.Marshal(m)
+}
+
+// 计算两个json文档之间的 RFC 6902 patch
+func CreateJSONPatch(original, modified []byte) ([]byte, error) {
+	a, err := decode(original)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decode(modified)
+	if err != nil {
+		return nil, err
+	}
+	ops := diffJSON("", a, b, []Operation{})
+	return json.Marshal(ops)
+}
+
+func diffJSON(path string, a, b interface{}, ops []Operation) []Operation {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range sortedMapKeys(av) {
+			if _, ok := bv[k]; !ok {
+				ops = append(ops, Operation{Op: OpRemove, Path: path + "/" + escapePointer(k)})
+			}
+		}
+		for _, k := range sortedMapKeys(bv) {
+			child := path + "/" + escapePointer(k)
+			if old, ok := av[k]; ok {
+				ops = diffJSON(child, old, bv[k], ops)
+			} else {
+				ops = append(ops, Operation{Op: OpAdd, Path: child, Value: bv[k]})
+			}
+		}
+		return ops
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			break
+		}
+		for i := range av {
+			ops = diffJSON(path+"/"+strconv.Itoa(i), av[i], bv[i], ops)
+		}
+		return ops
+	}
+	if !deepEqual(a, b) {
+		ops = append(ops, Operation{Op: OpReplace, Path: path, Value: b})
+	}
+	return ops
+}
+
+// 按 RFC 6902 应用patch
+func ApplyJSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("json patch must be an array of operations, got %T", raw)
+	}
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid json patch operation %v", item)
+		}
+		op := Operation{Value: m["value"]}
+		op.Op, _ = m["op"].(string)
+		op.Path, _ = m["path"].(string)
+		op.From, _ = m["from"].(string)
+		_, hasValue := m["value"]
+		if target, err = applyOperation(target, op, hasValue); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(target)
+}
+
+func applyOperation(doc interface{}, op Operation, hasValue bool) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case OpAdd:
+		if !hasValue {
+			return nil, fmt.Errorf("add operation at %q is missing value", op.Path)
+		}
+		return addValue(doc, path, deepCopy(op.Value))
+	case OpRemove:
+		doc, _, err = removeValue(doc, path)
+		return doc, err
+	case OpReplace:
+		if !hasValue {
+			return nil, fmt.Errorf("replace operation at %q is missing value", op.Path)
+		}
+		if _, err := getValue(doc, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return deepCopy(op.Value), nil
+		}
+		doc, _, err = removeValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopy(op.Value))
+	case OpMove:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+			return nil, fmt.Errorf("cannot move %q into its own child %q", op.From, op.Path)
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case OpCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopy(value))
+	case OpTest:
+		value, err := getValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !deepEqual(value, op.Value) {
+			return nil, fmt.Errorf("test operation at %q failed", op.Path)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unsupported operation %q", op.Op)
+}
+
+// 解析 RFC 6901 JSON Pointer
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid json pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(t, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func escapePointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d out of bounds", i)
+	}
+	return i, nil
+}
+
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	node := doc
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", "/"+strings.Join(path, "/"))
+			}
+			node = v
+		case []interface{}:
+			i, err := arrayIndex(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", "/"+strings.Join(path, "/"))
+		}
+	}
+	return node, nil
+}
+
+// 对父节点进行修改，返回修改后的根节点
+func modifyParent(doc interface{}, path []string, fn func(parent interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch n := doc.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path segment %q does not exist", path[0])
+		}
+		updated, err := modifyParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := modifyParent(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	}
+	return nil, fmt.Errorf("path segment %q does not exist", path[0])
+}
+
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modifyParent(doc, path, func(parent interface{}, key string) (interface{}, error) {
+		switch n := parent.(type) {
+		case map[string]interface{}:
+			n[key] = value
+			return n, nil
+		case []interface{}:
+			i, err := arrayIndex(key, len(n), true)
+			if err != nil {
+				return nil, err
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		return nil, fmt.Errorf("cannot add %q to a scalar", key)
+	})
+}
+
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the root document")
+	}
+	var removed interface{}
+	doc, err := modifyParent(doc, path, func(parent interface{}, key string) (interface{}, error) {
+		switch n := parent.(type) {
+		case map[string]interface{}:
+			v, ok := n[key]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", "/"+strings.Join(path, "/"))
+			}
+			removed = v
+			delete(n, key)
+			return n, nil
+		case []interface{}:
+			i, err := arrayIndex(key, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = n[i]
+			return append(n[:i], n[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from a scalar", key)
+	})
+	return doc, removed, err
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package patch
+
+import (
+	"encoding/json"
+)
+
+// 计算两个json对象之间的 RFC 7386 merge patch
+func CreateMergePatch(original, modified []byte) ([]byte, error) {
+	a, err := decodeObject(original)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeObject(modified)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(diffMerge(a, b, diffOptions{}))
+}
+
+// 计算patch时可以只保留删除或只保留新增和修改
+type diffOptions struct {
+	ignoreDeletions           bool
+	ignoreChangesAndAdditions bool
+}
+
+func diffMerge(a, b map[string]interface{}, opts diffOptions) map[string]interface{} {
+	patch := map[string]interface{}{}
+	if !opts.ignoreDeletions {
+		for k := range a {
+			if _, ok := b[k]; !ok {
+				patch[k] = nil
+			}
+		}
+	}
+	for k, bv := range b {
+		av, ok := a[k]
+		if !ok {
+			if !opts.ignoreChangesAndAdditions {
+				patch[k] = bv
+			}
+			continue
+		}
+		am, aIsMap := av.(map[string]interface{})
+		bm, bIsMap := bv.(map[string]interface{})
+		if aIsMap && bIsMap {
+			if sub := diffMerge(am, bm, opts); len(sub) > 0 {
+				patch[k] = sub
+			}
+			continue
+		}
+		if !opts.ignoreChangesAndAdditions && !deepEqual(av, bv) {
+			patch[k] = bv
+		}
+	}
+	return patch
+}
+
+// 按 RFC 7386 应用merge patch
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return deepCopy(patch)
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
@@ -0,0 +1,138 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+var ErrUnsupportedPatchType = errors.New("unsupported patch type")
+
+// 计算两个版本文档（json或yaml）之间的patch
+func Create(pt rest.PatchType, original, modified []byte) ([]byte, error) {
+	switch pt {
+	case rest.JSONPatchType:
+		return CreateJSONPatch(original, modified)
+	case rest.MergePatchType:
+		return CreateMergePatch(original, modified)
+	case rest.StrategicMergePatchType:
+		return CreateStrategicMergePatch(original, modified)
+	}
+	return nil, ErrUnsupportedPatchType
+}
+
+// 在本地应用patch，返回json格式的结果
+func Apply(pt rest.PatchType, doc, patch []byte) ([]byte, error) {
+	switch pt {
+	case rest.JSONPatchType:
+		return ApplyJSONPatch(doc, patch)
+	case rest.MergePatchType:
+		return ApplyMergePatch(doc, patch)
+	case rest.StrategicMergePatchType:
+		return ApplyStrategicMergePatch(doc, patch)
+	}
+	return nil, ErrUnsupportedPatchType
+}
+
+// 计算两个版本的资源结构体之间的patch
+func CreateFromObjects(pt rest.PatchType, original, modified interface{}) ([]byte, error) {
+	originalJson, err := resource.ToJson(original)
+	if err != nil {
+		return nil, err
+	}
+	modifiedJson, err := resource.ToJson(modified)
+	if err != nil {
+		return nil, err
+	}
+	return Create(pt, originalJson, modifiedJson)
+}
+
+// 在本地对资源结构体应用patch，结果写入out，用于预览
+func ApplyToObject(pt rest.PatchType, obj interface{}, patch []byte, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer, got %T", out)
+	}
+	doc, err := resource.ToJson(obj)
+	if err != nil {
+		return err
+	}
+	patched, err := Apply(pt, doc, patch)
+	if err != nil {
+		return err
+	}
+	m, err := decodeObject(patched)
+	if err != nil {
+		return err
+	}
+	// 解码到新的值，避免残留out原有的字段
+	fresh := reflect.New(v.Elem().Type())
+	if err := resource.FromMap(m, fresh.Interface()); err != nil {
+		return err
+	}
+	v.Elem().Set(fresh.Elem())
+	return nil
+}
+
+// 解析json或yaml文档，数字保留为json.Number
+func decode(data []byte) (interface{}, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty document")
+	}
+	if data[0] != '{' && data[0] != '[' {
+		m, err := resource.DecodeMap(data)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(m); err != nil {
+			return nil, err
+		}
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", doc)
+	}
+	return m, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = deepCopy(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = deepCopy(item)
+		}
+		return list
+	}
+	return v
+}
+
+func deepEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,189 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s-client-go/resource"
+	v1 "k8s-client-go/resource/core/v1"
+	"k8s-client-go/rest"
+)
+
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var a, b interface{}
+	if err := json.Unmarshal(got, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected %s, got %s", want, string(got))
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := `{"baz":"qux","foo":"bar","list":[1,2,3],"a/b":{"c":1}}`
+	patch := `[
+		{"op":"replace","path":"/baz","value":"boo"},
+		{"op":"add","path":"/hello","value":["world"]},
+		{"op":"remove","path":"/foo"},
+		{"op":"add","path":"/list/1","value":9},
+		{"op":"add","path":"/list/-","value":4},
+		{"op":"move","from":"/a~1b/c","path":"/moved"},
+		{"op":"copy","from":"/hello","path":"/copied"},
+		{"op":"test","path":"/moved","value":1}
+	]`
+	got, err := ApplyJSONPatch([]byte(doc), []byte(patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"baz":"boo","hello":["world"],"list":[1,9,2,3,4],"a/b":{},"moved":1,"copied":["world"]}`)
+
+	for _, bad := range []string{
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"test","path":"/baz","value":"nope"}]`,
+		`[{"op":"add","path":"/list/9","value":1}]`,
+		`[{"op":"replace","path":"/baz"}]`,
+		`[{"op":"jump","path":"/baz"}]`,
+	} {
+		if _, err := ApplyJSONPatch([]byte(doc), []byte(bad)); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	original := `{"a":1,"b":{"c":"d","e":[1,2]},"f":[1]}`
+	modified := `{"a":2,"b":{"c":"d","e":[1,3],"g":null},"f":[1,2]}`
+	p, err := CreateJSONPatch([]byte(original), []byte(modified))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, p, `[
+		{"op":"replace","path":"/a","value":2},
+		{"op":"replace","path":"/b/e/1","value":3},
+		{"op":"add","path":"/b/g","value":null},
+		{"op":"replace","path":"/f","value":[1,2]}
+	]`)
+	got, err := ApplyJSONPatch([]byte(original), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, modified)
+}
+
+func TestMergePatch(t *testing.T) {
+	// RFC 7386 示例
+	doc := `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`
+	patch := `{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`
+	got, err := ApplyMergePatch([]byte(doc), []byte(patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"This will be unchanged","phoneNumber":"+01-123-456-7890"}`
+	assertJSON(t, got, want)
+
+	created, err := CreateMergePatch([]byte(doc), []byte(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, created, patch)
+}
+
+func TestStrategicMergePatch(t *testing.T) {
+	doc := `{"spec":{"containers":[
+		{"name":"web","image":"nginx:1.16","ports":[{"containerPort":80}],"env":[{"name":"A","value":"1"}]},
+		{"name":"sidecar","image":"busybox"}
+	],"tolerations":[{"key":"a"}]},"metadata":{"finalizers":["x","y"]}}`
+
+	patch := `{"spec":{"containers":[
+		{"name":"web","image":"nginx:1.17","env":[{"name":"B","value":"2"}]},
+		{"name":"sidecar","$patch":"delete"},
+		{"name":"debug","image":"alpine"}
+	],"tolerations":[{"key":"b"}]},"metadata":{"finalizers":["z"],"$deleteFromPrimitiveList/finalizers":["x"]}}`
+	got, err := ApplyStrategicMergePatch([]byte(doc), []byte(patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"spec":{"containers":[
+		{"name":"web","image":"nginx:1.17","ports":[{"containerPort":80}],"env":[{"name":"A","value":"1"},{"name":"B","value":"2"}]},
+		{"name":"debug","image":"alpine"}
+	],"tolerations":[{"key":"b"}]},"metadata":{"finalizers":["y","z"]}}`
+	assertJSON(t, got, want)
+
+	replace := `{"spec":{"containers":[{"name":"only","image":"alpine"},{"$patch":"replace"}]}}`
+	got, err = ApplyStrategicMergePatch([]byte(doc), []byte(replace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"spec":{"containers":[{"name":"only","image":"alpine"}],"tolerations":[{"key":"a"}]},"metadata":{"finalizers":["x","y"]}}`)
+
+	created, err := CreateStrategicMergePatch([]byte(doc), []byte(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := ApplyStrategicMergePatch([]byte(doc), created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, roundTrip, want)
+}
+
+func TestObjects(t *testing.T) {
+	original := v1.NewResPod("web")
+	original.Spec.Containers = []resource.Container{{Name: "web", Image: "nginx:1.16"}, {Name: "log", Image: "busybox"}}
+	modified := v1.NewResPod("web")
+	modified.Spec.Containers = []resource.Container{{Name: "web", Image: "nginx:1.17"}, {Name: "log", Image: "busybox"}}
+	modified.Metadata.Labels["app"] = "web"
+
+	for _, pt := range []rest.PatchType{rest.JSONPatchType, rest.MergePatchType, rest.StrategicMergePatchType} {
+		p, err := CreateFromObjects(pt, original, modified)
+		if err != nil {
+			t.Fatalf("%s: %v", pt, err)
+		}
+		out := v1.NewResPod("stale")
+		out.Metadata.Namespace = "stale"
+		if err := ApplyToObject(pt, original, p, out); err != nil {
+			t.Fatalf("%s: %v", pt, err)
+		}
+		if out.Metadata.Name != "web" || out.Metadata.Namespace != "" || out.Metadata.Labels["app"] != "web" ||
+			len(out.Spec.Containers) != 2 || out.Spec.Containers[0].Image != "nginx:1.17" {
+			t.Fatalf("%s: unexpected result %+v", pt, out)
+		}
+	}
+
+	p, err := CreateFromObjects(rest.StrategicMergePatchType, original, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, p, `{"metadata":{"labels":{"app":"web"}},"spec":{"containers":[{"name":"web","image":"nginx:1.17"}]}}`)
+}
+
+func TestCreateThreeWayMergePatch(t *testing.T) {
+	original := `{"metadata":{"labels":{"app":"web","tier":"front"}},"spec":{"replicas":2,"containers":[{"name":"web","image":"nginx:1.16"},{"name":"sidecar","image":"envoy"}]}}`
+	modified := `{"metadata":{"labels":{"app":"web"}},"spec":{"replicas":3,"containers":[{"name":"web","image":"nginx:1.17"}]}}`
+	// 服务端当前对象中有其它途径写入的标签和字段
+	current := `{"metadata":{"labels":{"app":"web","tier":"front","team":"ops"}},"spec":{"replicas":2,"paused":true,"containers":[{"name":"web","image":"nginx:1.16","imagePullPolicy":"Always"},{"name":"sidecar","image":"envoy"}]}}`
+
+	p, err := CreateThreeWayMergePatch([]byte(original), []byte(modified), []byte(current), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, p, `{"metadata":{"labels":{"tier":null}},"spec":{"replicas":3,"containers":[{"name":"web","image":"nginx:1.17"},{"name":"sidecar","$patch":"delete"}]}}`)
+
+	got, err := ApplyStrategicMergePatch([]byte(current), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, got, `{"metadata":{"labels":{"app":"web","team":"ops"}},"spec":{"replicas":3,"paused":true,"containers":[{"name":"web","image":"nginx:1.17","imagePullPolicy":"Always"}]}}`)
+
+	// 没有上次apply的配置时不删除任何字段
+	p, err = CreateThreeWayMergePatch(nil, []byte(modified), []byte(current), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, p, `{"spec":{"replicas":3,"containers":[{"name":"web","image":"nginx:1.17"}]}}`)
+}
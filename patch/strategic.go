@@ -0,0 +1,429 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// strategic merge patch 指令
+const (
+	directiveMarker               = "$patch"
+	deleteDirective               = "delete"
+	replaceDirective              = "replace"
+	mergeDirective                = "merge"
+	retainKeysDirective           = "$retainKeys"
+	deleteFromPrimitiveListPrefix = "$deleteFromPrimitiveList/"
+	setElementOrderPrefix         = "$setElementOrder/"
+)
+
+// 按字段名确定列表元素的合并键，未列出的列表整体替换
+var mergeKeys = map[string][]string{
+	"containers":                {"name"},
+	"initContainers":            {"name"},
+	"ephemeralContainers":       {"name"},
+	"volumes":                   {"name"},
+	"env":                       {"name"},
+	"imagePullSecrets":          {"name"},
+	"ports":                     {"containerPort", "port"}, // 容器端口用containerPort，service端口用port
+	"volumeMounts":              {"mountPath"},
+	"volumeDevices":             {"devicePath"},
+	"hostAliases":               {"ip"},
+	"conditions":                {"type"},
+	"ownerReferences":           {"uid"},
+	"topologySpreadConstraints": {"topologyKey"},
+}
+
+// 按集合合并的基本类型列表
+var mergePrimitiveLists = map[string]bool{
+	"finalizers": true,
+}
+
+// 计算两个json对象之间的strategic merge patch
+func CreateStrategicMergePatch(original, modified []byte) ([]byte, error) {
+	a, err := decodeObject(original)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeObject(modified)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(diffStrategic(a, b, diffOptions{}))
+}
+
+func diffStrategic(a, b map[string]interface{}, opts diffOptions) map[string]interface{} {
+	patch := map[string]interface{}{}
+	if !opts.ignoreDeletions {
+		for k := range a {
+			if _, ok := b[k]; !ok {
+				patch[k] = nil
+			}
+		}
+	}
+	for k, bv := range b {
+		av, ok := a[k]
+		if !ok {
+			if !opts.ignoreChangesAndAdditions {
+				patch[k] = bv
+			}
+			continue
+		}
+		switch bt := bv.(type) {
+		case map[string]interface{}:
+			if am, ok := av.(map[string]interface{}); ok {
+				if sub := diffStrategic(am, bt, opts); len(sub) > 0 {
+					patch[k] = sub
+				}
+				continue
+			}
+		case []interface{}:
+			if al, ok := av.([]interface{}); ok {
+				diffStrategicList(k, al, bt, patch, opts)
+				continue
+			}
+		}
+		if !opts.ignoreChangesAndAdditions && !deepEqual(av, bv) {
+			patch[k] = bv
+		}
+	}
+	return patch
+}
+
+func diffStrategicList(field string, a, b []interface{}, patch map[string]interface{}, opts diffOptions) {
+	if deepEqual(a, b) {
+		return
+	}
+
+	if mergePrimitiveLists[field] {
+		var added, deleted []interface{}
+		simulated := []interface{}{}
+		for _, v := range a {
+			if containsValue(b, v) {
+				simulated = append(simulated, v)
+			} else {
+				deleted = append(deleted, v)
+			}
+		}
+		for _, v := range b {
+			if !containsValue(a, v) {
+				added = append(added, v)
+				simulated = append(simulated, v)
+			}
+		}
+		if len(added) > 0 && !opts.ignoreChangesAndAdditions {
+			patch[field] = added
+		}
+		if len(deleted) > 0 && !opts.ignoreDeletions {
+			patch[deleteFromPrimitiveListPrefix+field] = deleted
+		}
+		if !deepEqual(simulated, b) && !opts.ignoreChangesAndAdditions {
+			patch[setElementOrderPrefix+field] = b
+		}
+		return
+	}
+
+	key := mergeKeyFor(field, a, b)
+	if key == "" || !allHaveKey(a, key) || !allHaveKey(b, key) {
+		if !opts.ignoreChangesAndAdditions {
+			patch[field] = b
+		}
+		return
+	}
+
+	var items, order, expected []interface{}
+	simulated := []interface{}{}
+	for _, av := range a {
+		value := av.(map[string]interface{})[key]
+		if findByKey(b, key, value) >= 0 {
+			simulated = append(simulated, value)
+		} else if !opts.ignoreDeletions {
+			items = append(items, map[string]interface{}{key: value, directiveMarker: deleteDirective})
+		}
+	}
+	for _, bv := range b {
+		bm := bv.(map[string]interface{})
+		order = append(order, map[string]interface{}{key: bm[key]})
+		expected = append(expected, bm[key])
+		i := findByKey(a, key, bm[key])
+		if i < 0 {
+			if !opts.ignoreChangesAndAdditions {
+				items = append(items, bm)
+			}
+			simulated = append(simulated, bm[key])
+			continue
+		}
+		if sub := diffStrategic(a[i].(map[string]interface{}), bm, opts); len(sub) > 0 {
+			sub[key] = bm[key]
+			items = append(items, sub)
+		}
+	}
+	if len(items) > 0 {
+		patch[field] = items
+	}
+	if !deepEqual(simulated, expected) && !opts.ignoreChangesAndAdditions {
+		patch[setElementOrderPrefix+field] = order
+	}
+}
+
+// 应用strategic merge patch
+func ApplyStrategicMergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeObject(patch)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mergeStrategic(target, p)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	return json.Marshal(result)
+}
+
+func mergeStrategic(original, patch map[string]interface{}) (map[string]interface{}, error) {
+	if directive, ok := patch[directiveMarker]; ok {
+		switch directive {
+		case replaceDirective:
+			return stripDirectives(patch).(map[string]interface{}), nil
+		case deleteDirective:
+			return nil, nil
+		case mergeDirective:
+		default:
+			return nil, fmt.Errorf("unknown patch directive %v", directive)
+		}
+	}
+	if original == nil {
+		original = map[string]interface{}{}
+	}
+
+	if retain, ok := patch[retainKeysDirective].([]interface{}); ok {
+		for k := range original {
+			if !containsValue(retain, k) {
+				delete(original, k)
+			}
+		}
+	}
+	for k, v := range patch {
+		if !strings.HasPrefix(k, deleteFromPrimitiveListPrefix) {
+			continue
+		}
+		field := strings.TrimPrefix(k, deleteFromPrimitiveListPrefix)
+		values, _ := v.([]interface{})
+		if list, ok := original[field].([]interface{}); ok {
+			kept := []interface{}{}
+			for _, item := range list {
+				if !containsValue(values, item) {
+					kept = append(kept, item)
+				}
+			}
+			original[field] = kept
+		}
+	}
+
+	for k, v := range patch {
+		if strings.HasPrefix(k, "$") {
+			continue
+		}
+		switch pv := v.(type) {
+		case nil:
+			delete(original, k)
+		case map[string]interface{}:
+			om, _ := original[k].(map[string]interface{})
+			merged, err := mergeStrategic(om, pv)
+			if err != nil {
+				return nil, err
+			}
+			if merged == nil {
+				delete(original, k)
+			} else {
+				original[k] = merged
+			}
+		case []interface{}:
+			ol, _ := original[k].([]interface{})
+			merged, err := mergeStrategicList(k, ol, pv)
+			if err != nil {
+				return nil, err
+			}
+			original[k] = merged
+		default:
+			original[k] = pv
+		}
+	}
+
+	for k, v := range patch {
+		if !strings.HasPrefix(k, setElementOrderPrefix) {
+			continue
+		}
+		field := strings.TrimPrefix(k, setElementOrderPrefix)
+		order, _ := v.([]interface{})
+		if list, ok := original[field].([]interface{}); ok {
+			original[field] = sortByOrder(field, list, order)
+		}
+	}
+	return original, nil
+}
+
+func mergeStrategicList(field string, original, patch []interface{}) ([]interface{}, error) {
+	// 列表中的 {$patch: replace} 表示整体替换
+	for _, item := range patch {
+		if m, ok := item.(map[string]interface{}); ok && len(m) == 1 && m[directiveMarker] == replaceDirective {
+			return stripDirectives(patch).([]interface{}), nil
+		}
+	}
+
+	if mergePrimitiveLists[field] {
+		result := deepCopy(original).([]interface{})
+		for _, v := range patch {
+			if !containsValue(result, v) {
+				result = append(result, v)
+			}
+		}
+		return result, nil
+	}
+
+	key := mergeKeyFor(field, original, patch)
+	if key == "" {
+		return stripDirectives(patch).([]interface{}), nil
+	}
+
+	result := []interface{}{}
+	if original != nil {
+		result = deepCopy(original).([]interface{})
+	}
+	for _, item := range patch {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("list %q must contain objects with merge key %q", field, key)
+		}
+		value, ok := m[key]
+		if !ok {
+			return nil, fmt.Errorf("list %q element does not contain merge key %q", field, key)
+		}
+		i := findByKey(result, key, value)
+		if m[directiveMarker] == deleteDirective {
+			if i >= 0 {
+				result = append(result[:i], result[i+1:]...)
+			}
+			continue
+		}
+		if i < 0 {
+			result = append(result, stripDirectives(m))
+			continue
+		}
+		om, _ := result[i].(map[string]interface{})
+		merged, err := mergeStrategic(om, m)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = merged
+	}
+	return result, nil
+}
+
+func mergeKeyFor(field string, lists ...[]interface{}) string {
+	candidates := mergeKeys[field]
+	if len(candidates) == 0 {
+		return ""
+	}
+	for _, list := range lists {
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok {
+				for _, key := range candidates {
+					if _, ok := m[key]; ok {
+						return key
+					}
+				}
+			}
+		}
+	}
+	return candidates[0]
+}
+
+// 按 $setElementOrder 给出的顺序排列，未列出的元素保持原有顺序放在最后
+func sortByOrder(field string, list, order []interface{}) []interface{} {
+	key := mergeKeyFor(field, list)
+	identity := func(item interface{}) interface{} {
+		if m, ok := item.(map[string]interface{}); ok && key != "" {
+			return m[key]
+		}
+		return item
+	}
+	result := make([]interface{}, 0, len(list))
+	used := make([]bool, len(list))
+	for _, o := range order {
+		for i, item := range list {
+			if !used[i] && deepEqual(identity(item), identity(o)) {
+				result = append(result, item)
+				used[i] = true
+				break
+			}
+		}
+	}
+	for i, item := range list {
+		if !used[i] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// 去掉所有指令
+func stripDirectives(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if !strings.HasPrefix(k, "$") {
+				m[k] = stripDirectives(item)
+			}
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, 0, len(val))
+		for _, item := range val {
+			if m, ok := item.(map[string]interface{}); ok && len(m) == 1 && m[directiveMarker] != nil {
+				continue
+			}
+			list = append(list, stripDirectives(item))
+		}
+		return list
+	}
+	return v
+}
+
+func findByKey(list []interface{}, key string, value interface{}) int {
+	for i, item := range list {
+		if m, ok := item.(map[string]interface{}); ok && deepEqual(m[key], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func allHaveKey(list []interface{}, key string) bool {
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if deepEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// 三路合并：original为上次apply的配置，modified为本次期望的配置，current为服务端当前的对象
+// 新增和修改以current为基准计算；删除只针对上次apply过而本次去掉的字段，其它途径写入的字段保持不变
+func CreateThreeWayMergePatch(original, modified, current []byte, strategic bool) ([]byte, error) {
+	o := map[string]interface{}{}
+	if len(bytes.TrimSpace(original)) != 0 {
+		var err error
+		if o, err = decodeObject(original); err != nil {
+			return nil, err
+		}
+	}
+	m, err := decodeObject(modified)
+	if err != nil {
+		return nil, err
+	}
+	c, err := decodeObject(current)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffMerge
+	if strategic {
+		diff = diffStrategic
+	}
+	delta := diff(c, m, diffOptions{ignoreDeletions: true})
+	deletions := diff(o, m, diffOptions{ignoreChangesAndAdditions: true})
+	return json.Marshal(mergePatches(delta, deletions))
+}
+
+// 合并两个patch，列表中的元素patch直接拼接
+func mergePatches(a, b map[string]interface{}) map[string]interface{} {
+	for k, bv := range b {
+		av, ok := a[k]
+		if !ok {
+			a[k] = bv
+			continue
+		}
+		if am, ok := av.(map[string]interface{}); ok {
+			if bm, ok := bv.(map[string]interface{}); ok {
+				a[k] = mergePatches(am, bm)
+				continue
+			}
+		}
+		if al, ok := av.([]interface{}); ok {
+			if bl, ok := bv.([]interface{}); ok {
+				a[k] = append(al, bl...)
+			}
+		}
+	}
+	return a
+}
@@ -0,0 +1,335 @@
+package portforward
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/websocket"
+)
+
+// port-forward使用的WebSocket子协议
+// 请求中的每个端口占用两个通道，2*i为数据，2*i+1为错误，每个通道的第一个消息为2字节小端序的端口号
+const PortForwardProtocolV4Name = "v4.channel.k8s.io"
+
+// 为一个远端端口建立WebSocket连接
+type Dialer interface {
+	Dial(ctx context.Context, port uint16) (*websocket.Conn, error)
+}
+
+type wsDialer struct {
+	transport http.RoundTripper
+	header    http.Header
+	url       *url.URL
+}
+
+// 通过apiserver的 /pods/{name}/portforward 连接pod的端口
+func NewDialer(restClient *rest.RESTClient, namespace, pod string) (Dialer, error) {
+	mapping, err := rest.DefaultRESTMapper.RESTMapping("v1", resource.RESOURCE_POD)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	u := restClient.Host()
+	u.Path += mapping.ResourcePath(namespace, pod) + "/portforward"
+	return &wsDialer{transport: restClient.Transport(), header: restClient.Headers(), url: u}, nil
+}
+
+func (d *wsDialer) Dial(ctx context.Context, port uint16) (*websocket.Conn, error) {
+	u := *d.url
+	u.RawQuery = url.Values{"ports": []string{strconv.Itoa(int(port))}}.Encode()
+	conn, resp, err := websocket.Dial(ctx, d.transport, &u, d.header, []string{PortForwardProtocolV4Name})
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			if statusErr := rest.CheckResponse(resp); statusErr != nil {
+				return nil, statusErr
+			}
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// 本地端口与pod端口的对应关系
+type ForwardedPort struct {
+	Local  uint16
+	Remote uint16
+}
+
+// 将本地监听的端口转发到pod的端口，每个本地连接对应一个WebSocket连接
+type PortForwarder struct {
+	dialer    Dialer
+	addresses []string
+	ports     []ForwardedPort
+	stopChan  <-chan struct{}
+	readyChan chan struct{}
+	out       io.Writer
+	errOut    io.Writer
+
+	lock      sync.Mutex
+	listeners []net.Listener
+	wg        sync.WaitGroup
+	outLock   sync.Mutex
+}
+
+// ports的格式为 "本地端口:远端端口"，本地端口为空或0时随机选择，只写一个端口时本地与远端相同
+// 所有端口开始监听后关闭readyChan，关闭stopChan时停止转发；out和errOut可以为nil
+func New(dialer Dialer, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (*PortForwarder, error) {
+	return NewOnAddresses(dialer, []string{"localhost"}, ports, stopChan, readyChan, out, errOut)
+}
+
+// 在指定的本地地址上监听，localhost同时监听127.0.0.1和::1
+func NewOnAddresses(dialer Dialer, addresses []string, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut io.Writer) (*PortForwarder, error) {
+	if len(ports) == 0 {
+		return nil, errors.New("you must specify at least 1 port")
+	}
+	if len(addresses) == 0 {
+		return nil, errors.New("you must specify at least 1 address")
+	}
+	parsed, err := parsePorts(ports)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		out = io.Discard
+	}
+	if errOut == nil {
+		errOut = io.Discard
+	}
+	return &PortForwarder{
+		dialer:    dialer,
+		addresses: addresses,
+		ports:     parsed,
+		stopChan:  stopChan,
+		readyChan: readyChan,
+		out:       out,
+		errOut:    errOut,
+	}, nil
+}
+
+func parsePorts(ports []string) ([]ForwardedPort, error) {
+	var forwarded []ForwardedPort
+	for _, spec := range ports {
+		parts := strings.Split(spec, ":")
+		var local, remote string
+		switch len(parts) {
+		case 1:
+			local, remote = parts[0], parts[0]
+		case 2:
+			local, remote = parts[0], parts[1]
+			if local == "" {
+				local = "0"
+			}
+		default:
+			return nil, fmt.Errorf("invalid port format '%s'", spec)
+		}
+		localPort, err := strconv.ParseUint(local, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing local port '%s': %v", local, err)
+		}
+		remotePort, err := strconv.ParseUint(remote, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing remote port '%s': %v", remote, err)
+		}
+		if remotePort == 0 {
+			return nil, fmt.Errorf("remote port must be > 0")
+		}
+		forwarded = append(forwarded, ForwardedPort{Local: uint16(localPort), Remote: uint16(remotePort)})
+	}
+	return forwarded, nil
+}
+
+// 开始监听并转发，直到stopChan关闭后返回；任一端口无法监听时返回错误
+func (pf *PortForwarder) ForwardPorts() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		pf.Close()
+		pf.wg.Wait()
+	}()
+
+	listened := false
+	for i := range pf.ports {
+		port := &pf.ports[i]
+		if err := pf.listenOnPort(ctx, port); err != nil {
+			pf.printf(pf.errOut, "Unable to listen on port %d: %v\n", port.Local, err)
+			continue
+		}
+		listened = true
+	}
+	if !listened {
+		return errors.New("unable to listen on any of the requested ports")
+	}
+	if pf.readyChan != nil {
+		close(pf.readyChan)
+	}
+	<-pf.stopChan
+	return nil
+}
+
+func (pf *PortForwarder) listenOnPort(ctx context.Context, port *ForwardedPort) error {
+	var errs []string
+	listened := false
+	for _, address := range pf.addresses {
+		hosts := []string{address}
+		if address == "localhost" {
+			hosts = []string{"127.0.0.1", "::1"}
+		}
+		for _, host := range hosts {
+			listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(int(port.Local))))
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			// 随机端口在第一次监听后确定，其余地址使用同一端口
+			port.Local = uint16(listener.Addr().(*net.TCPAddr).Port)
+			pf.printf(pf.out, "Forwarding from %s -> %d\n", listener.Addr().String(), port.Remote)
+			pf.lock.Lock()
+			pf.listeners = append(pf.listeners, listener)
+			pf.lock.Unlock()
+			pf.wg.Add(1)
+			go pf.acceptConnections(ctx, listener, *port)
+			listened = true
+		}
+	}
+	if !listened {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (pf *PortForwarder) acceptConnections(ctx context.Context, listener net.Listener, port ForwardedPort) {
+	defer pf.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// 停止转发时监听被关闭
+			if ctx.Err() == nil && !isClosedError(err) {
+				pf.printf(pf.errOut, "Error accepting connection on port %d: %v\n", port.Local, err)
+			}
+			return
+		}
+		go pf.handleConnection(ctx, conn, port)
+	}
+}
+
+// 转发一个本地连接，远端返回的错误写入errOut
+func (pf *PortForwarder) handleConnection(ctx context.Context, conn net.Conn, port ForwardedPort) {
+	defer conn.Close()
+	pf.printf(pf.out, "Handling connection for %d\n", port.Local)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ws, err := pf.dialer.Dial(ctx, port.Remote)
+	if err != nil {
+		pf.printf(pf.errOut, "error creating stream for port %d -> %d: %v\n", port.Local, port.Remote, err)
+		return
+	}
+	defer ws.Close()
+	go func() {
+		<-ctx.Done()
+		ws.Close()
+		conn.Close()
+	}()
+
+	// 本地到远端
+	go func() {
+		defer cancel()
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if werr := ws.WriteMessage(websocket.BinaryMessage, append([]byte{0}, buf[:n]...)); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// 远端到本地，跳过每个通道第一个消息中的端口号
+	initialized := map[byte]bool{}
+	var remoteErr []byte
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		if messageType != websocket.BinaryMessage || len(data) == 0 {
+			continue
+		}
+		channel, payload := data[0], data[1:]
+		if !initialized[channel] {
+			if len(payload) < 2 {
+				pf.printf(pf.errOut, "error forwarding port %d -> %d: invalid port header\n", port.Local, port.Remote)
+				break
+			}
+			if p := binary.LittleEndian.Uint16(payload); p != port.Remote {
+				pf.printf(pf.errOut, "error forwarding port %d -> %d: unexpected port %d\n", port.Local, port.Remote, p)
+				break
+			}
+			initialized[channel] = true
+			payload = payload[2:]
+		}
+		switch channel {
+		case 0:
+			if len(payload) > 0 {
+				if _, err := conn.Write(payload); err != nil {
+					cancel()
+					return
+				}
+			}
+		case 1:
+			remoteErr = append(remoteErr, payload...)
+		}
+	}
+	if len(remoteErr) > 0 {
+		pf.printf(pf.errOut, "an error occurred forwarding %d -> %d: %s\n", port.Local, port.Remote, string(remoteErr))
+	}
+}
+
+// 已开始监听的端口，须在readyChan关闭后调用
+func (pf *PortForwarder) GetPorts() ([]ForwardedPort, error) {
+	pf.lock.Lock()
+	defer pf.lock.Unlock()
+	if len(pf.listeners) == 0 {
+		return nil, errors.New("listeners not ready")
+	}
+	ports := make([]ForwardedPort, len(pf.ports))
+	copy(ports, pf.ports)
+	return ports, nil
+}
+
+// 停止监听，ForwardPorts返回时会自动调用
+func (pf *PortForwarder) Close() {
+	pf.lock.Lock()
+	defer pf.lock.Unlock()
+	for _, listener := range pf.listeners {
+		listener.Close()
+	}
+}
+
+// 各连接并发输出，out和errOut不要求并发安全
+func (pf *PortForwarder) printf(w io.Writer, format string, args ...interface{}) {
+	pf.outLock.Lock()
+	defer pf.outLock.Unlock()
+	fmt.Fprintf(w, format, args...)
+}
+
+func isClosedError(err error) bool {
+	return errors.Is(err, net.ErrClosed)
+}
@@ -0,0 +1,138 @@
+package portforward
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s-client-go/rest"
+	"k8s-client-go/websocket"
+)
+
+// 模拟apiserver的port-forward：80端口在每行前加上 pod: 后返回，81端口通过错误通道返回错误
+func portForwardServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web/portforward" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		port, err := strconv.Atoi(r.URL.Query().Get("ports"))
+		if err != nil {
+			t.Errorf("invalid ports %q", r.URL.Query().Get("ports"))
+			return
+		}
+		conn, err := websocket.Upgrade(w, r, []string{PortForwardProtocolV4Name})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		header := make([]byte, 2)
+		binary.LittleEndian.PutUint16(header, uint16(port))
+		conn.WriteMessage(websocket.BinaryMessage, append([]byte{0}, header...))
+		conn.WriteMessage(websocket.BinaryMessage, append([]byte{1}, header...))
+		if port != 80 {
+			conn.WriteMessage(websocket.BinaryMessage, append([]byte{1}, "connection refused"...))
+			return
+		}
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if data[0] == 0 {
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte{0}, "pod: "+string(data[1:])...))
+			}
+		}
+	}))
+}
+
+type lockedBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func TestPortForwarder(t *testing.T) {
+	server := portForwardServer(t)
+	defer server.Close()
+	restClient, err := rest.NewRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dialer, err := NewDialer(restClient, "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	out, errOut := &lockedBuffer{}, &lockedBuffer{}
+	pf, err := NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{":80", "0:81"}, stopChan, readyChan, out, errOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- pf.ForwardPorts()
+	}()
+	<-readyChan
+	ports, err := pf.GetPorts()
+	if err != nil || len(ports) != 2 || ports[0].Remote != 80 || ports[0].Local == 0 {
+		t.Fatalf("unexpected ports %v %v", ports, err)
+	}
+
+	// 两个并发的连接各自对应一个WebSocket连接
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", ports[0].Local))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "hello %d\n", i)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil || line != fmt.Sprintf("pod: hello %d\n", i) {
+			t.Fatalf("unexpected response %q %v", line, err)
+		}
+	}
+
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", ports[1].Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if n, _ := conn.Read(make([]byte, 1)); n != 0 {
+		t.Fatal("expected connection to be closed")
+	}
+	conn.Close()
+
+	close(stopChan)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(errOut.String(), "connection refused") || strings.Count(out.String(), "Handling connection") != 3 {
+		t.Fatalf("unexpected output:\n%s\n%s", out.String(), errOut.String())
+	}
+	if _, err := New(dialer, []string{"80:0"}, stopChan, nil, nil, nil); err == nil {
+		t.Fatal("expected invalid port error")
+	}
+}
@@ -0,0 +1,207 @@
+package remotecommand
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s-client-go/rest"
+)
+
+// 复制的目标容器，需要容器中有tar命令
+type CopyOptions struct {
+	Namespace string
+	Pod       string
+	Container string // pod中只有一个容器时可以为空
+}
+
+// 将本地文件或目录复制到容器中的destPath，目录递归复制，保留文件权限
+func CopyToPod(ctx context.Context, restClient *rest.RESTClient, opts CopyOptions, srcPath, destPath string) error {
+	if srcPath == "" || destPath == "" {
+		return errors.New("source and destination are required")
+	}
+	if _, err := os.Lstat(srcPath); err != nil {
+		return err
+	}
+	destPath = path.Clean(destPath)
+	exec, err := NewExecutor(restClient, opts.Namespace, opts.Pod, ExecOptions{
+		Container: opts.Container,
+		Command:   []string{"tar", "-xmf", "-", "-C", path.Dir(destPath)},
+	})
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(makeTar(srcPath, path.Base(destPath), writer))
+	}()
+	defer reader.Close()
+	stderr := &bytes.Buffer{}
+	if err := exec.Stream(ctx, StreamOptions{Stdin: reader, Stderr: stderr}); err != nil {
+		return copyError(err, stderr)
+	}
+	return nil
+}
+
+// 将容器中的文件或目录复制到本地的destPath，拒绝归档中指向destPath之外的路径
+func CopyFromPod(ctx context.Context, restClient *rest.RESTClient, opts CopyOptions, srcPath, destPath string) error {
+	if srcPath == "" || destPath == "" {
+		return errors.New("source and destination are required")
+	}
+	srcPath = path.Clean(srcPath)
+	exec, err := NewExecutor(restClient, opts.Namespace, opts.Pod, ExecOptions{
+		Container: opts.Container,
+		Command:   []string{"tar", "-cf", "-", "-C", path.Dir(srcPath), path.Base(srcPath)},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	reader, writer := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		err := exec.Stream(ctx, StreamOptions{Stdout: writer, Stderr: stderr})
+		if err != nil {
+			err = copyError(err, stderr)
+		}
+		writer.CloseWithError(err)
+	}()
+	// 解压失败后结束exec
+	defer reader.Close()
+	if err := untar(reader, path.Base(srcPath), destPath); err != nil {
+		return err
+	}
+	// 归档结尾之后tar仍可能失败，如路径不存在时GNU tar输出空归档后以状态2退出，读完输出并返回exec的结果
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+// 在错误中附上stderr的内容，*ExitError保持原类型
+func copyError(err error, stderr *bytes.Buffer) error {
+	msg := strings.TrimSpace(stderr.String())
+	if msg == "" {
+		return err
+	}
+	if exitErr, ok := err.(*ExitError); ok {
+		return &ExitError{Code: exitErr.Code, Message: exitErr.Error() + ": " + msg}
+	}
+	return fmt.Errorf("%v: %s", err, msg)
+}
+
+// 将srcPath打包，归档中的路径以prefix开头
+func makeTar(srcPath, prefix string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(srcPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcPath, file)
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(prefix, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// 解压归档，prefix对应destPath，链接不会被创建
+func untar(r io.Reader, prefix, destPath string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := targetPath(hdr.Name, prefix, destPath)
+		if err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, mode.Perm()); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, mode.Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// 归档中的路径须在prefix之下，否则可能写到destPath之外
+func targetPath(name, prefix, destPath string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("illegal file path %q in archive", name)
+	}
+	if clean == prefix {
+		return destPath, nil
+	}
+	if !strings.HasPrefix(clean, prefix+"/") {
+		return "", fmt.Errorf("illegal file path %q in archive", name)
+	}
+	return filepath.Join(destPath, filepath.FromSlash(strings.TrimPrefix(clean, prefix+"/"))), nil
+}
+
+func writeFile(target string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// 文件已存在时OpenFile不会修改权限
+	return os.Chmod(target, perm)
+}
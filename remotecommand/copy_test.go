@@ -0,0 +1,170 @@
+package remotecommand
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s-client-go/rest"
+	"k8s-client-go/websocket"
+)
+
+// 模拟容器中的tar：解压时记录收到的文件，打包时返回archive
+func tarServer(t *testing.T, received map[string]*tar.Header, contents map[string]string, archive []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := strings.Join(r.URL.Query()["command"], " ")
+		conn, err := websocket.Upgrade(w, r, []string{StreamProtocolV4Name})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		switch command {
+		case "tar -xmf - -C /tmp":
+			reader, writer := io.Pipe()
+			go func() {
+				for {
+					_, data, err := conn.ReadMessage()
+					if err != nil {
+						writer.CloseWithError(err)
+						return
+					}
+					if data[0] == stdinChannel {
+						writer.Write(data[1:])
+					}
+				}
+			}()
+			tr := tar.NewReader(reader)
+			for {
+				hdr, err := tr.Next()
+				if err != nil {
+					break
+				}
+				data, _ := ioutil.ReadAll(tr)
+				received[hdr.Name] = hdr
+				contents[hdr.Name] = string(data)
+			}
+		case "tar -cf - -C /var log":
+			for len(archive) > 0 {
+				n := 100
+				if n > len(archive) {
+					n = len(archive)
+				}
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte{stdoutChannel}, archive[:n]...))
+				archive = archive[n:]
+			}
+		default:
+			// 与GNU tar一致：路径不存在时仍输出空归档，然后以状态2退出
+			if strings.HasPrefix(command, "tar -cf -") {
+				empty := &bytes.Buffer{}
+				tar.NewWriter(empty).Close()
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte{stdoutChannel}, empty.Bytes()...))
+			}
+			conn.WriteMessage(websocket.BinaryMessage, append([]byte{stderrChannel}, "tar: no such file\n"...))
+			conn.WriteMessage(websocket.BinaryMessage, append([]byte{errorChannel}, `{"kind":"Status","status":"Failure","reason":"NonZeroExitCode",`+
+				`"message":"command terminated with non-zero exit code","details":{"causes":[{"reason":"ExitCode","message":"2"}]}}`...))
+			return
+		}
+		conn.WriteMessage(websocket.BinaryMessage, append([]byte{errorChannel}, `{"kind":"Status","status":"Success"}`...))
+	}))
+}
+
+func buildArchive(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, name := range []string{"log/", "log/sub/", "log/sub/app.log", "../evil", "log/../../evil"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		hdr := &tar.Header{Name: name, Mode: 0640, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			hdr = &tar.Header{Name: name, Mode: 0750, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func TestCopyToPod(t *testing.T) {
+	received, contents := map[string]*tar.Header{}, map[string]string{}
+	server := tarServer(t, received, contents, nil)
+	defer server.Close()
+	restClient, err := rest.NewRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := t.TempDir()
+	os.MkdirAll(filepath.Join(src, "conf"), 0755)
+	ioutil.WriteFile(filepath.Join(src, "conf", "app.yaml"), []byte("port: 80\n"), 0600)
+	ioutil.WriteFile(filepath.Join(src, "run.sh"), []byte("#!/bin/sh\n"), 0755)
+	opts := CopyOptions{Namespace: "default", Pod: "web"}
+	if err := CopyToPod(context.Background(), restClient, opts, src, "/tmp/data"); err != nil {
+		t.Fatal(err)
+	}
+	if contents["data/conf/app.yaml"] != "port: 80\n" || received["data/conf/app.yaml"].Mode&0777 != 0600 ||
+		received["data/run.sh"].Mode&0777 != 0755 || received["data/conf/"] == nil {
+		t.Fatalf("unexpected archive %v", contents)
+	}
+
+	err = CopyToPod(context.Background(), restClient, opts, src, "/missing/data")
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.ExitStatus() != 2 || !strings.Contains(err.Error(), "tar: no such file") {
+		t.Fatalf("expected error with stderr, got %v", err)
+	}
+}
+
+func TestCopyFromPod(t *testing.T) {
+	files := map[string]string{"log/": "", "log/sub/": "", "log/sub/app.log": "started\n"}
+	server := tarServer(t, nil, nil, buildArchive(t, files))
+	defer server.Close()
+	restClient, err := rest.NewRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := CopyOptions{Namespace: "default", Pod: "web"}
+
+	dest := filepath.Join(t.TempDir(), "logs")
+	if err := CopyFromPod(context.Background(), restClient, opts, "/var/log", dest); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dest, "sub", "app.log"))
+	if err != nil || info.Mode().Perm() != 0640 {
+		t.Fatalf("unexpected file %v %v", info, err)
+	}
+	data, _ := ioutil.ReadFile(filepath.Join(dest, "sub", "app.log"))
+	if string(data) != "started\n" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	for _, evil := range []string{"../evil", "log/../../evil"} {
+		server := tarServer(t, nil, nil, buildArchive(t, map[string]string{"log/": "", evil: "owned"}))
+		restClient, _ := rest.NewRESTClient(&rest.Config{Host: server.URL})
+		root := t.TempDir()
+		err := CopyFromPod(context.Background(), restClient, opts, "/var/log", filepath.Join(root, "logs"))
+		server.Close()
+		if err == nil || !strings.Contains(err.Error(), "illegal file path") {
+			t.Fatalf("expected illegal path error for %s, got %v", evil, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "evil")); !os.IsNotExist(err) {
+			t.Fatalf("%s was written outside destination", evil)
+		}
+	}
+
+	err = CopyFromPod(context.Background(), restClient, opts, "/missing", dest)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.ExitStatus() != 2 || !strings.Contains(err.Error(), "tar: no such file") {
+		t.Fatalf("expected exit error with stderr, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package remotecommand
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/websocket"
+)
+
+// exec使用的WebSocket子协议，每个二进制消息的第一个字节为通道号
+const StreamProtocolV4Name = "v4.channel.k8s.io"
+
+const (
+	stdinChannel  = 0
+	stdoutChannel = 1
+	stderrChannel = 2
+	errorChannel  = 3 // 命令结束后服务端发送的Status
+	resizeChannel = 4
+)
+
+// 命令以非0状态退出时返回
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("command terminated with exit code %d", e.Code)
+}
+
+func (e *ExitError) ExitStatus() int {
+	return e.Code
+}
+
+// 终端大小
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+// 终端大小变化时依次返回新的大小，返回nil表示不再变化
+type TerminalSizeQueue interface {
+	Next() *TerminalSize
+}
+
+// 为nil的流不会在请求中打开，Tty为true时stderr合并到stdout
+type StreamOptions struct {
+	Stdin             io.Reader
+	Stdout            io.Writer
+	Stderr            io.Writer
+	Tty               bool
+	TerminalSizeQueue TerminalSizeQueue
+}
+
+// 在容器中执行的命令
+type ExecOptions struct {
+	Container string // pod中只有一个容器时可以为空
+	Command   []string
+}
+
+type Executor interface {
+	// 执行命令直到结束或ctx取消，命令以非0状态退出时返回*ExitError
+	Stream(ctx context.Context, options StreamOptions) error
+}
+
+type wsExecutor struct {
+	transport http.RoundTripper
+	header    http.Header
+	url       *url.URL
+}
+
+// 通过apiserver的 /pods/{name}/exec 在容器中执行命令
+func NewExecutor(restClient *rest.RESTClient, namespace, pod string, opts ExecOptions) (Executor, error) {
+	if len(opts.Command) == 0 {
+		return nil, errors.New("command is required")
+	}
+	mapping, err := rest.DefaultRESTMapper.RESTMapping("v1", resource.RESOURCE_POD)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	u := restClient.Host()
+	u.Path += mapping.ResourcePath(namespace, pod) + "/exec"
+	query := url.Values{}
+	for _, arg := range opts.Command {
+		query.Add("command", arg)
+	}
+	if opts.Container != "" {
+		query.Set("container", opts.Container)
+	}
+	u.RawQuery = query.Encode()
+	return NewWebSocketExecutor(restClient.Transport(), restClient.Headers(), u), nil
+}
+
+// u为完整的exec地址，包括command和container参数，流相关的参数由Stream根据StreamOptions设置
+func NewWebSocketExecutor(transport http.RoundTripper, header http.Header, u *url.URL) Executor {
+	return &wsExecutor{transport: transport, header: header, url: u}
+}
+
+func (e *wsExecutor) Stream(ctx context.Context, options StreamOptions) error {
+	u := *e.url
+	query := u.Query()
+	query.Set("stdin", strconv.FormatBool(options.Stdin != nil))
+	query.Set("stdout", strconv.FormatBool(options.Stdout != nil))
+	query.Set("stderr", strconv.FormatBool(options.Stderr != nil && !options.Tty))
+	query.Set("tty", strconv.FormatBool(options.Tty))
+	u.RawQuery = query.Encode()
+
+	conn, resp, err := websocket.Dial(ctx, e.transport, &u, e.header, []string{StreamProtocolV4Name})
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			if statusErr := rest.CheckResponse(resp); statusErr != nil {
+				return statusErr
+			}
+		}
+		return err
+	}
+	return stream(ctx, conn, options)
+}
+
+func stream(ctx context.Context, conn *websocket.Conn, options StreamOptions) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+
+	if options.Stdin != nil {
+		go copyStdin(conn, options.Stdin)
+	}
+	if options.Tty && options.TerminalSizeQueue != nil {
+		go handleResize(conn, options.TerminalSizeQueue, done)
+	}
+
+	var status []byte
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != io.EOF {
+				return err
+			}
+			break
+		}
+		if messageType != websocket.BinaryMessage || len(data) == 0 {
+			continue
+		}
+		// 连接建立后服务端会在每个通道上发送一个空消息
+		channel, payload := data[0], data[1:]
+		var out io.Writer
+		switch channel {
+		case stdoutChannel:
+			out = options.Stdout
+		case stderrChannel:
+			out = options.Stderr
+		case errorChannel:
+			status = append(status, payload...)
+			continue
+		default:
+			continue
+		}
+		if out != nil && len(payload) > 0 {
+			if _, err := out.Write(payload); err != nil {
+				return err
+			}
+		}
+	}
+	return statusError(status)
+}
+
+func copyStdin(conn *websocket.Conn, stdin io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if werr := conn.WriteMessage(websocket.BinaryMessage, append([]byte{stdinChannel}, buf[:n]...)); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func handleResize(conn *websocket.Conn, queue TerminalSizeQueue, done <-chan struct{}) {
+	for {
+		size := queue.Next()
+		if size == nil {
+			return
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+		data, err := json.Marshal(size)
+		if err != nil {
+			return
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, append([]byte{resizeChannel}, data...)); err != nil {
+			return
+		}
+	}
+}
+
+// 解析错误通道中的Status，Success或为空时返回nil
+func statusError(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	status := resource.Status{}
+	if err := yaml.Unmarshal(data, &status); err != nil || status.Status == "" {
+		return errors.New(string(data))
+	}
+	if status.Status == "Success" {
+		return nil
+	}
+	if status.Reason == "NonZeroExitCode" {
+		for _, cause := range status.Details.Causes {
+			if cause.Reason != "ExitCode" {
+				continue
+			}
+			code, err := strconv.Atoi(cause.Message)
+			if err != nil {
+				return fmt.Errorf("error stream protocol error: invalid exit code value %q", cause.Message)
+			}
+			return &ExitError{Code: code, Message: status.Message}
+		}
+	}
+	return &rest.StatusError{Status: status}
+}
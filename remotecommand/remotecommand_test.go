@@ -0,0 +1,132 @@
+package remotecommand
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s-client-go/rest"
+	"k8s-client-go/websocket"
+)
+
+// 模拟apiserver的exec：将stdin原样写回stdout，请求stderr时在stderr输出一行，收到resize后结束，宽度不为80时退出码为3
+func execServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web/exec" {
+			http.Error(w, `{"kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"pods \"missing\" not found"}`, http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		if strings.Join(query["command"], " ") != "sh -c cat" || query.Get("container") != "app" || query.Get("stdin") != "true" {
+			t.Errorf("unexpected query %v", query)
+		}
+		conn, err := websocket.Upgrade(w, r, []string{StreamProtocolV4Name})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		for channel := byte(0); channel <= errorChannel; channel++ {
+			conn.WriteMessage(websocket.BinaryMessage, []byte{channel})
+		}
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			switch data[0] {
+			case stdinChannel:
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte{stdoutChannel}, data[1:]...))
+				if query.Get("stderr") == "true" {
+					conn.WriteMessage(websocket.BinaryMessage, append([]byte{stderrChannel}, "warning\n"...))
+				}
+			case resizeChannel:
+				size := TerminalSize{}
+				json.Unmarshal(data[1:], &size)
+				status := `{"kind":"Status","status":"Failure","reason":"NonZeroExitCode","message":"command terminated with non-zero exit code",` +
+					`"details":{"causes":[{"reason":"ExitCode","message":"3"}]}}`
+				if size.Width == 80 && size.Height == 24 {
+					status = `{"kind":"Status","status":"Success"}`
+				}
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte{errorChannel}, status...))
+				return
+			}
+		}
+	}))
+}
+
+type sizeQueue chan *TerminalSize
+
+func (q sizeQueue) Next() *TerminalSize {
+	return <-q
+}
+
+// 输出stdin后再发送终端大小，保证服务端先处理stdin
+type syncWriter struct {
+	bytes.Buffer
+	written chan struct{}
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	n, err := w.Buffer.Write(p)
+	select {
+	case w.written <- struct{}{}:
+	default:
+	}
+	return n, err
+}
+
+func runExec(t *testing.T, server *httptest.Server, pod string, width uint16) (string, string, error) {
+	restClient, err := rest.NewRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec, err := NewExecutor(restClient, "default", pod, ExecOptions{Container: "app", Command: []string{"sh", "-c", "cat"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := &syncWriter{written: make(chan struct{}, 1)}
+	stderr := &bytes.Buffer{}
+	queue := make(sizeQueue)
+	go func() {
+		<-stdout.written
+		queue <- &TerminalSize{Width: width, Height: 24}
+		close(queue)
+	}()
+	err = exec.Stream(context.Background(), StreamOptions{
+		Stdin:             strings.NewReader("hello\n"),
+		Stdout:            stdout,
+		Stderr:            stderr,
+		Tty:               true,
+		TerminalSizeQueue: queue,
+	})
+	return stdout.String(), stderr.String(), err
+}
+
+func TestExecutor_Stream(t *testing.T) {
+	server := execServer(t)
+	defer server.Close()
+
+	stdout, stderr, err := runExec(t, server, "web", 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tty模式下不请求stderr
+	if stdout != "hello\n" || stderr != "" {
+		t.Fatalf("unexpected output %q %q", stdout, stderr)
+	}
+
+	_, _, err = runExec(t, server, "web", 100)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.ExitStatus() != 3 {
+		t.Fatalf("expected exit code 3, got %v", err)
+	}
+
+	_, _, err = runExec(t, server, "missing", 80)
+	if !rest.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -2,18 +2,172 @@ package resource
 
 import "math/big"
 
+// 数值 = value * 10^scale
 type int64Amount struct {
 	value int64
 	scale Scale
 }
 
+// 十进制指数
 type Scale int32
 
+const (
+	Nano  Scale = -9
+	Micro Scale = -6
+	Milli Scale = -3
+	Kilo  Scale = 3
+	Mega  Scale = 6
+	Giga  Scale = 9
+	Tera  Scale = 12
+	Peta  Scale = 15
+	Exa   Scale = 18
+)
+
+var (
+	bigTen = big.NewInt(10)
+)
+
+func (a int64Amount) Sign() int {
+	switch {
+	case a.value == 0:
+		return 0
+	case a.value > 0:
+		return 1
+	}
+	return -1
+}
+
+func (a int64Amount) AsDec() *Dec {
+	d := &Dec{scale: a.scale}
+	d.unscaled.SetInt64(a.value)
+	return d
+}
+
+// 超出int64范围的数值
 type infDecAmount struct {
 	*Dec
 }
 
+// 任意精度的十进制数，数值 = unscaled * 10^scale
 type Dec struct {
 	unscaled big.Int
 	scale    Scale
 }
+
+func NewDec(unscaled int64, scale Scale) *Dec {
+	d := &Dec{scale: scale}
+	d.unscaled.SetInt64(unscaled)
+	return d
+}
+
+func (d *Dec) Unscaled() *big.Int {
+	return new(big.Int).Set(&d.unscaled)
+}
+
+func (d *Dec) Scale() Scale {
+	return d.scale
+}
+
+func (d *Dec) Sign() int {
+	return d.unscaled.Sign()
+}
+
+func (d *Dec) Copy() *Dec {
+	c := &Dec{scale: d.scale}
+	c.unscaled.Set(&d.unscaled)
+	return c
+}
+
+// 转换到更小的指数，数值不变
+func (d *Dec) rescale(scale Scale) *Dec {
+	c := d.Copy()
+	if scale < d.scale {
+		c.unscaled.Mul(&c.unscaled, pow10(int64(d.scale-scale)))
+		c.scale = scale
+	}
+	return c
+}
+
+// 去掉末尾的0
+func (d *Dec) normalize() *Dec {
+	c := d.Copy()
+	if c.unscaled.Sign() == 0 {
+		c.scale = 0
+		return c
+	}
+	r := new(big.Int)
+	q := new(big.Int)
+	for {
+		q.QuoRem(&c.unscaled, bigTen, r)
+		if r.Sign() != 0 {
+			return c
+		}
+		c.unscaled.Set(q)
+		c.scale++
+	}
+}
+
+func (d *Dec) Add(x, y *Dec) *Dec {
+	scale := x.scale
+	if y.scale < scale {
+		scale = y.scale
+	}
+	a, b := x.rescale(scale), y.rescale(scale)
+	d.unscaled.Add(&a.unscaled, &b.unscaled)
+	d.scale = scale
+	return d
+}
+
+func (d *Dec) Sub(x, y *Dec) *Dec {
+	neg := y.Copy()
+	neg.unscaled.Neg(&neg.unscaled)
+	return d.Add(x, neg)
+}
+
+func (d *Dec) Cmp(y *Dec) int {
+	scale := d.scale
+	if y.scale < scale {
+		scale = y.scale
+	}
+	return d.rescale(scale).unscaled.Cmp(&y.rescale(scale).unscaled)
+}
+
+// 按指定指数取整，ceil为true时向正无穷取整，否则远离0取整
+func (d *Dec) round(scale Scale, ceil bool) (*Dec, bool) {
+	if scale <= d.scale {
+		return d.rescale(scale), true
+	}
+	c := &Dec{scale: scale}
+	r := new(big.Int)
+	c.unscaled.QuoRem(&d.unscaled, pow10(int64(scale-d.scale)), r)
+	if r.Sign() == 0 {
+		return c, true
+	}
+	if r.Sign() > 0 {
+		c.unscaled.Add(&c.unscaled, big.NewInt(1))
+	} else if !ceil {
+		c.unscaled.Sub(&c.unscaled, big.NewInt(1))
+	}
+	return c, false
+}
+
+func (d *Dec) String() string {
+	n := d.normalize()
+	if n.scale >= 0 {
+		return new(big.Int).Mul(&n.unscaled, pow10(int64(n.scale))).String()
+	}
+	s := new(big.Int).Abs(&n.unscaled).String()
+	digits := int(-n.scale)
+	for len(s) <= digits {
+		s = "0" + s
+	}
+	s = s[:len(s)-digits] + "." + s[len(s)-digits:]
+	if n.unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
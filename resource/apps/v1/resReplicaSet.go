@@ -39,7 +39,7 @@ type ReplicaSetTemplate struct {
 		Labels map[string]string
 	}
 	Spec struct {
-		Containers []resource.IContainer
+		Containers resource.Containers
 	}
 }
 
@@ -69,8 +69,8 @@ func NewResReplicaSet() *ResReplicaSet {
 			Template: ReplicaSetTemplate{
 				Metadata: struct{ Labels map[string]string }{
 					Labels: map[string]string{}},
-				Spec: struct{ Containers []resource.IContainer }{
-					Containers: []resource.IContainer{}},
+				Spec: struct{ Containers resource.Containers }{
+					Containers: resource.Containers{}},
 			}},
 	}
 }
@@ -149,6 +149,18 @@ func (r *ResReplicaSet) SetReplicas(replica int) error {
 	return nil
 }
 
+func (r *ResReplicaSet) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	specPath := resource.NewPath("spec")
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.Replicas, specPath.Child("replicas"))...)
+	tmplPath := specPath.Child("template")
+	allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Template.Metadata.Labels, tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateSelectorMatchesTemplate(&r.Spec.Selector, r.Spec.Template.Metadata.Labels, specPath.Child("selector"), tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateContainers(r.Spec.Template.Spec.Containers, tmplPath.Child("spec", "containers"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResReplicaSet) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -44,7 +44,7 @@ type StatefulSetSpecTemplate struct {
 		Labels map[string]string
 	}
 	Spec struct {
-		Containers []resource.IContainer
+		Containers resource.Containers
 	}
 }
 
@@ -74,7 +74,7 @@ func NewResStatefulSet() *ResStatefulSet {
 			Replicas:    0,
 			Template: &StatefulSetSpecTemplate{
 				Metadata: struct{ Labels map[string]string }{Labels: map[string]string{}},
-				Spec:     struct{ Containers []resource.IContainer }{Containers: nil},
+				Spec:     struct{ Containers resource.Containers }{Containers: nil},
 			},
 			VolumeClaimTemplate: &VolumeClaimTemplate{
 				Metadata: struct {
@@ -193,6 +193,40 @@ func (r *ResStatefulSet) SetSelector(selector resource.Selector) error {
 	return nil
 }
 
+func (r *ResStatefulSet) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, resource.Required(specPath, "")).ToError()
+	}
+	if r.Spec.ServiceName == "" {
+		allErrs = append(allErrs, resource.Required(specPath.Child("serviceName"), ""))
+	} else {
+		allErrs = append(allErrs, resource.ValidateDNS1123Label(r.Spec.ServiceName, specPath.Child("serviceName"))...)
+	}
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.Replicas, specPath.Child("replicas"))...)
+	tmplPath := specPath.Child("template")
+	if r.Spec.Template == nil {
+		allErrs = append(allErrs, resource.Required(tmplPath, ""))
+	} else {
+		allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Template.Metadata.Labels, tmplPath.Child("metadata", "labels"))...)
+		allErrs = append(allErrs, resource.ValidateSelectorMatchesTemplate(&r.Spec.Selector, r.Spec.Template.Metadata.Labels, specPath.Child("selector"), tmplPath.Child("metadata", "labels"))...)
+		allErrs = append(allErrs, resource.ValidateContainers(r.Spec.Template.Spec.Containers, tmplPath.Child("spec", "containers"))...)
+	}
+	if claim := r.Spec.VolumeClaimTemplate; claim != nil && claim.Metadata.Name != "" {
+		claimPath := specPath.Child("volumeClaimTemplate")
+		allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(claim.Metadata.Name, claimPath.Child("metadata", "name"))...)
+		allErrs = append(allErrs, resource.ValidateAnnotations(claim.Metadata.Annotations, claimPath.Child("metadata", "annotations"))...)
+		if claim.Spec != nil {
+			for i, mode := range claim.Spec.AccessModes {
+				allErrs = append(allErrs, resource.ValidateEnum(mode, resource.AccessModes, claimPath.Child("spec", "accessModes").Index(i))...)
+			}
+			allErrs = append(allErrs, resource.ValidateQuantity(claim.Spec.Resources.Requests.Storage, claimPath.Child("spec", "resources", "requests", "storage"))...)
+		}
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResStatefulSet) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
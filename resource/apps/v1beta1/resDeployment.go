@@ -31,10 +31,10 @@ type ResDeployment struct {
 				Labels map[string]string
 			}
 			Spec struct {
-				Containers []resource.IContainer
+				Containers resource.Containers
 			}
 		}
-		Replicas string // replica副本数
+		Replicas *int32 `yaml:",omitempty"` // replica副本数，nil时由服务端默认为1
 	}
 }
 
@@ -55,20 +55,20 @@ func NewResDeployment() *ResDeployment {
 			Selector *resource.Selector
 			Template struct {
 				Metadata struct{ Labels map[string]string }
-				Spec     struct{ Containers []resource.IContainer }
+				Spec     struct{ Containers resource.Containers }
 			}
-			Replicas string
+			Replicas *int32 `yaml:",omitempty"`
 		}{
 			Selector: nil,
 			Template: struct {
 				Metadata struct{ Labels map[string]string }
-				Spec     struct{ Containers []resource.IContainer }
+				Spec     struct{ Containers resource.Containers }
 			}{
 				Metadata: struct{ Labels map[string]string }{
 					Labels: map[string]string{}},
-				Spec: struct{ Containers []resource.IContainer }{
+				Spec: struct{ Containers resource.Containers }{
 					Containers: nil}},
-			Replicas: ""},
+			Replicas: nil},
 	}
 }
 
@@ -130,6 +130,20 @@ func (r *ResDeployment) SetTemplateLabels(labels map[string]string) error {
 	return errors.New("labels are empty")
 }
 
+func (r *ResDeployment) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	specPath := resource.NewPath("spec")
+	if r.Spec.Replicas != nil {
+		allErrs = append(allErrs, resource.ValidateNonnegative(int(*r.Spec.Replicas), specPath.Child("replicas"))...)
+	}
+	tmplPath := specPath.Child("template")
+	allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Template.Metadata.Labels, tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateSelectorMatchesTemplate(r.Spec.Selector, r.Spec.Template.Metadata.Labels, specPath.Child("selector"), tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateContainers(r.Spec.Template.Spec.Containers, tmplPath.Child("spec", "containers"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResDeployment) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
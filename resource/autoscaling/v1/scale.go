@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"k8s-client-go/labels"
+	"k8s-client-go/resource"
+)
+
+// Deployment、ReplicaSet、StatefulSet和ReplicationController的scale子资源
+type Scale struct {
+	ApiVersion string `yaml:"apiVersion"`
+	Kind       string
+	Metadata   struct {
+		Name            string
+		Namespace       string
+		ResourceVersion string `yaml:"resourceVersion"`
+	}
+	Spec   ScaleSpec
+	Status ScaleStatus
+}
+
+type ScaleSpec struct {
+	Replicas int32
+}
+
+type ScaleStatus struct {
+	Replicas int32
+	Selector string // 序列化后的标签选择器，如 app=web
+}
+
+func NewScale() *Scale {
+	return &Scale{ApiVersion: "autoscaling/v1", Kind: "Scale"}
+}
+
+// 根据对象的spec.replicas、status.replicas和spec.selector生成scale子资源，用于测试中的模拟服务端
+func ScaleFromObject(obj map[string]interface{}) *Scale {
+	u := &resource.Unstructured{Object: obj}
+	scale := NewScale()
+	scale.Metadata.Name = u.GetName()
+	scale.Metadata.Namespace = u.GetNamespace()
+	scale.Metadata.ResourceVersion = u.GetResourceVersion()
+	replicas, found, _ := resource.NestedInt64(obj, "spec", "replicas")
+	if !found {
+		replicas = 1
+	}
+	scale.Spec.Replicas = int32(replicas)
+	status, _, _ := resource.NestedInt64(obj, "status", "replicas")
+	scale.Status.Replicas = int32(status)
+	// ReplicationController的selector直接是标签
+	selector, found, _ := resource.NestedStringMap(obj, "spec", "selector", "matchLabels")
+	if !found {
+		selector, _, _ = resource.NestedStringMap(obj, "spec", "selector")
+	}
+	scale.Status.Selector = labels.Set(selector).String()
+	return scale
+}
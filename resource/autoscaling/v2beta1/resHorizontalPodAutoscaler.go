@@ -138,6 +138,66 @@ func (r *ResHorizontalPodAutoscaler) SetMatchLabels(labels map[string]string) er
 	return nil
 }
 
+func (r *ResHorizontalPodAutoscaler) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, resource.Required(specPath, "")).ToError()
+	}
+	refPath := specPath.Child("scaleTargetRef")
+	if r.Spec.ScaleTargetRef == nil {
+		allErrs = append(allErrs, resource.Required(refPath, ""))
+	} else {
+		if r.Spec.ScaleTargetRef.Kind == "" {
+			allErrs = append(allErrs, resource.Required(refPath.Child("kind"), ""))
+		}
+		if r.Spec.ScaleTargetRef.Name == "" {
+			allErrs = append(allErrs, resource.Required(refPath.Child("name"), ""))
+		} else {
+			allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(r.Spec.ScaleTargetRef.Name, refPath.Child("name"))...)
+		}
+	}
+	if r.Spec.MinReplicas < 0 {
+		allErrs = append(allErrs, resource.Invalid(specPath.Child("minReplicas"), r.Spec.MinReplicas, "must be greater than or equal to 1"))
+	}
+	if r.Spec.MaxReplicas < 1 {
+		allErrs = append(allErrs, resource.Invalid(specPath.Child("maxReplicas"), r.Spec.MaxReplicas, "must be greater than 0"))
+	} else if r.Spec.MinReplicas > r.Spec.MaxReplicas {
+		allErrs = append(allErrs, resource.Invalid(specPath.Child("maxReplicas"), r.Spec.MaxReplicas, "must be greater than or equal to `minReplicas`"))
+	}
+	for i, metric := range r.Spec.Metrics {
+		metricPath := specPath.Child("metrics").Index(i)
+		if metric == nil {
+			continue
+		}
+		if metric.Type == "" {
+			allErrs = append(allErrs, resource.Required(metricPath.Child("type"), ""))
+			continue
+		}
+		allErrs = append(allErrs, resource.ValidateEnum(metric.Type, []string{METRIC_TYPE_RESOURCE, METRIC_TYPE_PODS, METRIC_TYPE_OBJECT, METRIC_TYPE_EXTERNAL}, metricPath.Child("type"))...)
+		switch metric.Type {
+		case METRIC_TYPE_RESOURCE:
+			if metric.Resource == nil {
+				allErrs = append(allErrs, resource.Required(metricPath.Child("resource"), "must populate information for the given metric source"))
+			}
+		case METRIC_TYPE_PODS:
+			if metric.Pods == nil {
+				allErrs = append(allErrs, resource.Required(metricPath.Child("pods"), "must populate information for the given metric source"))
+			} else {
+				allErrs = append(allErrs, resource.ValidateQuantity(metric.Pods.TargetAverageValue, metricPath.Child("pods", "targetAverageValue"))...)
+			}
+		case METRIC_TYPE_OBJECT:
+			if metric.Object == nil {
+				allErrs = append(allErrs, resource.Required(metricPath.Child("object"), "must populate information for the given metric source"))
+			} else {
+				allErrs = append(allErrs, resource.ValidateQuantity(metric.Object.TargetValue, metricPath.Child("object", "targetValue"))...)
+			}
+		}
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResHorizontalPodAutoscaler) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
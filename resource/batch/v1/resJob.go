@@ -117,6 +117,27 @@ func (r *ResJob) SetRestartPolicy(policy string) error {
 	return nil
 }
 
+func (r *ResJob) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.MetaData.Name, r.MetaData.Namespace, true, nil, resource.NewPath("metadata"))
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, resource.Required(specPath, "")).ToError()
+	}
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.Completions, specPath.Child("completions"))...)
+	if r.Spec.Template == nil || r.Spec.Template.Spec == nil {
+		return append(allErrs, resource.Required(specPath.Child("template"), "")).ToError()
+	}
+	tmplPath := specPath.Child("template", "spec")
+	containers := make([]resource.IContainer, 0, len(r.Spec.Template.Spec.Container))
+	for _, c := range r.Spec.Template.Spec.Container {
+		containers = append(containers, c)
+	}
+	allErrs = append(allErrs, resource.ValidateContainers(containers, tmplPath.Child("containers"))...)
+	// job 不允许使用 Always
+	allErrs = append(allErrs, resource.ValidateRestartPolicy(r.Spec.Template.Spec.RestartPolicy, []string{"OnFailure", "Never"}, tmplPath.Child("restartPolicy"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResJob) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -1,7 +1,29 @@
 package v1
 
-import "testing"
+import (
+	"k8s-client-go/resource"
+	"testing"
+)
 
 func TestNewJob(t *testing.T) {
 
 }
+
+func TestResJob_Validate(t *testing.T) {
+	job := NewResJob()
+	job.SetMetadataName("pi")
+	job.SetNamespace("default")
+	job.AddContainer(resource.NewContainer("pi", "perl"))
+	job.SetRestartPolicy("Always")
+
+	err := job.Validate()
+	errs, ok := err.(resource.ErrorList)
+	if !ok || len(errs) != 1 || errs[0].Field != "spec.template.spec.restartPolicy" {
+		t.Fatalf("%v", err)
+	}
+
+	job.SetRestartPolicy("Never")
+	if err := job.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -75,6 +75,35 @@ func NewResCronJob() *ResCronJob {
 	}
 }
 
+func (r *ResCronJob) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	// job名称会在cronjob名称后追加11位后缀
+	if len(r.Metadata.Name) > 52 {
+		allErrs = append(allErrs, resource.TooLong(resource.NewPath("metadata", "name"), r.Metadata.Name, 52))
+	}
+	specPath := resource.NewPath("spec")
+	if r.Spec.Schedule == "" {
+		allErrs = append(allErrs, resource.Required(specPath.Child("schedule"), ""))
+	}
+	allErrs = append(allErrs, resource.ValidateEnum(r.Spec.ConcurrencyPolicy, []string{"Allow", "Forbid", "Replace"}, specPath.Child("concurrencyPolicy"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.StartingDeadlineSeconds, specPath.Child("startingDeadlineSeconds"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.SuccessfulJobsHistoryLimit, specPath.Child("successfulJobsHistoryLimit"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegative(r.Spec.FailedJobsHistoryLimit, specPath.Child("failedJobsHistoryLimit"))...)
+
+	jobPath := specPath.Child("jobTemplate", "spec")
+	jobSpec := r.Spec.JobTemplate.Spec
+	allErrs = append(allErrs, resource.ValidateNonnegative(jobSpec.Completions, jobPath.Child("completions"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegative(jobSpec.Parallelism, jobPath.Child("parallelism"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegative(jobSpec.BackoffLimit, jobPath.Child("backoffLimit"))...)
+	podPath := jobPath.Child("template", "spec")
+	allErrs = append(allErrs, resource.ValidateLabels(jobSpec.Template.Metadata.Labels, jobPath.Child("template", "metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidatePodSpec(&jobSpec.Template.Spec, podPath)...)
+	if jobSpec.Template.Spec.RestartPolicy == "" || jobSpec.Template.Spec.RestartPolicy == "Always" {
+		allErrs = append(allErrs, resource.NotSupported(podPath.Child("restartPolicy"), jobSpec.Template.Spec.RestartPolicy, []string{"OnFailure", "Never"}))
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResCronJob) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -0,0 +1,177 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 结构体转换为通用的map，键名与yaml标签一致
+func ToMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMap(data)
+}
+
+// 解析yaml或json为通用的map
+func DecodeMap(data []byte) (map[string]interface{}, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := NormalizeYaml(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", raw)
+	}
+	return m, nil
+}
+
+// 解析包含多个文档（以---分隔）的yaml或json，kind为List的文档会展开其中的items
+func DecodeManifests(data []byte) ([]map[string]interface{}, error) {
+	var manifests []map[string]interface{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return manifests, nil
+			}
+			return nil, err
+		}
+		if raw == nil {
+			continue
+		}
+		m, ok := NormalizeYaml(raw).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", raw)
+		}
+		if m["kind"] == "List" {
+			items, _ := m["items"].([]interface{})
+			for _, item := range items {
+				if obj, ok := item.(map[string]interface{}); ok {
+					manifests = append(manifests, obj)
+				}
+			}
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+}
+
+// 通用的map转换为结构体
+func FromMap(m map[string]interface{}, obj interface{}) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return DecodeInto(data, obj)
+}
+
+// 部分字段的类型与结构体定义不一致，这些字段没有解码，其它字段已写入结构体
+type PartialDecodeError struct {
+	Err error
+}
+
+func (e *PartialDecodeError) Error() string {
+	return "partially decoded: " + e.Err.Error()
+}
+
+func IsPartialDecode(err error) bool {
+	_, ok := err.(*PartialDecodeError)
+	return ok
+}
+
+// 将yaml或json解码到结构体，类型不一致的字段返回*PartialDecodeError
+func DecodeInto(data []byte, obj interface{}) error {
+	err := yaml.Unmarshal(data, obj)
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		return &PartialDecodeError{Err: typeErr}
+	}
+	return err
+}
+
+// 将yaml解析出的map[interface{}]interface{}转换为map[string]interface{}，便于json序列化
+func NormalizeYaml(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = NormalizeYaml(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = NormalizeYaml(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = NormalizeYaml(item)
+		}
+		return list
+	}
+	return v
+}
+
+// 结构体转换为json，键名与yaml标签一致
+func ToJson(obj interface{}) ([]byte, error) {
+	m, err := ToMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// 去掉未设置字段后的清单，用于create、update和apply，避免未设置的字段覆盖服务端的值或被服务端校验拒绝
+// 是否设置以结构体的omitempty标签和指针为准，显式设置的0、false和空字符串会保留
+func ToManifest(obj interface{}) (map[string]interface{}, error) {
+	m, err := ToMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	pruned, _ := PruneEmpty(m).(map[string]interface{})
+	if pruned == nil {
+		pruned = map[string]interface{}{}
+	}
+	return pruned, nil
+}
+
+// 递归去掉 nil（nil指针和零值时间）以及空的map和列表（nil的map、切片和全为nil的结构体），列表中的元素保留
+func PruneEmpty(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, item := range val {
+			if pruned := PruneEmpty(item); pruned != nil {
+				m[k] = pruned
+			}
+		}
+		if len(m) == 0 {
+			return nil
+		}
+		return m
+	case []interface{}:
+		if len(val) == 0 {
+			return nil
+		}
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			// 列表中的空元素保持原样
+			if list[i] = PruneEmpty(item); list[i] == nil {
+				list[i] = item
+			}
+		}
+		return list
+	}
+	return v
+}
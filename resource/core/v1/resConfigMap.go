@@ -64,6 +64,16 @@ func (r *ResConfigMap) SetData(data []map[string]string) error {
 	}
 }
 
+func (r *ResConfigMap) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	for k := range r.Data {
+		for _, msg := range resource.IsConfigMapKey(k) {
+			allErrs = append(allErrs, resource.Invalid(resource.NewPath("data"), k, msg))
+		}
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResConfigMap) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -1,6 +1,11 @@
 package v1
 
-import "k8s-client-go/resource"
+import (
+	"errors"
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+	"net"
+)
 
 type IResEndpoints interface {
 	resource.IResource
@@ -13,7 +18,7 @@ type ResEndpoints struct {
 		Name      string
 		Namespace string
 	}
-	Subsets Subset
+	Subsets []Subset
 }
 
 type Subset struct {
@@ -39,3 +44,43 @@ func NewResEndpoints() *ResEndpoints {
 		}{Name: "", Namespace: ""},
 	}
 }
+
+func (r *ResEndpoints) SetMetadataName(name string) error {
+	if name == "" {
+		return errors.New("name is empty")
+	}
+	r.Metadata.Name = name
+	return nil
+}
+
+func (r *ResEndpoints) SetNamespace(ns string) error {
+	if ns == "" {
+		return errors.New("namespace is empty")
+	}
+	r.Metadata.Namespace = ns
+	return nil
+}
+
+func (r *ResEndpoints) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	for i, subset := range r.Subsets {
+		subsetPath := resource.NewPath("subsets").Index(i)
+		for j, addr := range subset.Addresses {
+			if net.ParseIP(addr.Ip) == nil {
+				allErrs = append(allErrs, resource.Invalid(subsetPath.Child("addresses").Index(j).Child("ip"), addr.Ip, "must be a valid IP address"))
+			}
+		}
+		for j, port := range subset.Ports {
+			allErrs = append(allErrs, resource.ValidatePort(port.Port, subsetPath.Child("ports").Index(j).Child("port"))...)
+		}
+	}
+	return allErrs.ToError()
+}
+
+func (r *ResEndpoints) ToYamlFile() ([]byte, error) {
+	yamlData, err := yaml.Marshal(*r)
+	if err != nil {
+		return []byte{}, err
+	}
+	return yamlData, nil
+}
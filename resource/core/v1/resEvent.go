@@ -1 +1,153 @@
 package v1
+
+import (
+	"errors"
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/fields"
+	"k8s-client-go/resource"
+)
+
+type IResEvent interface {
+	resource.IResource
+	SetMetadataName(string) error
+	SetNamespace(string) error
+	SetInvolvedObject(ObjectReference) error
+	SetReason(string) error
+	SetMessage(string) error
+	SetType(string) error
+}
+
+type ResEvent struct {
+	ApiVersion string `yaml:"apiVersion"`
+	Kind       string
+	Metadata   struct {
+		Name      string
+		Namespace string
+	}
+	InvolvedObject      ObjectReference `yaml:"involvedObject"`
+	Reason              string
+	Message             string
+	Source              EventSource
+	FirstTimestamp      resource.Time `yaml:"firstTimestamp,omitempty"`
+	LastTimestamp       resource.Time `yaml:"lastTimestamp,omitempty"`
+	Count               int
+	Type                string // Normal、Warning
+	ReportingController string `yaml:"reportingComponent,omitempty"`
+	ReportingInstance   string `yaml:"reportingInstance,omitempty"`
+}
+
+// 事件关联的对象
+type ObjectReference struct {
+	Kind            string
+	Namespace       string
+	Name            string
+	Uid             string
+	ApiVersion      string `yaml:"apiVersion"`
+	ResourceVersion string `yaml:"resourceVersion"`
+	FieldPath       string `yaml:"fieldPath"` // 如 spec.containers{nginx}
+}
+
+type EventSource struct {
+	Component string
+	Host      string
+}
+
+func NewResEvent(name string) *ResEvent {
+	return &ResEvent{
+		ApiVersion: "v1",
+		Kind:       resource.RESOURCE_EVENT,
+		Metadata: struct {
+			Name      string
+			Namespace string
+		}{Name: name, Namespace: ""},
+		Type: "Normal",
+	}
+}
+
+func (r *ResEvent) SetMetadataName(name string) error {
+	if name == "" {
+		return errors.New("name is empty")
+	}
+	r.Metadata.Name = name
+	return nil
+}
+
+func (r *ResEvent) SetNamespace(ns string) error {
+	if ns == "" {
+		return errors.New("namespace is empty")
+	}
+	r.Metadata.Namespace = ns
+	return nil
+}
+
+func (r *ResEvent) SetInvolvedObject(obj ObjectReference) error {
+	if obj.Kind == "" || obj.Name == "" {
+		return errors.New("involved object kind or name is empty")
+	}
+	r.InvolvedObject = obj
+	return nil
+}
+
+func (r *ResEvent) SetReason(reason string) error {
+	if reason == "" {
+		return errors.New("reason is empty")
+	}
+	r.Reason = reason
+	return nil
+}
+
+func (r *ResEvent) SetMessage(message string) error {
+	r.Message = message
+	return nil
+}
+
+func (r *ResEvent) SetType(typeName string) error {
+	if typeName != "Normal" && typeName != "Warning" {
+		return errors.New("type must be Normal or Warning")
+	}
+	r.Type = typeName
+	return nil
+}
+
+func (r *ResEvent) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	objPath := resource.NewPath("involvedObject")
+	if r.InvolvedObject.Kind == "" {
+		allErrs = append(allErrs, resource.Required(objPath.Child("kind"), ""))
+	}
+	if r.InvolvedObject.Name == "" {
+		allErrs = append(allErrs, resource.Required(objPath.Child("name"), ""))
+	}
+	if r.InvolvedObject.Namespace != "" && r.InvolvedObject.Namespace != r.Metadata.Namespace {
+		allErrs = append(allErrs, resource.Invalid(objPath.Child("namespace"), r.InvolvedObject.Namespace, "does not match event.namespace"))
+	}
+	allErrs = append(allErrs, resource.ValidateEnum(r.Type, []string{"Normal", "Warning"}, resource.NewPath("type"))...)
+	return allErrs.ToError()
+}
+
+// 支持的字段选择器
+func (r *ResEvent) Fields() fields.Set {
+	return fields.Set{
+		"metadata.name":                  r.Metadata.Name,
+		"metadata.namespace":             r.Metadata.Namespace,
+		"involvedObject.kind":            r.InvolvedObject.Kind,
+		"involvedObject.namespace":       r.InvolvedObject.Namespace,
+		"involvedObject.name":            r.InvolvedObject.Name,
+		"involvedObject.uid":             r.InvolvedObject.Uid,
+		"involvedObject.apiVersion":      r.InvolvedObject.ApiVersion,
+		"involvedObject.resourceVersion": r.InvolvedObject.ResourceVersion,
+		"involvedObject.fieldPath":       r.InvolvedObject.FieldPath,
+		"reason":                         r.Reason,
+		"reportingComponent":             r.ReportingController,
+		"source":                         r.Source.Component,
+		"type":                           r.Type,
+	}
+}
+
+func (r *ResEvent) ToYamlFile() ([]byte, error) {
+	yamlData, err := yaml.Marshal(*r)
+	if err != nil {
+		return []byte{}, err
+	}
+	return yamlData, nil
+}
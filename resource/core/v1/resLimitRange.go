@@ -99,6 +99,29 @@ func (r *ResLimitRange) AddLimits(limit *Limit) error {
 	return nil
 }
 
+func (r *ResLimitRange) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	for i, limit := range r.Spec.Limits {
+		idxPath := resource.NewPath("spec", "limits").Index(i)
+		if limit == nil {
+			continue
+		}
+		if limit.Type == "" {
+			allErrs = append(allErrs, resource.Required(idxPath.Child("type"), ""))
+		}
+		allErrs = append(allErrs, resource.ValidateEnum(limit.Type, []string{"Pod", "Container", "PersistentVolumeClaim"}, idxPath.Child("type"))...)
+		names := []string{"default", "max", "min", "maxLimitRequestRatio"}
+		for j, v := range []resource.Limits{limit.Default, limit.Max, limit.Min, limit.MaxLimitRequestRatio} {
+			allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(v.Cpu, idxPath.Child(names[j], "cpu"))...)
+			allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(v.Memory, idxPath.Child(names[j], "memory"))...)
+		}
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(limit.DefaultRequest.Cpu, idxPath.Child("defaultRequest", "cpu"))...)
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(limit.DefaultRequest.Memory, idxPath.Child("defaultRequest", "memory"))...)
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResLimitRange) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -67,6 +67,13 @@ func (r *ResNamespace) SetLabels(labels map[string]string) error {
 	return nil
 }
 
+func (r *ResNamespace) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, false, resource.IsDNS1123Label, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResNamespace) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -3,7 +3,9 @@ package v1
 import (
 	"errors"
 	"gopkg.in/yaml.v2"
+	"k8s-client-go/fields"
 	"k8s-client-go/resource"
+	"strconv"
 	"time"
 )
 
@@ -180,6 +182,38 @@ func (r *ResNode) GetExternalID() string {
 	return r.Spec.ExternalID
 }
 
+func (r *ResNode) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, false, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	if r.Spec != nil {
+		for i, taint := range r.Spec.Taints {
+			idxPath := resource.NewPath("spec", "taints").Index(i)
+			if taint == nil {
+				continue
+			}
+			allErrs = append(allErrs, resource.ValidateLabels(map[string]string{taint.Key: taint.Value}, idxPath)...)
+			if taint.Effect == "" {
+				allErrs = append(allErrs, resource.Required(idxPath.Child("effect"), ""))
+			}
+			allErrs = append(allErrs, resource.ValidateEnum(taint.Effect, resource.TaintEffects, idxPath.Child("effect"))...)
+		}
+	}
+	return allErrs.ToError()
+}
+
+// 支持的字段选择器
+func (r *ResNode) Fields() fields.Set {
+	unschedulable := false
+	if r.Spec != nil {
+		unschedulable = r.Spec.Unschedulable
+	}
+	return fields.Set{
+		"metadata.name":      r.Metadata.Name,
+		"spec.unschedulable": strconv.FormatBool(unschedulable),
+	}
+}
+
 func (r *ResNode) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -66,6 +66,27 @@ func NewPersistentVolume() *ResPersistentVolume {
 	}
 }
 
+func (r *ResPersistentVolume) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, false, nil, resource.NewPath("metadata"))
+	specPath := resource.NewPath("spec")
+	if r.Spec.Capacity.Storage == "" {
+		allErrs = append(allErrs, resource.Required(specPath.Child("capacity", "storage"), ""))
+	}
+	allErrs = append(allErrs, resource.ValidateQuantity(r.Spec.Capacity.Storage, specPath.Child("capacity", "storage"))...)
+	if len(r.Spec.AccessModes) == 0 {
+		allErrs = append(allErrs, resource.Required(specPath.Child("accessModes"), ""))
+	}
+	for i, mode := range r.Spec.AccessModes {
+		allErrs = append(allErrs, resource.ValidateEnum(mode, resource.AccessModes, specPath.Child("accessModes").Index(i))...)
+	}
+	allErrs = append(allErrs, resource.ValidateEnum(r.Spec.VolumeMode, resource.VolumeModes, specPath.Child("volumeMode"))...)
+	allErrs = append(allErrs, resource.ValidateEnum(r.Spec.PersistentVolumeReclaimPolicy, []string{"Retain", "Delete", "Recycle"}, specPath.Child("persistentVolumeReclaimPolicy"))...)
+	if r.Spec.StorageClassName != "" {
+		allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(r.Spec.StorageClassName, specPath.Child("storageClassName"))...)
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResPersistentVolume) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
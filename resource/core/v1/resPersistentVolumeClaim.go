@@ -65,6 +65,31 @@ type ResPVCSpec struct {
 	VolumeName       string `yaml:"volumeName" json:"VolumeName"`
 }
 
+func (r *ResPersistentVolumeClaim) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, resource.Required(specPath, "")).ToError()
+	}
+	if len(r.Spec.AccessModes) == 0 {
+		allErrs = append(allErrs, resource.Required(specPath.Child("accessModes"), "at least 1 access mode is required"))
+	}
+	for i, mode := range r.Spec.AccessModes {
+		allErrs = append(allErrs, resource.ValidateEnum(mode, resource.AccessModes, specPath.Child("accessModes").Index(i))...)
+	}
+	storagePath := specPath.Child("resources", "requests", "storage")
+	if r.Spec.Resources == nil || r.Spec.Resources.Requests == nil || r.Spec.Resources.Requests.Storage == "" {
+		allErrs = append(allErrs, resource.Required(storagePath, ""))
+	} else {
+		allErrs = append(allErrs, resource.ValidateQuantity(r.Spec.Resources.Requests.Storage, storagePath)...)
+	}
+	allErrs = append(allErrs, resource.ValidateEnum(r.Spec.VolumeMode, resource.VolumeModes, specPath.Child("volumeMode"))...)
+	if r.Spec.StorageClassName != "" {
+		allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(r.Spec.StorageClassName, specPath.Child("storageClassName"))...)
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResPersistentVolumeClaim) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
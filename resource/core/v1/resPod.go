@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"gopkg.in/yaml.v2"
+	"k8s-client-go/fields"
 	"k8s-client-go/resource"
 )
 
@@ -27,7 +28,8 @@ type ResPod struct {
 		Labels      map[string]string
 		Annotations map[string]string
 	}
-	Spec resource.PodSpec
+	Spec   resource.PodSpec
+	Status resource.ResPodStatus `yaml:"status,omitempty"`
 }
 
 func NewResPod(name string) *ResPod {
@@ -115,6 +117,29 @@ func (r *ResPod) SetAnnotations(annos map[string]string) error {
 	return nil
 }
 
+func (r *ResPod) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, resource.ValidatePodSpec(&r.Spec, resource.NewPath("spec"))...)
+	return allErrs.ToError()
+}
+
+// 支持的字段选择器
+func (r *ResPod) Fields() fields.Set {
+	return fields.Set{
+		"metadata.name":            r.Metadata.Name,
+		"metadata.namespace":       r.Metadata.Namespace,
+		"spec.nodeName":            r.Spec.NodeName,
+		"spec.restartPolicy":       r.Spec.RestartPolicy,
+		"spec.schedulerName":       r.Spec.SchedulerName,
+		"spec.serviceAccountName":  r.Spec.ServiceAccountName,
+		"status.phase":             r.Status.Phase,
+		"status.podIP":             r.Status.PodIP,
+		"status.nominatedNodeName": r.Status.NominatedNodeName,
+	}
+}
+
 func (r *ResPod) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -47,12 +47,12 @@ func NewResResourceQuota() *ResResourceQuota {
 		}{Name: "", Namespace: ""},
 		Spec: struct{ Hard *Hard }{Hard: &Hard{
 			Requests: &resource.Request{
-				Cpu:    "",
-				Memory: "",
+				Cpu:    resource.Quantity{},
+				Memory: resource.Quantity{},
 			},
 			Limits: &resource.Limits{
-				Cpu:    "",
-				Memory: "",
+				Cpu:    resource.Quantity{},
+				Memory: resource.Quantity{},
 			},
 		}},
 	}
@@ -78,6 +78,28 @@ func (r *ResResourceQuota) GetNamespace() string {
 	return r.Metadata.Namespace
 }
 
+func (r *ResResourceQuota) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	hard := r.Spec.Hard
+	if hard == nil {
+		return allErrs.ToError()
+	}
+	hardPath := resource.NewPath("spec", "hard")
+	names := []string{"configmaps", "persistentvolumeclaims", "replicationcontrollers", "secrets", "services", "pods"}
+	for i, value := range []string{hard.Configmaps, hard.Persistentvolumeclaims, hard.Replicationcontrollers, hard.Secrets, hard.Services, hard.Pods} {
+		allErrs = append(allErrs, resource.ValidateQuantity(value, hardPath.Child(names[i]))...)
+	}
+	if hard.Requests != nil {
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(hard.Requests.Cpu, hardPath.Child("requests", "cpu"))...)
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(hard.Requests.Memory, hardPath.Child("requests", "memory"))...)
+	}
+	if hard.Limits != nil {
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(hard.Limits.Cpu, hardPath.Child("limits", "cpu"))...)
+		allErrs = append(allErrs, resource.ValidateNonnegativeQuantity(hard.Limits.Memory, hardPath.Child("limits", "memory"))...)
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResResourceQuota) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"gopkg.in/yaml.v2"
+	"k8s-client-go/fields"
 	"k8s-client-go/resource"
 )
 
@@ -90,6 +91,32 @@ func (r *ResSecret) GetData() (map[string]string, error) {
 	return data, nil
 }
 
+func (r *ResSecret) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	if r.Type == "" {
+		allErrs = append(allErrs, resource.Required(resource.NewPath("type"), ""))
+	}
+	dataPath := resource.NewPath("data")
+	for k, v := range r.Data {
+		for _, msg := range resource.IsConfigMapKey(k) {
+			allErrs = append(allErrs, resource.Invalid(dataPath, k, msg))
+		}
+		if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+			allErrs = append(allErrs, resource.Invalid(dataPath.Key(k), "<secret contents redacted>", "must be base64 encoded"))
+		}
+	}
+	return allErrs.ToError()
+}
+
+// 支持的字段选择器
+func (r *ResSecret) Fields() fields.Set {
+	return fields.Set{
+		"metadata.name":      r.Metadata.Name,
+		"metadata.namespace": r.Metadata.Namespace,
+		"type":               r.Type,
+	}
+}
+
 func (r *ResSecret) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
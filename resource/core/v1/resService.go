@@ -1,6 +1,9 @@
 package v1
 
-import "k8s-client-go/resource"
+import (
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+)
 
 type IResService interface {
 	resource.IResource
@@ -42,3 +45,32 @@ func NewResService() *ResService {
 		}{Name: "", Namespace: "", Annotations: map[string]string{}},
 	}
 }
+
+func (r *ResService) Validate() error {
+	// service名称需符合DNS-1035规范
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, resource.IsDNS1123Label, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	specPath := resource.NewPath("spec")
+	allErrs = append(allErrs, resource.ValidateEnum(r.Spec.Type, []string{"ClusterIP", "NodePort", "LoadBalancer", "ExternalName"}, specPath.Child("type"))...)
+	allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Selector.MatchLabels, specPath.Child("selector"))...)
+	if len(r.Spec.Ports) == 0 && r.Spec.Type != "ExternalName" && r.Spec.ClusterIP != "None" {
+		allErrs = append(allErrs, resource.Required(specPath.Child("ports"), ""))
+	}
+	for i, port := range r.Spec.Ports {
+		portPath := specPath.Child("ports").Index(i)
+		allErrs = append(allErrs, resource.ValidatePort(port.Port, portPath.Child("port"))...)
+		if port.TargetPort != 0 {
+			allErrs = append(allErrs, resource.ValidatePort(port.TargetPort, portPath.Child("targetPort"))...)
+		}
+		allErrs = append(allErrs, resource.ValidateEnum(port.Protocol, resource.Protocols, portPath.Child("protocol"))...)
+	}
+	return allErrs.ToError()
+}
+
+func (r *ResService) ToYamlFile() ([]byte, error) {
+	yamlData, err := yaml.Marshal(*r)
+	if err != nil {
+		return []byte{}, err
+	}
+	return yamlData, nil
+}
@@ -1,6 +1,9 @@
 package v1
 
-import "k8s-client-go/resource"
+import (
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+)
 
 type IResServiceAccount interface {
 	resource.IResource
@@ -20,7 +23,7 @@ type ResServiceAccount struct {
 func NewResServiceAccount() *ResServiceAccount {
 	return &ResServiceAccount{
 		ApiVersion: "v1",
-		Kind:       resource.RESOURCE_SERVICE,
+		Kind:       resource.RESOURCE_SERVICE_ACCOUNT,
 		Metadata: struct {
 			Name        string
 			Namespace   string
@@ -29,3 +32,18 @@ func NewResServiceAccount() *ResServiceAccount {
 		}{Name: string(""), Namespace: string(""), Annotations: nil, Labels: nil},
 	}
 }
+
+func (r *ResServiceAccount) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	return allErrs.ToError()
+}
+
+func (r *ResServiceAccount) ToYamlFile() ([]byte, error) {
+	yamlData, err := yaml.Marshal(*r)
+	if err != nil {
+		return []byte{}, err
+	}
+	return yamlData, nil
+}
@@ -0,0 +1,68 @@
+package resource
+
+import "fmt"
+
+// 级联删除的方式
+const (
+	// 先删除依赖对象，最后删除owner，删除期间owner带有foregroundDeletion finalizer
+	DeletePropagationForeground = "Foreground"
+	// 立即删除owner，由垃圾回收器在后台删除依赖对象
+	DeletePropagationBackground = "Background"
+	// 只删除owner，依赖对象的ownerReferences被移除后保留
+	DeletePropagationOrphan = "Orphan"
+)
+
+// DryRun中的值，服务端执行校验和准入控制但不持久化
+const DryRunAll = "All"
+
+// 删除或驱逐对象时的参数，作为DELETE请求的body时服务端默认其类型为meta.k8s.io/v1 DeleteOptions
+type DeleteOptions struct {
+	// 等待pod优雅退出的秒数，nil时使用对象自身的设置，0表示立即删除
+	GracePeriodSeconds *int64 `yaml:"gracePeriodSeconds,omitempty"`
+	// 对象的uid或resourceVersion与之不一致时服务端返回409
+	Preconditions *Preconditions `yaml:"preconditions,omitempty"`
+	// 为空时由资源类型决定，大多数类型为Background
+	PropagationPolicy string   `yaml:"propagationPolicy,omitempty"`
+	DryRun            []string `yaml:"dryRun,omitempty"`
+}
+
+type Preconditions struct {
+	Uid             string `yaml:"uid,omitempty"`
+	ResourceVersion string `yaml:"resourceVersion,omitempty"`
+}
+
+// 检查obj的uid和resourceVersion，用于测试中的模拟服务端
+func (p *Preconditions) Check(obj map[string]interface{}) error {
+	if p == nil {
+		return nil
+	}
+	u := &Unstructured{Object: obj}
+	if p.Uid != "" && p.Uid != u.GetUid() {
+		return fmt.Errorf("Precondition failed: UID in precondition: %s, UID in object meta: %s", p.Uid, u.GetUid())
+	}
+	if p.ResourceVersion != "" && p.ResourceVersion != u.GetResourceVersion() {
+		return fmt.Errorf("Precondition failed: ResourceVersion in precondition: %s, ResourceVersion in object meta: %s", p.ResourceVersion, u.GetResourceVersion())
+	}
+	return nil
+}
+
+// DryRun中包含All
+func (o *DeleteOptions) IsDryRun() bool {
+	if o == nil {
+		return false
+	}
+	for _, v := range o.DryRun {
+		if v == DryRunAll {
+			return true
+		}
+	}
+	return false
+}
+
+// DELETE请求的body，opts为nil时返回nil
+func EncodeDeleteOptions(opts *DeleteOptions) ([]byte, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	return ToJson(opts)
+}
@@ -50,7 +50,7 @@ func NewResDaemonSet() *ResDaemonSet {
 					Labels: map[string]string{}},
 				Spec: &DaemonSetTemplateSpec{
 					Tolerations:                   []*DaemonSetToleration{},
-					Containers:                    []resource.IContainer{},
+					Containers:                    resource.Containers{},
 					TerminationGracePeriodSeconds: "",
 					Volumes:                       []*resource.Volume{},
 					RestartPolicy:                 "Always",
@@ -76,7 +76,7 @@ type DaemonSetSpecTemplate struct {
 
 type DaemonSetTemplateSpec struct {
 	Tolerations                   []*DaemonSetToleration
-	Containers                    []resource.IContainer
+	Containers                    resource.Containers
 	TerminationGracePeriodSeconds string `yaml:"terminationGracePeriodSeconds"`
 	Volumes                       []*resource.Volume
 	RestartPolicy                 string            `yaml:"restartPolicy"` // 默认为 Always
@@ -224,6 +224,35 @@ func (r *ResDaemonSet) SetNodeSelector(selectors []map[string]string) error {
 	return nil
 }
 
+func (r *ResDaemonSet) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil || r.Spec.Template == nil || r.Spec.Template.Spec == nil {
+		return append(allErrs, resource.Required(specPath.Child("template"), "")).ToError()
+	}
+	tmplPath := specPath.Child("template")
+	podPath := tmplPath.Child("spec")
+	allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Template.Metadata.Labels, tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateSelectorMatchesTemplate(r.Spec.Selector, r.Spec.Template.Metadata.Labels, specPath.Child("selector"), tmplPath.Child("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateContainers(r.Spec.Template.Spec.Containers, podPath.Child("containers"))...)
+	allErrs = append(allErrs, resource.ValidateVolumes(r.Spec.Template.Spec.Volumes, podPath.Child("volumes"))...)
+	// daemonset 只允许 Always
+	allErrs = append(allErrs, resource.ValidateRestartPolicy(r.Spec.Template.Spec.RestartPolicy, []string{"Always"}, podPath.Child("restartPolicy"))...)
+	allErrs = append(allErrs, resource.ValidateNonnegativeString(r.Spec.Template.Spec.TerminationGracePeriodSeconds, podPath.Child("terminationGracePeriodSeconds"))...)
+	for i, toler := range r.Spec.Template.Spec.Tolerations {
+		idxPath := podPath.Child("tolerations").Index(i)
+		if toler == nil {
+			continue
+		}
+		allErrs = append(allErrs, resource.ValidateEnum(toler.Operator, resource.TolerationOperator, idxPath.Child("operator"))...)
+		allErrs = append(allErrs, resource.ValidateEnum(toler.Effect, resource.TaintEffects, idxPath.Child("effect"))...)
+		allErrs = append(allErrs, resource.ValidateNonnegativeString(toler.TolerationSeconds, idxPath.Child("tolerationSeconds"))...)
+	}
+	allErrs = append(allErrs, resource.ValidateLabels(r.Spec.Template.Spec.NodeSelector, podPath.Child("nodeSelector"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResDaemonSet) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"errors"
 	"strconv"
+	"strings"
 	"k8s-client-go/resource"
 )
 
@@ -104,6 +105,45 @@ func (r *ResIngress) GetMetadataName() string {
 	return r.MetaData.Name
 }
 
+func (r *ResIngress) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.MetaData.Name, r.MetaData.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.MetaData.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.MetaData.Annotations, resource.NewPath("metadata", "annotations"))...)
+	specPath := resource.NewPath("spec")
+	for i, rule := range r.Spec.Rules {
+		rulePath := specPath.Child("rules").Index(i)
+		if rule == nil {
+			continue
+		}
+		if rule.Host != "" {
+			allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(strings.TrimPrefix(rule.Host, "*."), rulePath.Child("host"))...)
+		}
+		for j, path := range rule.Http.Paths {
+			pathPath := rulePath.Child("http", "paths").Index(j)
+			if path.Path != "" && !strings.HasPrefix(path.Path, "/") {
+				allErrs = append(allErrs, resource.Invalid(pathPath.Child("path"), path.Path, "must be an absolute path"))
+			}
+			backendPath := pathPath.Child("backend")
+			if path.Backend.ServiceName == "" {
+				allErrs = append(allErrs, resource.Required(backendPath.Child("serviceName"), ""))
+			} else {
+				allErrs = append(allErrs, resource.ValidateDNS1123Label(path.Backend.ServiceName, backendPath.Child("serviceName"))...)
+			}
+			allErrs = append(allErrs, resource.ValidatePort(path.Backend.ServicePort, backendPath.Child("servicePort"))...)
+		}
+	}
+	for i, tls := range r.Spec.Tls {
+		tlsPath := specPath.Child("tls").Index(i)
+		for j, host := range tls.Hosts {
+			allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(strings.TrimPrefix(host, "*."), tlsPath.Child("hosts").Index(j))...)
+		}
+		if tls.SecretName != "" {
+			allErrs = append(allErrs, resource.ValidateDNS1123Subdomain(tls.SecretName, tlsPath.Child("secretName"))...)
+		}
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResIngress) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
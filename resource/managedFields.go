@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 字段管理记录，表示某个管理者通过某种操作拥有的字段
+type ManagedFieldsEntry struct {
+	ApiVersion string `yaml:"apiVersion"`
+	Fields     Fields `yaml:"fields,omitempty"`     // 1.17之前的格式
+	FieldsType string `yaml:"fieldsType,omitempty"` // FieldsV1
+	FieldsV1   Fields `yaml:"fieldsV1,omitempty"`
+	Manager    string
+	Operation  string // Apply、Update
+	Time       Time
+}
+
+// 拥有的字段集合，键的格式：
+// f:<name> 字段；k:<json> 按合并键匹配的列表元素；v:<json> 基本类型列表中的值；i:<index> 列表下标；. 元素本身
+type Fields struct {
+	Map map[string]Fields
+}
+
+func (f *Fields) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	m := map[string]Fields{}
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+	f.Map = m
+	return nil
+}
+
+func (f Fields) MarshalYAML() (interface{}, error) {
+	if f.Map == nil {
+		return map[string]Fields{}, nil
+	}
+	return f.Map, nil
+}
+
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	m := map[string]Fields{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	f.Map = m
+	return nil
+}
+
+func (f Fields) MarshalJSON() ([]byte, error) {
+	if f.Map == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(f.Map)
+}
+
+// 管理者拥有的字段路径
+func (e ManagedFieldsEntry) OwnedFields() []string {
+	if len(e.FieldsV1.Map) != 0 {
+		return e.FieldsV1.Paths()
+	}
+	return e.Fields.Paths()
+}
+
+// 展开为字段路径，如 spec.replicas、spec.template.spec.containers[name=nginx].image
+func (f Fields) Paths() []string {
+	paths := []string{}
+	f.collect("", &paths)
+	sort.Strings(paths)
+	return paths
+}
+
+func (f Fields) collect(prefix string, paths *[]string) {
+	for key, child := range f.Map {
+		if key == "." {
+			*paths = append(*paths, prefix)
+			continue
+		}
+		path := joinFieldKey(prefix, key)
+		if len(child.Map) == 0 {
+			*paths = append(*paths, path)
+			continue
+		}
+		child.collect(path, paths)
+	}
+}
+
+func joinFieldKey(prefix, key string) string {
+	if len(key) < 2 || key[1] != ':' {
+		return prefix + "." + key
+	}
+	value := key[2:]
+	switch key[0] {
+	case 'f':
+		if prefix == "" {
+			return value
+		}
+		return prefix + "." + value
+	case 'k':
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(value), &m); err != nil {
+			return prefix + "[" + value + "]"
+		}
+		pairs := make([]string, 0, len(m))
+		for k, v := range m {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(pairs)
+		return prefix + "[" + strings.Join(pairs, ",") + "]"
+	case 'v':
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return prefix + "[" + value + "]"
+		}
+		return prefix + "[" + fmt.Sprint(v) + "]"
+	case 'i':
+		return prefix + "[" + value + "]"
+	}
+	return prefix + "." + key
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"k8s-client-go/resource"
+	"net"
 	"gopkg.in/yaml.v2"
 )
 
@@ -186,6 +187,87 @@ func (r *ResNetworkPolicy) AddEgress(eg *Egress) error {
 	return nil
 }
 
+func (r *ResNetworkPolicy) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, resource.Required(specPath, "")).ToError()
+	}
+	if r.Spec.PodSelector != nil {
+		allErrs = append(allErrs, resource.ValidateLabels(r.Spec.PodSelector.MatchLabels, specPath.Child("podSelector", "matchLabels"))...)
+	}
+	for i, t := range r.Spec.PolicyTypes {
+		allErrs = append(allErrs, resource.ValidateEnum(t, []string{POLICY_TYPE_INGRESS, POLICY_TYPE_EGRESS}, specPath.Child("policyTypes").Index(i))...)
+	}
+	for i, ing := range r.Spec.Ingress {
+		ingPath := specPath.Child("ingress").Index(i)
+		if ing == nil {
+			continue
+		}
+		allErrs = append(allErrs, validateNetPolicyPorts(ing.Ports, ingPath.Child("ports"))...)
+		allErrs = append(allErrs, validateIpBlock(ing.From.IpBlock, ingPath.Child("from", "ipBlock"))...)
+		if ing.From.PodSelector != nil {
+			allErrs = append(allErrs, resource.ValidateLabels(ing.From.PodSelector.MatchLabels, ingPath.Child("from", "podSelector", "matchLabels"))...)
+		}
+		if ing.From.NamespaceSelector != nil {
+			allErrs = append(allErrs, resource.ValidateLabels(ing.From.NamespaceSelector.MatchLabels, ingPath.Child("from", "namespaceSelector", "matchLabels"))...)
+		}
+	}
+	for i, eg := range r.Spec.Egress {
+		egPath := specPath.Child("egress").Index(i)
+		if eg == nil {
+			continue
+		}
+		allErrs = append(allErrs, validateNetPolicyPorts(eg.Ports, egPath.Child("ports"))...)
+		allErrs = append(allErrs, validateIpBlock(eg.To.IpBlock, egPath.Child("to", "ipBlock"))...)
+	}
+	return allErrs.ToError()
+}
+
+func validateNetPolicyPorts(ports []*NetPolicyPort, field *resource.FieldPath) resource.ErrorList {
+	allErrs := resource.ErrorList{}
+	for i, port := range ports {
+		if port == nil {
+			continue
+		}
+		allErrs = append(allErrs, resource.ValidateEnum(port.Protocol, resource.Protocols, field.Index(i).Child("protocol"))...)
+		if port.Port != 0 {
+			allErrs = append(allErrs, resource.ValidatePort(port.Port, field.Index(i).Child("port"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateIpBlock(block *IpBlock, field *resource.FieldPath) resource.ErrorList {
+	allErrs := resource.ErrorList{}
+	if block == nil {
+		return allErrs
+	}
+	if block.Cidr == "" {
+		return append(allErrs, resource.Required(field.Child("cidr"), ""))
+	}
+	_, cidr, err := net.ParseCIDR(block.Cidr)
+	if err != nil {
+		return append(allErrs, resource.Invalid(field.Child("cidr"), block.Cidr, err.Error()))
+	}
+	for i, except := range block.Except {
+		_, exceptCidr, err := net.ParseCIDR(except)
+		if err != nil {
+			allErrs = append(allErrs, resource.Invalid(field.Child("except").Index(i), except, err.Error()))
+			continue
+		}
+		// except 必须在 cidr 范围内
+		cidrMaskLen, _ := cidr.Mask.Size()
+		exceptMaskLen, _ := exceptCidr.Mask.Size()
+		if !cidr.Contains(exceptCidr.IP) || cidrMaskLen >= exceptMaskLen {
+			allErrs = append(allErrs, resource.Invalid(field.Child("except").Index(i), except, "must be a strict subset of `cidr`"))
+		}
+	}
+	return allErrs
+}
+
 func (r *ResNetworkPolicy) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
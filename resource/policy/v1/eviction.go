@@ -0,0 +1,21 @@
+package v1
+
+import "k8s-client-go/resource"
+
+// 提交到pod的eviction子资源，服务端检查PodDisruptionBudget后删除pod
+type Eviction struct {
+	ApiVersion string `yaml:"apiVersion"`
+	Kind       string
+	Metadata   struct {
+		Name      string
+		Namespace string
+	}
+	DeleteOptions *resource.DeleteOptions `yaml:"deleteOptions,omitempty"`
+}
+
+func NewEviction(namespace, name string, opts *resource.DeleteOptions) *Eviction {
+	eviction := &Eviction{ApiVersion: "policy/v1", Kind: "Eviction", DeleteOptions: opts}
+	eviction.Metadata.Name = name
+	eviction.Metadata.Namespace = namespace
+	return eviction
+}
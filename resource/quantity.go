@@ -1,5 +1,15 @@
 package resource
 
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// 资源数量，如 cpu: 500m、memory: 1Gi
 type Quantity struct {
 	i int64Amount
 	d infDecAmount
@@ -9,3 +19,356 @@ type Quantity struct {
 }
 
 type Format string
+
+const (
+	DecimalExponent = Format("DecimalExponent") // 1e3
+	BinarySI        = Format("BinarySI")        // 1Ki
+	DecimalSI       = Format("DecimalSI")       // 1k
+)
+
+var (
+	ErrFormatWrong = errors.New("quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'")
+	ErrNumeric     = errors.New("unable to parse numeric part of quantity")
+	ErrSuffix      = errors.New("unable to parse quantity's suffix")
+)
+
+// 二进制后缀对应2的幂
+var binarySuffixes = map[string]int64{
+	"Ki": 10, "Mi": 20, "Gi": 30, "Ti": 40, "Pi": 50, "Ei": 60,
+}
+
+// 十进制后缀对应10的幂
+var decimalSuffixes = map[string]Scale{
+	"n": Nano, "u": Micro, "m": Milli, "": 0, "k": Kilo, "M": Mega, "G": Giga, "T": Tera, "P": Peta, "E": Exa,
+}
+
+func NewQuantity(value int64, format Format) *Quantity {
+	return &Quantity{i: int64Amount{value: value}, Format: format}
+}
+
+func NewMilliQuantity(value int64, format Format) *Quantity {
+	return &Quantity{i: int64Amount{value: value, scale: Milli}, Format: format}
+}
+
+func NewScaledQuantity(value int64, scale Scale) *Quantity {
+	return &Quantity{i: int64Amount{value: value, scale: scale}, Format: DecimalSI}
+}
+
+// 解析失败时panic，仅用于常量
+func MustParse(str string) Quantity {
+	q, err := ParseQuantity(str)
+	if err != nil {
+		panic("cannot parse '" + str + "': " + err.Error())
+	}
+	return q
+}
+
+func ParseQuantity(str string) (Quantity, error) {
+	if len(str) == 0 {
+		return Quantity{}, ErrFormatWrong
+	}
+	if str == "0" {
+		return Quantity{Format: DecimalSI, s: str}, nil
+	}
+
+	positive, whole, frac, suffix, err := parseQuantityString(str)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	amount := &Dec{scale: Scale(-len(frac))}
+	if _, ok := amount.unscaled.SetString(whole+frac, 10); !ok {
+		return Quantity{}, ErrNumeric
+	}
+	if !positive {
+		amount.unscaled.Neg(&amount.unscaled)
+	}
+
+	var format Format
+	if exp, ok := binarySuffixes[suffix]; ok {
+		format = BinarySI
+		amount.unscaled.Mul(&amount.unscaled, new(big.Int).Lsh(big.NewInt(1), uint(exp)))
+	} else if scale, ok := decimalSuffixes[suffix]; ok {
+		format = DecimalSI
+		amount.scale += scale
+	} else if len(suffix) > 1 && (suffix[0] == 'e' || suffix[0] == 'E') {
+		exp, err := strconv.ParseInt(suffix[1:], 10, 32)
+		if err != nil {
+			return Quantity{}, ErrSuffix
+		}
+		format = DecimalExponent
+		amount.scale += Scale(exp)
+	} else {
+		return Quantity{}, ErrSuffix
+	}
+
+	// 最小精度为 1n，向上取整
+	if amount.scale < Nano {
+		amount, _ = amount.round(Nano, false)
+	}
+
+	q := Quantity{Format: format}
+	q.setDec(amount)
+	return q, nil
+}
+
+// 拆分为符号、整数部分、小数部分和后缀
+func parseQuantityString(str string) (positive bool, whole, frac, suffix string, err error) {
+	positive = true
+	pos := 0
+	end := len(str)
+
+	switch str[0] {
+	case '-':
+		positive = false
+		pos++
+	case '+':
+		pos++
+	}
+
+	start := pos
+	for ; pos < end && str[pos] >= '0' && str[pos] <= '9'; pos++ {
+	}
+	whole = str[start:pos]
+
+	if pos < end && str[pos] == '.' {
+		pos++
+		start = pos
+		for ; pos < end && str[pos] >= '0' && str[pos] <= '9'; pos++ {
+		}
+		frac = str[start:pos]
+	}
+	if len(whole) == 0 && len(frac) == 0 {
+		return false, "", "", "", ErrFormatWrong
+	}
+
+	suffix = str[pos:]
+	for i, c := range suffix {
+		isLetter := strings.ContainsRune("eEinumkKMGTP", c)
+		isExp := c >= '0' && c <= '9' || (c == '-' || c == '+') && i > 0
+		if !isLetter && !isExp {
+			return false, "", "", "", ErrFormatWrong
+		}
+	}
+	return positive, whole, frac, suffix, nil
+}
+
+func (q *Quantity) isInfDec() bool {
+	return q.d.Dec != nil
+}
+
+func (q *Quantity) AsDec() *Dec {
+	if q.isInfDec() {
+		return q.d.Dec.Copy()
+	}
+	return q.i.AsDec()
+}
+
+// 保存数值，能用int64表示时不使用大数
+func (q *Quantity) setDec(d *Dec) {
+	n := d.normalize()
+	q.s = ""
+	if n.unscaled.IsInt64() {
+		q.i = int64Amount{value: n.unscaled.Int64(), scale: n.scale}
+		q.d.Dec = nil
+		return
+	}
+	q.i = int64Amount{}
+	q.d.Dec = n
+}
+
+func (q *Quantity) IsZero() bool {
+	if q.isInfDec() {
+		return q.d.Dec.Sign() == 0
+	}
+	return q.i.value == 0
+}
+
+func (q *Quantity) Sign() int {
+	if q.isInfDec() {
+		return q.d.Dec.Sign()
+	}
+	return q.i.Sign()
+}
+
+func (q *Quantity) Add(y Quantity) {
+	if q.Format == "" {
+		q.Format = y.Format
+	}
+	q.setDec(new(Dec).Add(q.AsDec(), y.AsDec()))
+}
+
+func (q *Quantity) Sub(y Quantity) {
+	if q.Format == "" {
+		q.Format = y.Format
+	}
+	q.setDec(new(Dec).Sub(q.AsDec(), y.AsDec()))
+}
+
+func (q *Quantity) Neg() {
+	d := q.AsDec()
+	d.unscaled.Neg(&d.unscaled)
+	q.setDec(d)
+}
+
+// 返回 -1、0、1，分别表示小于、等于、大于y
+func (q *Quantity) Cmp(y Quantity) int {
+	return q.AsDec().Cmp(y.AsDec())
+}
+
+func (q *Quantity) CmpInt64(y int64) int {
+	return q.AsDec().Cmp(NewDec(y, 0))
+}
+
+// 返回 ceil(q / 10^scale)，超出int64范围时取边界值
+func (q *Quantity) ScaledValue(scale Scale) int64 {
+	d, _ := q.AsDec().round(scale, true)
+	v := &d.rescale(scale).unscaled
+	if !v.IsInt64() {
+		if v.Sign() > 0 {
+			return math.MaxInt64
+		}
+		return math.MinInt64
+	}
+	return v.Int64()
+}
+
+// 返回 ceil(q)
+func (q *Quantity) Value() int64 {
+	return q.ScaledValue(0)
+}
+
+// 返回 ceil(q * 1000)
+func (q *Quantity) MilliValue() int64 {
+	return q.ScaledValue(Milli)
+}
+
+func (q *Quantity) Set(value int64) {
+	q.setDec(NewDec(value, 0))
+}
+
+func (q *Quantity) SetMilli(value int64) {
+	q.setDec(NewDec(value, Milli))
+}
+
+func (q Quantity) DeepCopy() Quantity {
+	if q.isInfDec() {
+		q.d.Dec = q.d.Dec.Copy()
+	}
+	return q
+}
+
+func (q *Quantity) Copy() *Quantity {
+	c := q.DeepCopy()
+	return &c
+}
+
+// 规范化输出，如 1500m、1536Mi、1e3
+func (q *Quantity) String() string {
+	if q == nil {
+		return "<nil>"
+	}
+	if len(q.s) == 0 {
+		q.s = q.canonical()
+	}
+	return q.s
+}
+
+func (q *Quantity) canonical() string {
+	if q.IsZero() {
+		return "0"
+	}
+	d := q.AsDec().normalize()
+
+	format := q.Format
+	switch format {
+	case DecimalExponent, DecimalSI:
+	case BinarySI:
+		// 小于1024或有小数时二进制后缀无法精确表示
+		if q.CmpInt64(-1024) > 0 && q.CmpInt64(1024) < 0 || d.scale < 0 {
+			format = DecimalSI
+		}
+	default:
+		format = DecimalSI
+	}
+
+	if format == BinarySI {
+		value := new(big.Int).Set(&d.rescale(0).unscaled)
+		suffixes := []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+		exp := 0
+		quo, rem := new(big.Int), new(big.Int)
+		mod := big.NewInt(1024)
+		for exp < len(suffixes)-1 {
+			quo.QuoRem(value, mod, rem)
+			if rem.Sign() != 0 {
+				break
+			}
+			value.Set(quo)
+			exp++
+		}
+		return value.String() + suffixes[exp]
+	}
+
+	// 指数取3的倍数，保证尾数为整数
+	exp := d.scale - ((d.scale%3)+3)%3
+	if format == DecimalSI && exp > Exa {
+		exp = Exa
+	}
+	mantissa := new(big.Int).Mul(&d.unscaled, pow10(int64(d.scale-exp)))
+	if format == DecimalExponent {
+		if exp == 0 {
+			return mantissa.String()
+		}
+		return mantissa.String() + "e" + strconv.Itoa(int(exp))
+	}
+	for suffix, scale := range decimalSuffixes {
+		if scale == exp {
+			return mantissa.String() + suffix
+		}
+	}
+	return mantissa.String()
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.String())
+}
+
+func (q *Quantity) UnmarshalJSON(value []byte) error {
+	str := string(value)
+	if str == "null" {
+		*q = Quantity{}
+		return nil
+	}
+	if len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"' {
+		if err := json.Unmarshal(value, &str); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseQuantity(strings.TrimSpace(str))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+func (q Quantity) MarshalYAML() (interface{}, error) {
+	return q.String(), nil
+}
+
+func (q *Quantity) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*q = Quantity{}
+		return nil
+	}
+	parsed, err := ParseQuantity(strings.TrimSpace(str))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
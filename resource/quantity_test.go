@@ -0,0 +1,118 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseQuantity(t *testing.T) {
+	cases := map[string]string{
+		"500m":         "500m",
+		"0.5":          "500m",
+		"1.5":          "1500m",
+		"1000m":        "1",
+		"1k":           "1k",
+		"1000":         "1k",
+		"1Ki":          "1Ki",
+		"1024":         "1024",
+		"1536Mi":       "1536Mi",
+		"1.5Gi":        "1536Mi",
+		"0.5Ki":        "512",
+		"1e3":          "1e3",
+		"1E6":          "1e6",
+		"12e-3":        "12e-3",
+		"100M":         "100M",
+		"-2Gi":         "-2Gi",
+		"+1":           "1",
+		"0":            "0",
+		"0.0000000001": "1n",
+		"1E":           "1E",
+		"1000E":        "1000E",
+	}
+	for in, want := range cases {
+		q, err := ParseQuantity(in)
+		if err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if got := q.String(); got != want {
+			t.Errorf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3", "1Zi", "1e", "--1", "1 Gi", "."} {
+		if _, err := ParseQuantity(in); err == nil {
+			t.Errorf("%s: expected error", in)
+		}
+	}
+}
+
+func TestQuantity_Arithmetic(t *testing.T) {
+	sum := MustParse("500m")
+	sum.Add(MustParse("1.5"))
+	sum.Add(MustParse("250m"))
+	if sum.String() != "2250m" || sum.MilliValue() != 2250 || sum.Value() != 3 {
+		t.Fatalf("unexpected sum %s", sum.String())
+	}
+
+	mem := MustParse("1Gi")
+	mem.Sub(MustParse("512Mi"))
+	if mem.String() != "512Mi" || mem.Value() != 512*1024*1024 {
+		t.Fatalf("unexpected difference %s", mem.String())
+	}
+
+	if c := MustParse("1"); c.Cmp(MustParse("1000m")) != 0 {
+		t.Fatal("1 should equal 1000m")
+	}
+	if c := MustParse("1Ki"); c.Cmp(MustParse("1k")) <= 0 {
+		t.Fatal("1Ki should be greater than 1k")
+	}
+
+	// 超出int64的数值
+	big := MustParse("8E")
+	big.Add(MustParse("8E"))
+	if big.String() != "16E" || big.Cmp(MustParse("8E")) <= 0 {
+		t.Fatalf("unexpected big sum %s", big.String())
+	}
+
+	var zero Quantity
+	zero.Add(MustParse("100Mi"))
+	if zero.String() != "100Mi" {
+		t.Fatalf("unexpected %s", zero.String())
+	}
+}
+
+func TestQuantity_Marshal(t *testing.T) {
+	res := ContainerResources{
+		Limits:   Limits{Cpu: MustParse("1"), Memory: MustParse("1Gi")},
+		Requests: Request{Cpu: MustParse("0.25")},
+	}
+	data, err := yaml.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := ContainerResources{}
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Requests.Cpu.String() != "250m" || decoded.Limits.Memory.String() != "1Gi" || !decoded.Requests.Memory.IsZero() {
+		t.Fatalf("unexpected yaml round trip %s", string(data))
+	}
+
+	data, err = json.Marshal(res.Limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"cpu":"1","memory":"1Gi"}` {
+		t.Fatalf("unexpected json %s", string(data))
+	}
+	limits := Limits{}
+	if err := json.Unmarshal([]byte(`{"cpu":2,"memory":"64Mi"}`), &limits); err != nil {
+		t.Fatal(err)
+	}
+	if limits.Cpu.MilliValue() != 2000 || limits.Memory.String() != "64Mi" {
+		t.Fatalf("unexpected limits %+v", limits)
+	}
+}
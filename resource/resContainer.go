@@ -22,27 +22,27 @@ type IContainer interface {
 
 // 容器结构体
 type Container struct {
-	Args                     []string
-	Command                  []string
-	Env                      []Env
-	EnvFrom                  []EnvFromSource `yaml:"envFrom"`
+	Args                     []string        `yaml:",omitempty"`
+	Command                  []string        `yaml:",omitempty"`
+	Env                      []Env           `yaml:",omitempty"`
+	EnvFrom                  []EnvFromSource `yaml:"envFrom,omitempty"`
 	Name                     string
 	Image                    string
-	ImagePullPolicy          string `yaml:"imagePullPolicy"` // [Always | Never | IfNotPresent]
-	Lifecycle                Lifecycle
-	WorkingDir               string        `yaml:"workingDir"`   // 当前工作目录
-	VolumeMounts             []VolumeMount `yaml:"volumeMounts"` // 挂载卷
+	ImagePullPolicy          string        `yaml:"imagePullPolicy,omitempty"` // [Always | Never | IfNotPresent]
+	Lifecycle                Lifecycle     `yaml:",omitempty"`
+	WorkingDir               string        `yaml:"workingDir,omitempty"`   // 当前工作目录
+	VolumeMounts             []VolumeMount `yaml:"volumeMounts,omitempty"` // 挂载卷
 	Resources                ContainerResources
-	Ports                    []ContainerPort // 端口号
-	LivenessProbe            Probe           `yaml:"livenessProbe"`
-	ReadinessProbe           Probe           `yaml:"readinessProbe"`
-	Stdin                    bool
-	StdinOnce                bool   `yaml:"stdinOnce"`
-	TerminationMessagePath   string `yaml:"terminationMessagePath"`
-	TerminationMessagePolicy string `yaml:"terminationMessagePolicy"`
-	Tty                      bool
-	SecurityContext          SecurityContext `yaml:"securityContext"`
-	VolumeDevices            []VolumeDevice  `yaml:"volumeDevices"`
+	Ports                    []ContainerPort `yaml:",omitempty"` // 端口号
+	LivenessProbe            Probe           `yaml:"livenessProbe,omitempty"`
+	ReadinessProbe           Probe           `yaml:"readinessProbe,omitempty"`
+	Stdin                    bool            `yaml:",omitempty"`
+	StdinOnce                bool            `yaml:"stdinOnce,omitempty"`
+	TerminationMessagePath   string          `yaml:"terminationMessagePath,omitempty"`
+	TerminationMessagePolicy string          `yaml:"terminationMessagePolicy,omitempty"`
+	Tty                      bool            `yaml:",omitempty"`
+	SecurityContext          SecurityContext `yaml:"securityContext,omitempty"`
+	VolumeDevices            []VolumeDevice  `yaml:"volumeDevices,omitempty"`
 }
 
 func NewContainer(name string, image string) *Container {
@@ -80,10 +80,10 @@ func (r *Container) SetVolumeMount(vols []VolumeMount) error {
 
 type VolumeMount struct {
 	MountPath        string `yaml:"mountPath"`
-	MountPropagation string `yaml:"mountPropagation"`
+	MountPropagation string `yaml:"mountPropagation,omitempty"`
 	Name             string
-	ReadOnly         bool   `yaml:"readOnly"`
-	SubPath          string `yaml:"subPath"`
+	ReadOnly         bool   `yaml:"readOnly,omitempty"`
+	SubPath          string `yaml:"subPath,omitempty"`
 }
 
 type VolumeDevice struct {
@@ -92,48 +92,48 @@ type VolumeDevice struct {
 }
 
 type SecurityContext struct {
-	Privileged               bool   // true-容器运行在特权模式
-	AllowPrivilegeEscalation bool   `yaml:"allowPrivilegeEscalation"`
-	ProcMount                string `yaml:"procMount"`
-	Capabilities             Capabilities
-	ReadOnlyRootFilesystem   bool           `yaml:"readOnlyRootFilesystem"`
-	RunAsGroup               int            `yaml:"runAsGroup"`
-	RunAsNonRoot             bool           `yaml:"runAsNonRoot"`
-	RunAsUser                int            `yaml:"runAsUser"`
-	SeLinuxOptions           SELinuxOptions `yaml:"seLinuxOptions"`
+	Privileged               bool           `yaml:",omitempty"` // true-容器运行在特权模式
+	AllowPrivilegeEscalation bool           `yaml:"allowPrivilegeEscalation,omitempty"`
+	ProcMount                string         `yaml:"procMount,omitempty"`
+	Capabilities             Capabilities   `yaml:",omitempty"`
+	ReadOnlyRootFilesystem   bool           `yaml:"readOnlyRootFilesystem,omitempty"`
+	RunAsGroup               int            `yaml:"runAsGroup,omitempty"`
+	RunAsNonRoot             bool           `yaml:"runAsNonRoot,omitempty"`
+	RunAsUser                int            `yaml:"runAsUser,omitempty"`
+	SeLinuxOptions           SELinuxOptions `yaml:"seLinuxOptions,omitempty"`
 }
 
 type Capabilities struct {
-	Add  []string
-	Drop []string
+	Add  []string `yaml:",omitempty"`
+	Drop []string `yaml:",omitempty"`
 }
 
 type SELinuxOptions struct {
-	Level string
-	Role  string
-	Type  string
-	User  string
+	Level string `yaml:",omitempty"`
+	Role  string `yaml:",omitempty"`
+	Type  string `yaml:",omitempty"`
+	User  string `yaml:",omitempty"`
 }
 
 type Lifecycle struct {
-	PostStart Handler `yaml:"postStart"`
-	PreStop   Handler `yaml:"preStop"`
+	PostStart Handler `yaml:"postStart,omitempty"`
+	PreStop   Handler `yaml:"preStop,omitempty"`
 }
 
 type Handler struct {
-	Exec      ExecAction      `yaml:"exec"`
-	HttpGet   HttpGetAction   `yaml:"httpGet"`
-	TcpSocket TcpSocketAction `yaml:"tcpSocket"`
+	Exec      ExecAction      `yaml:"exec,omitempty"`
+	HttpGet   HttpGetAction   `yaml:"httpGet,omitempty"`
+	TcpSocket TcpSocketAction `yaml:"tcpSocket,omitempty"`
 }
 
 type Env struct {
 	Name      string
-	ValueFrom *ValueFrom `yaml:"valueFrom"`
+	ValueFrom *ValueFrom `yaml:"valueFrom,omitempty"`
 }
 
 type ValueFrom struct {
-	FieldRef         *FieldRef         `yaml:"fieldRef"`
-	ResourceFieldRef *ResourceFieldRef `yaml:"resourceFieldRef"`
+	FieldRef         *FieldRef         `yaml:"fieldRef,omitempty"`
+	ResourceFieldRef *ResourceFieldRef `yaml:"resourceFieldRef,omitempty"`
 }
 
 type FieldRef struct {
@@ -149,19 +149,19 @@ type ValueFromHandler interface {
 }
 
 type EnvFromSource struct {
-	ConfigMapRef ConfigMapEnvSource `yaml:"configMapRef"`
-	Prefix       string
-	SecretRef    SecretEnvSource `yaml:"secretRef"`
+	ConfigMapRef ConfigMapEnvSource `yaml:"configMapRef,omitempty"`
+	Prefix       string             `yaml:",omitempty"`
+	SecretRef    SecretEnvSource    `yaml:"secretRef,omitempty"`
 }
 
 type ConfigMapEnvSource struct {
 	Name     string
-	Optional bool
+	Optional bool `yaml:",omitempty"`
 }
 
 type SecretEnvSource struct {
 	Name     string
-	Optional bool
+	Optional bool `yaml:",omitempty"`
 }
 
 func (r *Container) SetEnv(env Env) error {
@@ -291,7 +291,7 @@ func (r *Container) SetReadinessProbe(readiness ReadinessProbe) error {
 }
 
 func (r *Container) SetResource(res ContainerResources) error {
-	if res.Requests.Cpu == "" || res.Requests.Memory == "" {
+	if res.Requests.Cpu.IsZero() || res.Requests.Memory.IsZero() {
 		return errors.New("request cpu or memory is empty")
 	}
 	r.Resources = res
@@ -299,9 +299,9 @@ func (r *Container) SetResource(res ContainerResources) error {
 }
 
 type ProbeAction struct {
-	Exec      *ExecAction      `yaml:"exec"`
-	HttpGet   *HttpGetAction   `yaml:"httpGet"`
-	TcpSocket *TcpSocketAction `yaml:"tcpSocket"`
+	Exec      *ExecAction      `yaml:"exec,omitempty"`
+	HttpGet   *HttpGetAction   `yaml:"httpGet,omitempty"`
+	TcpSocket *TcpSocketAction `yaml:"tcpSocket,omitempty"`
 }
 
 type TcpSocketAction struct {
@@ -313,20 +313,20 @@ type ExecAction struct {
 }
 
 type HttpGetAction struct {
-	Path        string
+	Path        string `yaml:",omitempty"`
 	Port        string
-	Host        string
-	Scheme      string
-	HttpHeaders []map[string]string `yaml:"httpHeaders"`
+	Host        string              `yaml:",omitempty"`
+	Scheme      string              `yaml:",omitempty"`
+	HttpHeaders []map[string]string `yaml:"httpHeaders,omitempty"`
 }
 
 type Probe struct {
 	ProbeAction         `yaml:",inline"`
-	InitialDelaySeconds int `yaml:"initialDelaySeconds"`
-	TimeoutSeconds      int `yaml:"timeoutSeconds"`
-	PeriodSeconds       int `yaml:"periodSeconds"`
-	SuccessThreshold    int `yaml:"successThreshold"`
-	FailureThreshold    int `yaml:"failureThreshold"`
+	InitialDelaySeconds int `yaml:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds      int `yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int `yaml:"periodSeconds,omitempty"`
+	SuccessThreshold    int `yaml:"successThreshold,omitempty"`
+	FailureThreshold    int `yaml:"failureThreshold,omitempty"`
 }
 
 type ReadinessProbe struct {
@@ -347,8 +347,8 @@ type Secret struct {
 
 func NewResource() *ContainerResources {
 	return &ContainerResources{
-		Limits:   Limits{Cpu: "", Memory: ""},
-		Requests: Request{Cpu: "", Memory: ""},
+		Limits:   Limits{Cpu: Quantity{}, Memory: Quantity{}},
+		Requests: Request{Cpu: Quantity{}, Memory: Quantity{}},
 	}
 }
 
@@ -365,11 +365,11 @@ type Port struct {
 }
 
 type ContainerPort struct {
-	Name          string
+	Name          string `yaml:",omitempty"`
 	ContainerPort int    `yaml:"containerPort"`
-	HostPort      int    `yaml:"hostPort"`
-	Protocol      string // 仅支持 TCP UDP
-	HostIP        string `yaml:"hostIP"`
+	HostPort      int    `yaml:"hostPort,omitempty"`
+	Protocol      string `yaml:",omitempty"` // 仅支持 TCP UDP
+	HostIP        string `yaml:"hostIP,omitempty"`
 }
 
 func NewPort(name string) *Port {
@@ -380,3 +380,18 @@ func NewPort(name string) *Port {
 		Protocol:      "",
 	}
 }
+
+// 容器列表，解码时使用 *Container 作为具体类型
+type Containers []IContainer
+
+func (c *Containers) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var containers []*Container
+	if err := unmarshal(&containers); err != nil {
+		return err
+	}
+	*c = make(Containers, 0, len(containers))
+	for _, container := range containers {
+		*c = append(*c, container)
+	}
+	return nil
+}
@@ -1,5 +1,10 @@
 package resource
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type ContainerStatus struct {
 	ContainerID  string `yaml:"containerID"`
 	Image        string
@@ -21,7 +26,9 @@ type ContainerStateRunning struct {
 	StartedAt Time `yaml:"startedAt"`
 }
 
+// 时间，序列化为RFC3339格式，零值序列化为null
 type Time struct {
+	time.Time
 }
 
 type ContainerStateTerminated struct {
@@ -38,3 +45,58 @@ type ContainerStateWaiting struct {
 	Message string
 	Reason  string
 }
+
+func NewTime(t time.Time) Time {
+	return Time{t}
+}
+
+func Now() Time {
+	return Time{time.Now()}
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339))
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return t.parse(str)
+}
+
+func (t Time) MarshalYAML() (interface{}, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	return t.parse(str)
+}
+
+func (t *Time) parse(str string) error {
+	if str == "" || str == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed.Local()
+	return nil
+}
@@ -0,0 +1,36 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTime_Marshal(t *testing.T) {
+	state := ContainerStateRunning{}
+	if err := yaml.Unmarshal([]byte("startedAt: 2019-08-01T10:20:30Z\n"), &state); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2019, 8, 1, 10, 20, 30, 0, time.UTC)
+	if !state.StartedAt.Equal(want) {
+		t.Fatalf("unexpected time %v", state.StartedAt)
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "startedAt: \"2019-08-01T10:20:30Z\"\n" {
+		t.Fatalf("unexpected yaml %q", string(data))
+	}
+
+	data, err = json.Marshal(struct{ T Time }{})
+	if err != nil || string(data) != `{"T":null}` {
+		t.Fatalf("unexpected json %s %v", string(data), err)
+	}
+	decoded := struct{ T Time }{}
+	if err := json.Unmarshal([]byte(`{"T":"2019-08-01T10:20:30Z"}`), &decoded); err != nil || !decoded.T.Equal(want) {
+		t.Fatalf("unexpected time %v %v", decoded.T, err)
+	}
+}
@@ -1,7 +1,9 @@
 package resource
 
 import (
+	"errors"
 	"kboard/exception"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -66,6 +68,71 @@ func (r *ResCustomResourceDefinition) SetMetadataName(name string) error {
 	return nil
 }
 
+func (r *ResCustomResourceDefinition) Validate() error {
+	allErrs := ValidateObjectMeta(r.Metadata.Name, "", false, nil, NewPath("metadata"))
+	specPath := NewPath("spec")
+	if r.Spec == nil {
+		return append(allErrs, Required(specPath, "")).ToError()
+	}
+	if r.Spec.Group == "" {
+		allErrs = append(allErrs, Required(specPath.Child("group"), ""))
+	} else {
+		allErrs = append(allErrs, ValidateDNS1123Subdomain(r.Spec.Group, specPath.Child("group"))...)
+		if len(strings.Split(r.Spec.Group, ".")) < 2 {
+			allErrs = append(allErrs, Invalid(specPath.Child("group"), r.Spec.Group, "should be a domain with at least one dot"))
+		}
+	}
+	if r.Spec.Scope == "" {
+		allErrs = append(allErrs, Required(specPath.Child("scope"), ""))
+	}
+	allErrs = append(allErrs, ValidateEnum(r.Spec.Scope, []string{"Namespaced", "Cluster"}, specPath.Child("scope"))...)
+
+	namesPath := specPath.Child("names")
+	if r.Spec.Names == nil {
+		allErrs = append(allErrs, Required(namesPath, ""))
+	} else {
+		if r.Spec.Names.Plural == "" {
+			allErrs = append(allErrs, Required(namesPath.Child("plural"), ""))
+		} else {
+			allErrs = append(allErrs, ValidateDNS1123Label(r.Spec.Names.Plural, namesPath.Child("plural"))...)
+			// name 必须为 <plural>.<group>
+			if r.Metadata.Name != r.Spec.Names.Plural+"."+r.Spec.Group {
+				allErrs = append(allErrs, Invalid(NewPath("metadata", "name"), r.Metadata.Name, "must be spec.names.plural+\".\"+spec.group"))
+			}
+		}
+		if r.Spec.Names.Singular != "" {
+			allErrs = append(allErrs, ValidateDNS1123Label(r.Spec.Names.Singular, namesPath.Child("singular"))...)
+		}
+		if r.Spec.Names.Kind == "" {
+			allErrs = append(allErrs, Required(namesPath.Child("kind"), ""))
+		}
+		for i, shortName := range r.Spec.Names.ShortNames {
+			allErrs = append(allErrs, ValidateDNS1123Label(shortName, namesPath.Child("shortNames").Index(i))...)
+		}
+	}
+
+	storage := 0
+	versions := map[string]bool{}
+	for i, version := range r.Spec.Version {
+		versionPath := specPath.Child("version").Index(i)
+		if version == nil {
+			continue
+		}
+		allErrs = append(allErrs, ValidateDNS1123Label(version.Name, versionPath.Child("name"))...)
+		if versions[version.Name] {
+			allErrs = append(allErrs, Duplicate(versionPath.Child("name"), version.Name))
+		}
+		versions[version.Name] = true
+		if version.Storage {
+			storage++
+		}
+	}
+	if len(r.Spec.Version) > 0 && storage != 1 {
+		allErrs = append(allErrs, Invalid(specPath.Child("version"), storage, "must have exactly one version marked as storage version"))
+	}
+	return allErrs.ToError()
+}
+
 func (r *ResCustomResourceDefinition) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -73,3 +140,23 @@ func (r *ResCustomResourceDefinition) ToYamlFile() ([]byte, error) {
 	}
 	return yamlData, nil
 }
+
+// 自定义资源实例的组、版本和资源名，版本优先使用存储版本
+func (r *ResCustomResourceDefinition) GroupVersionResource() (GroupVersionResource, error) {
+	if r.Spec == nil || r.Spec.Names == nil || r.Spec.Group == "" || r.Spec.Names.Plural == "" {
+		return GroupVersionResource{}, errors.New("spec.group and spec.names.plural must be set")
+	}
+	version := ""
+	for _, v := range r.Spec.Version {
+		if v == nil || !v.Served {
+			continue
+		}
+		if version == "" || v.Storage {
+			version = v.Name
+		}
+	}
+	if version == "" {
+		return GroupVersionResource{}, errors.New("no served version")
+	}
+	return GroupVersionResource{Group: r.Spec.Group, Version: version, Resource: r.Spec.Names.Plural}, nil
+}
@@ -1,5 +1,11 @@
 package resource
 
+import (
+	"fmt"
+
+	"k8s-client-go/labels"
+)
+
 type Selector struct {
 	MatchLabels      map[string]string   `yaml:"matchLabels"`
 	MatchExpressions []*MatchExpressions `yaml:"matchExpressions"` // 匹配正则表达式，如：{key: tier, operator: In, values: [frontend]}
@@ -10,3 +16,133 @@ type MatchExpressions struct {
 	Operator string // In
 	Values   []string
 }
+
+// 结构体中的操作符与选择器操作符的对应关系
+var selectorOperators = map[string]labels.Operator{
+	"In":           labels.In,
+	"NotIn":        labels.NotIn,
+	"Exists":       labels.Exists,
+	"DoesNotExist": labels.DoesNotExist,
+}
+
+// 转换为可匹配的选择器，nil不匹配任何对象，空选择器匹配所有对象
+func (s *Selector) AsSelector() (labels.Selector, error) {
+	if s == nil {
+		return labels.Nothing(), nil
+	}
+	selector := labels.Everything()
+	for k, v := range s.MatchLabels {
+		r, err := labels.NewRequirement(k, labels.Equals, []string{v})
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*r)
+	}
+	for _, expr := range s.MatchExpressions {
+		if expr == nil {
+			continue
+		}
+		op, ok := selectorOperators[expr.Operator]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a valid label selector operator", expr.Operator)
+		}
+		r, err := labels.NewRequirement(expr.Key, op, expr.Values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*r)
+	}
+	return selector, nil
+}
+
+// 是否匹配标签
+func (s *Selector) Matches(set map[string]string) bool {
+	selector, err := s.AsSelector()
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(set))
+}
+
+// 字符串形式，如 app=web,tier in (a,b)
+func (s *Selector) String() string {
+	selector, err := s.AsSelector()
+	if err != nil {
+		return ""
+	}
+	return selector.String()
+}
+
+// 由选择器生成结构体，等值条件放入matchLabels
+func SelectorFromLabels(selector labels.Selector) (*Selector, error) {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return nil, fmt.Errorf("selector %T can not be converted", selector)
+	}
+	s := &Selector{}
+	for i := range requirements {
+		r := &requirements[i]
+		switch r.Operator() {
+		case labels.Equals, labels.DoubleEquals:
+			if s.MatchLabels == nil {
+				s.MatchLabels = map[string]string{}
+			}
+			s.MatchLabels[r.Key()] = r.Values()[0]
+		case labels.NotEquals, labels.NotIn:
+			s.MatchExpressions = append(s.MatchExpressions, &MatchExpressions{Key: r.Key(), Operator: "NotIn", Values: r.Values()})
+		case labels.In:
+			s.MatchExpressions = append(s.MatchExpressions, &MatchExpressions{Key: r.Key(), Operator: "In", Values: r.Values()})
+		case labels.Exists:
+			s.MatchExpressions = append(s.MatchExpressions, &MatchExpressions{Key: r.Key(), Operator: "Exists"})
+		case labels.DoesNotExist:
+			s.MatchExpressions = append(s.MatchExpressions, &MatchExpressions{Key: r.Key(), Operator: "DoesNotExist"})
+		default:
+			return nil, fmt.Errorf("%q is not a valid label selector operator", r.Operator())
+		}
+	}
+	return s, nil
+}
+
+// 解析字符串形式的选择器，如 app=web,tier in (a,b),!canary,env!=prod
+func ParseSelector(str string) (*Selector, error) {
+	selector, err := labels.Parse(str)
+	if err != nil {
+		return nil, err
+	}
+	return SelectorFromLabels(selector)
+}
+
+func (s *LabelSelector) ToSelector() *Selector {
+	if s == nil {
+		return nil
+	}
+	selector := &Selector{MatchLabels: s.MatchLabels}
+	for _, expr := range s.MatchExpressions {
+		selector.MatchExpressions = append(selector.MatchExpressions, &MatchExpressions{Key: expr.Key, Operator: expr.Operator, Values: expr.Values})
+	}
+	return selector
+}
+
+func (s *LabelSelector) AsSelector() (labels.Selector, error) {
+	return s.ToSelector().AsSelector()
+}
+
+func (s *LabelSelector) Matches(set map[string]string) bool {
+	return s.ToSelector().Matches(set)
+}
+
+func (s *LabelSelector) String() string {
+	return s.ToSelector().String()
+}
+
+func LabelSelectorFromLabels(selector labels.Selector) (*LabelSelector, error) {
+	s, err := SelectorFromLabels(selector)
+	if err != nil {
+		return nil, err
+	}
+	labelSelector := &LabelSelector{MatchLabels: s.MatchLabels}
+	for _, expr := range s.MatchExpressions {
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, LabelSelectorRequirement{Key: expr.Key, Operator: expr.Operator, Values: expr.Values})
+	}
+	return labelSelector, nil
+}
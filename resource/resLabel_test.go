@@ -0,0 +1,47 @@
+package resource
+
+import (
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	selector, err := ParseSelector("app=web,tier in (a,b),!canary,env!=prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selector.MatchLabels["app"] != "web" || len(selector.MatchExpressions) != 3 {
+		t.Fatalf("unexpected selector %+v", selector)
+	}
+	if got := selector.String(); got != "app=web,!canary,env notin (prod),tier in (a,b)" {
+		t.Fatalf("unexpected string %s", got)
+	}
+	if !selector.Matches(map[string]string{"app": "web", "tier": "a"}) {
+		t.Fatal("selector should match")
+	}
+	if selector.Matches(map[string]string{"app": "web", "tier": "a", "env": "prod"}) {
+		t.Fatal("selector should not match")
+	}
+}
+
+func TestSelector_AsSelector(t *testing.T) {
+	s := &Selector{MatchExpressions: []*MatchExpressions{{Key: "tier", Operator: "Like", Values: []string{"a"}}}}
+	if _, err := s.AsSelector(); err == nil {
+		t.Fatal("expected operator error")
+	}
+
+	var empty *Selector
+	if empty.Matches(map[string]string{"app": "web"}) {
+		t.Fatal("nil selector should match nothing")
+	}
+	if !(&Selector{}).Matches(map[string]string{"app": "web"}) {
+		t.Fatal("empty selector should match everything")
+	}
+
+	ls := &LabelSelector{
+		MatchLabels:      map[string]string{"app": "web"},
+		MatchExpressions: []LabelSelectorRequirement{{Key: "zone", Operator: "Exists"}},
+	}
+	if ls.String() != "app=web,zone" || !ls.Matches(map[string]string{"app": "web", "zone": "z1"}) {
+		t.Fatalf("unexpected label selector %s", ls.String())
+	}
+}
@@ -1,24 +1,25 @@
 package resource
 
 
+// 字段均为omitempty，未设置的字段不会出现在提交的对象中
 type ObjectMeta struct {
-	Annotations map[string]string
-	ClusterName string `yaml:"clusterName"`
-	CreationTimestamp Time `yaml:"creationTimestamp"`
-	DeletionGracePeriodSeconds int `yaml:"deletionGracePeriodSeconds"`
-	DeletionTimestamp Time `yaml:"deletionTimestamp"`
-	Finalizers []string
-	GenerateName string `yaml:"generateName"`
-	Generation int
-	Initializers Initializers
-	Labels map[string]string
-	ManagedFields []ManagedFieldsEntry `yaml:"managedFields"`
-	Name string
-	Namespace string
-	OwnerReferences []OwnerReference `yaml:"ownerReferences"`
-	ResourceVersion string `yaml:"resourceVersion"`
-	SelfLink string `yaml:"selfLink"`
-	Uid string
+	Annotations map[string]string `yaml:",omitempty"`
+	ClusterName string `yaml:"clusterName,omitempty"`
+	CreationTimestamp Time `yaml:"creationTimestamp,omitempty"`
+	DeletionGracePeriodSeconds int `yaml:"deletionGracePeriodSeconds,omitempty"`
+	DeletionTimestamp Time `yaml:"deletionTimestamp,omitempty"`
+	Finalizers []string `yaml:",omitempty"`
+	GenerateName string `yaml:"generateName,omitempty"`
+	Generation int `yaml:",omitempty"`
+	Initializers Initializers `yaml:",omitempty"`
+	Labels map[string]string `yaml:",omitempty"`
+	ManagedFields []ManagedFieldsEntry `yaml:"managedFields,omitempty"`
+	Name string `yaml:",omitempty"`
+	Namespace string `yaml:",omitempty"`
+	OwnerReferences []OwnerReference `yaml:"ownerReferences,omitempty"`
+	ResourceVersion string `yaml:"resourceVersion,omitempty"`
+	SelfLink string `yaml:"selfLink,omitempty"`
+	Uid string `yaml:",omitempty"`
 }
 
 type Initializers struct {
@@ -31,7 +32,7 @@ type Initializer struct {
 }
 
 type Status struct {
-	ApiVersion string
+	ApiVersion string `yaml:"apiVersion"`
 	Code int
 	Details StatusDetails
 	Kind string
@@ -43,6 +44,7 @@ type Status struct {
 
 type ListMeta struct {
 	Continue string
+	RemainingItemCount *int64 `yaml:"remainingItemCount,omitempty"`
 	ResourceVersion string `yaml:"resourceVersion"`
 	SelfLink string `yaml:"selfLink"`
 }
@@ -62,18 +64,6 @@ type StatusCause struct {
 	Reason string
 }
 
-type ManagedFieldsEntry struct {
-	ApiVersion string `yaml:"apiVersion"`
-	Fields Fields
-	Manager string
-	Operation string
-	Time Time
-}
-
-type Fields struct {
-
-}
-
 type OwnerReference struct {
 	ApiVersion string `yaml:"apiVersion"`
 	BlockOwnerDeletion bool `yaml:"blockOwnerDeletion"`
@@ -89,54 +79,54 @@ type PodTemplateSpec struct {
 }
 
 type PodSpec struct {
-	ActiveDeadlineSeconds         int `yaml:"activeDeadlineSeconds"`
-	Affinity                      Affinity
-	AutomountServiceAccountToken  bool `yaml:"automountServiceAccountToken"`
+	ActiveDeadlineSeconds         int      `yaml:"activeDeadlineSeconds,omitempty"`
+	Affinity                      Affinity `yaml:",omitempty"`
+	AutomountServiceAccountToken  bool     `yaml:"automountServiceAccountToken,omitempty"`
 	Containers                    []Container
-	DnsConfig                     PodDNSConfig `yaml:"podDNSConfig"`
-	DnsPolicy                     string       `yaml:"dnsPolicy"`
-	EnableServiceLinks            bool         `yaml:"enableServiceLinks"`
-	HostAliases                   []HostAlias  `yaml:"hostAlias"`
-	HostIPC                       bool         `yaml:"hostIPC"`
-	HostNetwork                   bool         `yaml:"hostNetwork"`
-	HostPID                       bool         `yaml:"hostPID"`
-	Hostname                      string
-	ImagePullSecrets              []LocalObjectReference `yaml:"imagePullSecrets"`
-	InitContainers                []Container            `yaml:"initContainers"`
-	NodeName                      string                 `yaml:"nodeName"`
-	NodeSelector                  struct{}               `yaml:"nodeSelector"`
-	Priority                      int
-	PriorityClassName             string             `yaml:"priorityClassName"`
-	ReadinessGates                []PodReadinessGate `yaml:"readinessGates"`
-	RestartPolicy                 string             `yaml:"restartPolicy"` // [Always | Never | OnFailure]
-	RuntimeClassName              string             `yaml:"runtimeClassName"`
-	SchedulerName                 string             `yaml:"schedulerName"`
-	SecurityContext               PodSecurityContext `yaml:"securityContext"`
-	ServiceAccount                string             `yaml:"serviceAccount"`
-	ServiceAccountName            string             `yaml:"serviceAccountName"`
-	ShareProcessNamespace         bool               `yaml:"shareProcessNamespace"`
-	Subdomain                     string
-	TerminationGracePeriodSeconds int `yaml:"terminationGracePeriodSeconds"`
-	Tolerations                   []Toleration
-	Volumes                       []Volume
+	DnsConfig                     PodDNSConfig           `yaml:"podDNSConfig,omitempty"`
+	DnsPolicy                     string                 `yaml:"dnsPolicy,omitempty"`
+	EnableServiceLinks            bool                   `yaml:"enableServiceLinks,omitempty"`
+	HostAliases                   []HostAlias            `yaml:"hostAlias,omitempty"`
+	HostIPC                       bool                   `yaml:"hostIPC,omitempty"`
+	HostNetwork                   bool                   `yaml:"hostNetwork,omitempty"`
+	HostPID                       bool                   `yaml:"hostPID,omitempty"`
+	Hostname                      string                 `yaml:",omitempty"`
+	ImagePullSecrets              []LocalObjectReference `yaml:"imagePullSecrets,omitempty"`
+	InitContainers                []Container            `yaml:"initContainers,omitempty"`
+	NodeName                      string                 `yaml:"nodeName,omitempty"`
+	NodeSelector                  struct{}               `yaml:"nodeSelector,omitempty"`
+	Priority                      int                    `yaml:",omitempty"`
+	PriorityClassName             string                 `yaml:"priorityClassName,omitempty"`
+	ReadinessGates                []PodReadinessGate     `yaml:"readinessGates,omitempty"`
+	RestartPolicy                 string                 `yaml:"restartPolicy,omitempty"` // [Always | Never | OnFailure]
+	RuntimeClassName              string                 `yaml:"runtimeClassName,omitempty"`
+	SchedulerName                 string                 `yaml:"schedulerName,omitempty"`
+	SecurityContext               PodSecurityContext     `yaml:"securityContext,omitempty"`
+	ServiceAccount                string                 `yaml:"serviceAccount,omitempty"`
+	ServiceAccountName            string                 `yaml:"serviceAccountName,omitempty"`
+	ShareProcessNamespace         bool                   `yaml:"shareProcessNamespace,omitempty"`
+	Subdomain                     string                 `yaml:",omitempty"`
+	TerminationGracePeriodSeconds int                    `yaml:"terminationGracePeriodSeconds,omitempty"`
+	Tolerations                   []Toleration           `yaml:",omitempty"`
+	Volumes                       []Volume               `yaml:",omitempty"`
 }
 
 type Toleration struct {
-	Effect            string
-	Key               string
-	Operator          string
-	TolerationSeconds int `yaml:"tolerationSeconds"`
-	Value             string
+	Effect            string `yaml:",omitempty"`
+	Key               string `yaml:",omitempty"`
+	Operator          string `yaml:",omitempty"`
+	TolerationSeconds int    `yaml:"tolerationSeconds,omitempty"`
+	Value             string `yaml:",omitempty"`
 }
 
 type PodSecurityContext struct {
-	FsGroup            int            `yaml:"fsGroup"`
-	RunAsGroup         int            `yaml:"runAsGroup"`
-	RunAsNonRoot       bool           `yaml:"runAsNonRoot"`
-	RunAsUser          int            `yaml:"runAsUser"`
-	SeLinuxOptions     SELinuxOptions `yaml:"seLinuxOptions"`
-	SupplementalGroups []int          `yaml:"supplementalGroups"`
-	Sysctls            []Sysctl
+	FsGroup            int            `yaml:"fsGroup,omitempty"`
+	RunAsGroup         int            `yaml:"runAsGroup,omitempty"`
+	RunAsNonRoot       bool           `yaml:"runAsNonRoot,omitempty"`
+	RunAsUser          int            `yaml:"runAsUser,omitempty"`
+	SeLinuxOptions     SELinuxOptions `yaml:"seLinuxOptions,omitempty"`
+	SupplementalGroups []int          `yaml:"supplementalGroups,omitempty"`
+	Sysctls            []Sysctl       `yaml:",omitempty"`
 }
 
 type Sysctl struct {
@@ -165,14 +155,14 @@ type PodDNSConfigOption struct {
 }
 
 type Affinity struct {
-	NodeAffinity    NodeAffinity    `yaml:"nodeAffinity"`
-	PodAffinity     PodAffinity     `yaml:"podAffinity"`
-	PodAntiAffinity PodAntiAffinity `yaml:"podAntiAffinity"`
+	NodeAffinity    NodeAffinity    `yaml:"nodeAffinity,omitempty"`
+	PodAffinity     PodAffinity     `yaml:"podAffinity,omitempty"`
+	PodAntiAffinity PodAntiAffinity `yaml:"podAntiAffinity,omitempty"`
 }
 
 type NodeAffinity struct {
-	PreferredDuringSchedulingIgnoredDuringExecution []PreferredSchedulingTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution"`
-	RequiredDuringSchedulingIgnoredDuringExecution  NodeSelector              `yaml:"requiredDuringSchedulingIgnoredDuringExecution"`
+	PreferredDuringSchedulingIgnoredDuringExecution []PreferredSchedulingTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+	RequiredDuringSchedulingIgnoredDuringExecution  NodeSelector              `yaml:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
 }
 
 type PreferredSchedulingTerm struct {
@@ -196,8 +186,8 @@ type NodeSelectorRequirement struct {
 }
 
 type PodAffinity struct {
-	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPodAffinityTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution"`
-	RequiredDuringSchedulingIgnoredDuringExecution  []PodAffinityTerm         `yaml:"requiredDuringSchedulingIgnoredDuringExecution"`
+	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPodAffinityTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+	RequiredDuringSchedulingIgnoredDuringExecution  []PodAffinityTerm         `yaml:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
 }
 
 type WeightedPodAffinityTerm struct {
@@ -213,7 +203,7 @@ type PodAffinityTerm struct {
 
 type LabelSelector struct {
 	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions"`
-	MatchLabels      struct{}                   `yaml:"matchLabels"`
+	MatchLabels      map[string]string          `yaml:"matchLabels"`
 }
 
 type LabelSelectorRequirement struct {
@@ -223,6 +213,6 @@ type LabelSelectorRequirement struct {
 }
 
 type PodAntiAffinity struct {
-	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPodAffinityTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution"`
-	RequiredDuringSchedulingIgnoredDuringExecution  []PodAffinityTerm         `yaml:"requiredDuringSchedulingIgnoredDuringExecution"`
+	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPodAffinityTerm `yaml:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+	RequiredDuringSchedulingIgnoredDuringExecution  []PodAffinityTerm         `yaml:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
 }
@@ -1,7 +1,7 @@
 package resource
 
 type ResPodStatus struct {
-	Conditions            PodCondition
+	Conditions            []PodCondition
 	ContainerStatuses     []ContainerStatus `yaml:"containerStatuses"`
 	HostIP                string            `yaml:"hostIP"`
 	InitContainerStatuses []ContainerStatus `yaml:"initContainerStatuses"`
@@ -1,7 +1,10 @@
 package resource
 
+import "errors"
+
 type IResource interface {
 	ToYamlFile() ([]byte, error)
+	Validate() error
 }
 
 type Resource struct {
@@ -25,6 +28,7 @@ const (
 	RESOURCE_LIMIT_RANGE             = "LimitRange"
 	RESOURCE_ENDPOINTS               = "Endpoints"
 	RESOURCE_SERVICE_ACCOUNT         = "ServiceAccount"
+	RESOURCE_EVENT                   = "Event"
 
 	// storage
 	RESOURCE_STORAGE_CLASS = "StorageClass"
@@ -82,6 +86,7 @@ func GetKinds() map[string]string {
 		RESOURCE_CUSTOM_RESOURCE_DEFINITION: "CustomResourceDefinition",
 		RESOURCE_ENDPOINTS:                  "Endpoints",
 		RESOURCE_SERVICE_ACCOUNT:            "ServiceAccount",
+		RESOURCE_EVENT:                      "Event",
 	}
 }
 
@@ -127,3 +132,37 @@ func GetReclaimPolicy() map[string]string {
 		"Retain": "Retain",
 	}
 }
+
+// 资源的唯一标识
+type ObjectKey struct {
+	ApiVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+func (k ObjectKey) String() string {
+	if k.Namespace == "" {
+		return k.Kind + "/" + k.Name
+	}
+	return k.Kind + "/" + k.Namespace + "/" + k.Name
+}
+
+// 读取资源的apiVersion、kind、namespace和name
+func GetObjectKey(obj interface{}) (ObjectKey, error) {
+	m, err := ToMap(obj)
+	if err != nil {
+		return ObjectKey{}, err
+	}
+	key := ObjectKey{}
+	key.ApiVersion, _ = m["apiVersion"].(string)
+	key.Kind, _ = m["kind"].(string)
+	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+		key.Namespace, _ = metadata["namespace"].(string)
+		key.Name, _ = metadata["name"].(string)
+	}
+	if key.ApiVersion == "" || key.Kind == "" {
+		return key, errors.New("apiVersion and kind must be set")
+	}
+	return key, nil
+}
@@ -30,7 +30,7 @@ type ResPodPreset struct {
 }
 
 type ResPodPresetSpec struct {
-	Containers       []resource.IContainer
+	Containers       resource.Containers
 	RestartPolicy    string              `yaml:"restartPolicy"` // [Always | Never | OnFailure]
 	NodeSelector     struct{}            `yaml:"nodeSelector"`
 	ImagePullSecrets []map[string]string `yaml:"imagePullSecrets"`
@@ -53,7 +53,7 @@ func NewResPodPreset(name string) *ResPodPreset {
 			Labels:      map[string]string{},
 			Annotations: map[string]string{}},
 		Spec: &ResPodPresetSpec{
-			Containers:       []resource.IContainer{},
+			Containers:       resource.Containers{},
 			RestartPolicy:    "",
 			NodeSelector:     struct{}{},
 			ImagePullSecrets: []map[string]string{},
@@ -91,6 +91,24 @@ func (r *ResPodPreset) SetAnnotations(map[string]string) error {
 	return nil
 }
 
+func (r *ResPodPreset) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, true, nil, resource.NewPath("metadata"))
+	allErrs = append(allErrs, resource.ValidateLabels(r.Metadata.Labels, resource.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, resource.ValidateAnnotations(r.Metadata.Annotations, resource.NewPath("metadata", "annotations"))...)
+	specPath := resource.NewPath("spec")
+	if r.Spec == nil {
+		return allErrs.ToError()
+	}
+	for i, c := range r.Spec.Containers {
+		if container, ok := c.(*resource.Container); ok && container != nil {
+			allErrs = append(allErrs, container.Validate(specPath.Child("containers").Index(i))...)
+		}
+	}
+	allErrs = append(allErrs, resource.ValidateVolumes(r.Spec.Volumes, specPath.Child("volumes"))...)
+	allErrs = append(allErrs, resource.ValidateRestartPolicy(r.Spec.RestartPolicy, resource.RestartPolicies, specPath.Child("restartPolicy"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResPodPreset) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
@@ -88,6 +88,17 @@ func (r *ResStorageClass) GetParameters() interface{} {
 	return ""
 }
 
+func (r *ResStorageClass) Validate() error {
+	allErrs := resource.ValidateObjectMeta(r.Metadata.Name, r.Metadata.Namespace, false, nil, resource.NewPath("metadata"))
+	if r.Provisioner == "" {
+		allErrs = append(allErrs, resource.Required(resource.NewPath("provisioner"), ""))
+	} else {
+		allErrs = append(allErrs, resource.ValidateLabels(map[string]string{r.Provisioner: ""}, resource.NewPath("provisioner"))...)
+	}
+	allErrs = append(allErrs, resource.ValidateEnum(r.ReclaimPolicy, []string{"Delete", "Retain"}, resource.NewPath("reclaimPolicy"))...)
+	return allErrs.ToError()
+}
+
 func (r *ResStorageClass) ToYamlFile() ([]byte, error) {
 	yamlData, err := yaml.Marshal(*r)
 	if err != nil {
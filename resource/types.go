@@ -130,11 +130,11 @@ type HostPathType string
 type StorageMedium string
 
 type Limits struct {
-	Cpu    string
-	Memory string
+	Cpu    Quantity `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory Quantity `json:"memory,omitempty" yaml:"memory,omitempty"`
 }
 
 type Request struct {
-	Cpu    string
-	Memory string
+	Cpu    Quantity `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory Quantity `json:"memory,omitempty" yaml:"memory,omitempty"`
 }
@@ -0,0 +1,498 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 资源的组、版本和复数名称，对应REST路径 /apis/<group>/<version>/<resource>
+type GroupVersionResource struct {
+	Group    string
+	Version  string
+	Resource string
+}
+
+func (r GroupVersionResource) GroupVersion() string {
+	if r.Group == "" {
+		return r.Version
+	}
+	return r.Group + "/" + r.Version
+}
+
+func (r GroupVersionResource) String() string {
+	return r.Resource + "." + r.Version + "." + r.Group
+}
+
+// 未定义结构体的对象，如自定义资源的实例，以嵌套map保存
+// 数值统一为int64或float64
+type Unstructured struct {
+	Object map[string]interface{}
+}
+
+func NewUnstructured(apiVersion, kind string) *Unstructured {
+	return &Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{},
+	}}
+}
+
+// 结构体转换为Unstructured
+func ToUnstructured(obj interface{}) (*Unstructured, error) {
+	m, err := ToMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &Unstructured{Object: normalizeNumbers(m).(map[string]interface{})}, nil
+}
+
+// Unstructured转换为结构体
+func FromUnstructured(u *Unstructured, obj interface{}) error {
+	return FromMap(u.Object, obj)
+}
+
+// 不知道对象的作用域，只检查命名空间的格式，是否允许命名空间由服务端根据REST映射判断
+func (u *Unstructured) Validate() error {
+	if u.GetApiVersion() == "" || u.GetKind() == "" {
+		return errors.New("apiVersion and kind must be set")
+	}
+	allErrs := ValidateObjectMeta(u.GetName(), u.GetNamespace(), true, nil, NewPath("metadata"))
+	return allErrs.ToError()
+}
+
+func (u *Unstructured) ToYamlFile() ([]byte, error) {
+	return yaml.Marshal(u.Object)
+}
+
+func (u *Unstructured) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Object)
+}
+
+func (u *Unstructured) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return err
+	}
+	u.Object = normalizeNumbers(m).(map[string]interface{})
+	return nil
+}
+
+func (u *Unstructured) MarshalYAML() (interface{}, error) {
+	return u.Object, nil
+}
+
+func (u *Unstructured) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	m, ok := normalizeNumbers(NormalizeYaml(raw)).(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected an object, got %T", raw)
+	}
+	u.Object = m
+	return nil
+}
+
+func (u *Unstructured) DeepCopy() *Unstructured {
+	if u == nil {
+		return nil
+	}
+	return &Unstructured{Object: deepCopyValue(u.Object).(map[string]interface{})}
+}
+
+func (u *Unstructured) GetApiVersion() string  { return u.nestedString("apiVersion") }
+func (u *Unstructured) SetApiVersion(v string) { u.setNestedField(v, "apiVersion") }
+func (u *Unstructured) GetKind() string        { return u.nestedString("kind") }
+func (u *Unstructured) SetKind(kind string)    { u.setNestedField(kind, "kind") }
+func (u *Unstructured) GetName() string        { return u.nestedString("metadata", "name") }
+func (u *Unstructured) SetName(name string)    { u.setNestedField(name, "metadata", "name") }
+func (u *Unstructured) GetNamespace() string   { return u.nestedString("metadata", "namespace") }
+func (u *Unstructured) GetUid() string         { return u.nestedString("metadata", "uid") }
+
+func (u *Unstructured) SetNamespace(namespace string) {
+	u.setNestedField(namespace, "metadata", "namespace")
+}
+
+func (u *Unstructured) GetResourceVersion() string {
+	return u.nestedString("metadata", "resourceVersion")
+}
+
+func (u *Unstructured) SetResourceVersion(version string) {
+	u.setNestedField(version, "metadata", "resourceVersion")
+}
+
+func (u *Unstructured) GetGeneration() int64 {
+	generation, _, _ := NestedInt64(u.Object, "metadata", "generation")
+	return generation
+}
+
+func (u *Unstructured) GetLabels() map[string]string {
+	m, _, _ := NestedStringMap(u.Object, "metadata", "labels")
+	return m
+}
+
+func (u *Unstructured) SetLabels(labels map[string]string) {
+	if labels == nil {
+		RemoveNestedField(u.Object, "metadata", "labels")
+		return
+	}
+	SetNestedStringMap(u.Object, labels, "metadata", "labels")
+}
+
+func (u *Unstructured) GetAnnotations() map[string]string {
+	m, _, _ := NestedStringMap(u.Object, "metadata", "annotations")
+	return m
+}
+
+func (u *Unstructured) SetAnnotations(annotations map[string]string) {
+	if annotations == nil {
+		RemoveNestedField(u.Object, "metadata", "annotations")
+		return
+	}
+	SetNestedStringMap(u.Object, annotations, "metadata", "annotations")
+}
+
+// 对象的唯一标识
+func (u *Unstructured) GetObjectKey() ObjectKey {
+	return ObjectKey{ApiVersion: u.GetApiVersion(), Kind: u.GetKind(), Namespace: u.GetNamespace(), Name: u.GetName()}
+}
+
+func (u *Unstructured) nestedString(fields ...string) string {
+	s, _, _ := NestedString(u.Object, fields...)
+	return s
+}
+
+func (u *Unstructured) setNestedField(value interface{}, fields ...string) {
+	if u.Object == nil {
+		u.Object = map[string]interface{}{}
+	}
+	SetNestedField(u.Object, value, fields...)
+}
+
+// 对象列表，Object中保存列表本身的apiVersion、kind和metadata
+type UnstructuredList struct {
+	Object map[string]interface{}
+	Items  []Unstructured
+}
+
+func (l *UnstructuredList) GetResourceVersion() string {
+	s, _, _ := NestedString(l.Object, "metadata", "resourceVersion")
+	return s
+}
+
+func (l *UnstructuredList) GetContinue() string {
+	s, _, _ := NestedString(l.Object, "metadata", "continue")
+	return s
+}
+
+func (l *UnstructuredList) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(l.Object)+1)
+	for k, v := range l.Object {
+		m[k] = v
+	}
+	items := make([]interface{}, len(l.Items))
+	for i := range l.Items {
+		items[i] = l.Items[i].Object
+	}
+	m["items"] = items
+	return json.Marshal(m)
+}
+
+func (l *UnstructuredList) UnmarshalJSON(data []byte) error {
+	u := &Unstructured{}
+	if err := u.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	items, _ := u.Object["items"].([]interface{})
+	delete(u.Object, "items")
+	l.Object = u.Object
+	l.Items = make([]Unstructured, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("list item must be an object, got %T", item)
+		}
+		l.Items = append(l.Items, Unstructured{Object: m})
+	}
+	return nil
+}
+
+// 获取嵌套字段的值，不复制，found表示字段是否存在
+func NestedFieldNoCopy(obj map[string]interface{}, fields ...string) (interface{}, bool, error) {
+	var val interface{} = obj
+	for i, field := range fields {
+		if val == nil {
+			return nil, false, nil
+		}
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected map[string]interface{}", jsonPath(fields[:i+1]), val, val)
+		}
+		if val, ok = m[field]; !ok {
+			return nil, false, nil
+		}
+	}
+	return val, true, nil
+}
+
+// 获取嵌套字段值的深拷贝
+func NestedFieldCopy(obj map[string]interface{}, fields ...string) (interface{}, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	return deepCopyValue(val), true, nil
+}
+
+func NestedString(obj map[string]interface{}, fields ...string) (string, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return "", found, err
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", false, fmt.Errorf("%v accessor error: %v is of the type %T, expected string", jsonPath(fields), val, val)
+	}
+	return s, true, nil
+}
+
+func NestedBool(obj map[string]interface{}, fields ...string) (bool, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return false, found, err
+	}
+	b, ok := val.(bool)
+	if !ok {
+		return false, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected bool", jsonPath(fields), val, val)
+	}
+	return b, true, nil
+}
+
+func NestedInt64(obj map[string]interface{}, fields ...string) (int64, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return 0, found, err
+	}
+	switch n := normalizeNumbers(val).(type) {
+	case int64:
+		return n, true, nil
+	case float64:
+		if n == float64(int64(n)) {
+			return int64(n), true, nil
+		}
+	}
+	return 0, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected int64", jsonPath(fields), val, val)
+}
+
+func NestedFloat64(obj map[string]interface{}, fields ...string) (float64, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return 0, found, err
+	}
+	switch n := normalizeNumbers(val).(type) {
+	case float64:
+		return n, true, nil
+	case int64:
+		return float64(n), true, nil
+	}
+	return 0, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected float64", jsonPath(fields), val, val)
+}
+
+func NestedStringSlice(obj map[string]interface{}, fields ...string) ([]string, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	list, ok := val.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected []interface{}", jsonPath(fields), val, val)
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("%v accessor error: contains non-string value %v of the type %T", jsonPath(fields), item, item)
+		}
+		strs = append(strs, s)
+	}
+	return strs, true, nil
+}
+
+// 返回列表的深拷贝
+func NestedSlice(obj map[string]interface{}, fields ...string) ([]interface{}, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	list, ok := val.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected []interface{}", jsonPath(fields), val, val)
+	}
+	return deepCopyValue(list).([]interface{}), true, nil
+}
+
+// 返回map的深拷贝
+func NestedMap(obj map[string]interface{}, fields ...string) (map[string]interface{}, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected map[string]interface{}", jsonPath(fields), val, val)
+	}
+	return deepCopyValue(m).(map[string]interface{}), true, nil
+}
+
+func NestedStringMap(obj map[string]interface{}, fields ...string) (map[string]string, bool, error) {
+	val, found, err := NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected map[string]interface{}", jsonPath(fields), val, val)
+	}
+	strs := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("%v accessor error: contains non-string value %v of the type %T for key %s", jsonPath(fields), v, v, k)
+		}
+		strs[k] = s
+	}
+	return strs, true, nil
+}
+
+// 设置嵌套字段的值，中间不存在的map会自动创建，value会被深拷贝
+func SetNestedField(obj map[string]interface{}, value interface{}, fields ...string) error {
+	if len(fields) == 0 {
+		return errors.New("fields is empty")
+	}
+	m := obj
+	for i, field := range fields[:len(fields)-1] {
+		if val, ok := m[field]; ok && val != nil {
+			next, ok := val.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("value cannot be set because %v is not a map[string]interface{}", jsonPath(fields[:i+1]))
+			}
+			m = next
+			continue
+		}
+		next := map[string]interface{}{}
+		m[field] = next
+		m = next
+	}
+	m[fields[len(fields)-1]] = normalizeNumbers(deepCopyValue(value))
+	return nil
+}
+
+func SetNestedStringSlice(obj map[string]interface{}, value []string, fields ...string) error {
+	list := make([]interface{}, 0, len(value))
+	for _, s := range value {
+		list = append(list, s)
+	}
+	return SetNestedField(obj, list, fields...)
+}
+
+func SetNestedSlice(obj map[string]interface{}, value []interface{}, fields ...string) error {
+	return SetNestedField(obj, value, fields...)
+}
+
+func SetNestedStringMap(obj map[string]interface{}, value map[string]string, fields ...string) error {
+	m := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		m[k] = v
+	}
+	return SetNestedField(obj, m, fields...)
+}
+
+func SetNestedMap(obj map[string]interface{}, value map[string]interface{}, fields ...string) error {
+	return SetNestedField(obj, value, fields...)
+}
+
+// 删除嵌套字段，字段不存在时不做任何事
+func RemoveNestedField(obj map[string]interface{}, fields ...string) {
+	m := obj
+	for _, field := range fields[:len(fields)-1] {
+		next, ok := m[field].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, fields[len(fields)-1])
+}
+
+func jsonPath(fields []string) string {
+	return "." + strings.Join(fields, ".")
+}
+
+// 整数统一为int64，json.Number按是否为整数转换为int64或float64
+func normalizeNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = normalizeNumbers(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = normalizeNumbers(item)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case int:
+		return int64(val)
+	case int32:
+		return int64(val)
+	case uint64:
+		return int64(val)
+	case float32:
+		return float64(val)
+	}
+	return v
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = deepCopyValue(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = deepCopyValue(item)
+		}
+		return list
+	case map[string]string:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = item
+		}
+		return m
+	case []string:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = item
+		}
+		return list
+	}
+	return v
+}
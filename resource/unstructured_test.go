@@ -0,0 +1,105 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnstructured_Nested(t *testing.T) {
+	u := &Unstructured{}
+	if err := json.Unmarshal([]byte(`{"apiVersion":"stable.example.com/v1","kind":"CronTab","metadata":{"name":"job","labels":{"app":"cron"}},"spec":{"replicas":3,"ratio":0.5,"image":"busybox","args":["a","b"]}}`), u); err != nil {
+		t.Fatal(err)
+	}
+	if u.GetKind() != "CronTab" || u.GetName() != "job" || u.GetLabels()["app"] != "cron" {
+		t.Fatalf("unexpected object %v", u.Object)
+	}
+	if n, found, err := NestedInt64(u.Object, "spec", "replicas"); err != nil || !found || n != 3 {
+		t.Fatalf("unexpected replicas %v %v %v", n, found, err)
+	}
+	if f, _, _ := NestedFloat64(u.Object, "spec", "ratio"); f != 0.5 {
+		t.Fatalf("unexpected ratio %v", f)
+	}
+	if args, _, _ := NestedStringSlice(u.Object, "spec", "args"); len(args) != 2 || args[1] != "b" {
+		t.Fatalf("unexpected args %v", args)
+	}
+	if _, found, _ := NestedString(u.Object, "spec", "missing"); found {
+		t.Fatal("expected missing field")
+	}
+	if _, _, err := NestedString(u.Object, "spec", "image", "name"); err == nil {
+		t.Fatal("expected accessor error")
+	}
+
+	if err := SetNestedField(u.Object, 5, "spec", "replicas"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetNestedField(u.Object, "daily", "spec", "schedule", "name"); err != nil {
+		t.Fatal(err)
+	}
+	if n, _, _ := NestedInt64(u.Object, "spec", "replicas"); n != 5 {
+		t.Fatalf("unexpected replicas %v", n)
+	}
+	if s, _, _ := NestedString(u.Object, "spec", "schedule", "name"); s != "daily" {
+		t.Fatalf("unexpected schedule %v", s)
+	}
+	if err := SetNestedField(u.Object, "x", "spec", "image", "name"); err == nil {
+		t.Fatal("expected error setting field below a string")
+	}
+	RemoveNestedField(u.Object, "spec", "args")
+	if _, found, _ := NestedSlice(u.Object, "spec", "args"); found {
+		t.Fatal("expected args to be removed")
+	}
+
+	u.SetLabels(map[string]string{"tier": "batch"})
+	u.SetNamespace("jobs")
+	copied := u.DeepCopy()
+	copied.SetName("other")
+	if u.GetName() != "job" || copied.GetLabels()["tier"] != "batch" || copied.GetNamespace() != "jobs" {
+		t.Fatalf("unexpected copy %v", copied.Object)
+	}
+}
+
+func TestUnstructured_Convert(t *testing.T) {
+	crd := NewCustomResourceDefinition()
+	crd.SetMetadataName("crontabs.stable.example.com")
+	u, err := ToUnstructured(crd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.GetKind() != RESOURCE_CUSTOM_RESOURCE_DEFINITION || u.GetName() != "crontabs.stable.example.com" {
+		t.Fatalf("unexpected object %v", u.Object)
+	}
+	u.SetName("widgets.stable.example.com")
+	back := NewCustomResourceDefinition()
+	if err := FromUnstructured(u, back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Metadata.Name != "widgets.stable.example.com" {
+		t.Fatalf("unexpected name %s", back.Metadata.Name)
+	}
+
+	crd.Spec = &CustomResourceDefinitionSpec{
+		Group:   "stable.example.com",
+		Version: []*CrdVersion{{Name: "v1beta1", Served: true}, {Name: "v1", Served: true, Storage: true}},
+		Names:   &CrdNames{Plural: "crontabs", Kind: "CronTab"},
+	}
+	gvr, err := crd.GroupVersionResource()
+	if err != nil || gvr.GroupVersion() != "stable.example.com/v1" || gvr.Resource != "crontabs" {
+		t.Fatalf("unexpected gvr %v, err %v", gvr, err)
+	}
+}
+
+func TestUnstructured_Validate(t *testing.T) {
+	pod := NewUnstructured("v1", RESOURCE_POD)
+	pod.SetName("web")
+	pod.SetNamespace("default")
+	if err := pod.Validate(); err != nil {
+		t.Fatalf("namespaced object should be valid, got %v", err)
+	}
+	pod.SetNamespace("Default")
+	if err := pod.Validate(); err == nil {
+		t.Fatal("expected invalid namespace error")
+	}
+	if err := NewUnstructured("v1", RESOURCE_POD).Validate(); err == nil {
+		t.Fatal("expected name required error")
+	}
+}
@@ -0,0 +1,589 @@
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 校验错误类型
+const (
+	ERROR_TYPE_REQUIRED      = "Required value"
+	ERROR_TYPE_INVALID       = "Invalid value"
+	ERROR_TYPE_NOT_SUPPORTED = "Unsupported value"
+	ERROR_TYPE_DUPLICATE     = "Duplicate value"
+	ERROR_TYPE_TOO_LONG      = "Too long"
+)
+
+const (
+	DNS1123_LABEL_MAX_LENGTH     = 63
+	DNS1123_SUBDOMAIN_MAX_LENGTH = 253
+	LABEL_VALUE_MAX_LENGTH       = 63
+	QUALIFIED_NAME_MAX_LENGTH    = 63
+	TOTAL_ANNOTATION_SIZE_LIMIT  = 256 * 1024
+)
+
+var (
+	dns1123LabelRegexp     = regexp.MustCompile("^[a-z0-9]([-a-z0-9]*[a-z0-9])?$")
+	dns1123SubdomainRegexp = regexp.MustCompile("^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$")
+	qualifiedNameRegexp    = regexp.MustCompile("^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$")
+	labelValueRegexp       = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$")
+	configMapKeyRegexp     = regexp.MustCompile("^[-._a-zA-Z0-9]+$")
+	envVarNameRegexp       = regexp.MustCompile("^[-._a-zA-Z][-._a-zA-Z0-9]*$")
+)
+
+// 字段路径，如 spec.template.spec.containers[0].image
+type FieldPath struct {
+	parent *FieldPath
+	name   string
+	index  string
+}
+
+func NewPath(name string, more ...string) *FieldPath {
+	p := &FieldPath{name: name}
+	for _, v := range more {
+		p = &FieldPath{parent: p, name: v}
+	}
+	return p
+}
+
+func (p *FieldPath) Child(name string, more ...string) *FieldPath {
+	c := &FieldPath{parent: p, name: name}
+	for _, v := range more {
+		c = &FieldPath{parent: c, name: v}
+	}
+	return c
+}
+
+func (p *FieldPath) Index(i int) *FieldPath {
+	return &FieldPath{parent: p, index: strconv.Itoa(i)}
+}
+
+func (p *FieldPath) Key(key string) *FieldPath {
+	return &FieldPath{parent: p, index: key}
+}
+
+func (p *FieldPath) String() string {
+	if p == nil {
+		return ""
+	}
+	parent := p.parent.String()
+	if p.index != "" || p.name == "" {
+		return parent + "[" + p.index + "]"
+	}
+	if parent == "" {
+		return p.name
+	}
+	return parent + "." + p.name
+}
+
+// 单个字段的校验错误
+type FieldError struct {
+	Type   string
+	Field  string
+	Value  interface{}
+	Detail string
+}
+
+func (e *FieldError) Error() string {
+	msg := e.Field + ": " + e.Type
+	if e.Value != nil && e.Type != ERROR_TYPE_REQUIRED {
+		msg += fmt.Sprintf(": %#v", e.Value)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func Required(field *FieldPath, detail string) *FieldError {
+	return &FieldError{Type: ERROR_TYPE_REQUIRED, Field: field.String(), Detail: detail}
+}
+
+func Invalid(field *FieldPath, value interface{}, detail string) *FieldError {
+	return &FieldError{Type: ERROR_TYPE_INVALID, Field: field.String(), Value: value, Detail: detail}
+}
+
+func NotSupported(field *FieldPath, value interface{}, valid []string) *FieldError {
+	detail := ""
+	if len(valid) > 0 {
+		detail = "supported values: \"" + strings.Join(valid, "\", \"") + "\""
+	}
+	return &FieldError{Type: ERROR_TYPE_NOT_SUPPORTED, Field: field.String(), Value: value, Detail: detail}
+}
+
+func Duplicate(field *FieldPath, value interface{}) *FieldError {
+	return &FieldError{Type: ERROR_TYPE_DUPLICATE, Field: field.String(), Value: value}
+}
+
+func TooLong(field *FieldPath, value interface{}, max int) *FieldError {
+	return &FieldError{Type: ERROR_TYPE_TOO_LONG, Field: field.String(), Value: value, Detail: fmt.Sprintf("must have at most %d bytes", max)}
+}
+
+// 校验错误列表，同时作为多错误返回
+type ErrorList []*FieldError
+
+func (list ErrorList) Error() string {
+	msgs := make([]string, 0, len(list))
+	for _, e := range list {
+		msgs = append(msgs, e.Error())
+	}
+	if len(msgs) == 1 {
+		return msgs[0]
+	}
+	return "[" + strings.Join(msgs, ", ") + "]"
+}
+
+// 没有错误时返回nil，避免返回非nil的空接口
+func (list ErrorList) ToError() error {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+// 将多条校验提示转换为错误列表
+func toErrors(field *FieldPath, value interface{}, msgs []string) ErrorList {
+	allErrs := ErrorList{}
+	for _, msg := range msgs {
+		allErrs = append(allErrs, Invalid(field, value, msg))
+	}
+	return allErrs
+}
+
+func IsDNS1123Label(value string) []string {
+	var errs []string
+	if len(value) > DNS1123_LABEL_MAX_LENGTH {
+		errs = append(errs, fmt.Sprintf("must be no more than %d characters", DNS1123_LABEL_MAX_LENGTH))
+	}
+	if !dns1123LabelRegexp.MatchString(value) {
+		errs = append(errs, "a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+	return errs
+}
+
+func IsDNS1123Subdomain(value string) []string {
+	var errs []string
+	if len(value) > DNS1123_SUBDOMAIN_MAX_LENGTH {
+		errs = append(errs, fmt.Sprintf("must be no more than %d characters", DNS1123_SUBDOMAIN_MAX_LENGTH))
+	}
+	if !dns1123SubdomainRegexp.MatchString(value) {
+		errs = append(errs, "a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character")
+	}
+	return errs
+}
+
+// 标签键校验 [prefix/]name
+func IsQualifiedName(value string) []string {
+	var errs []string
+	parts := strings.Split(value, "/")
+	var name string
+	switch len(parts) {
+	case 1:
+		name = parts[0]
+	case 2:
+		var prefix string
+		prefix, name = parts[0], parts[1]
+		if len(prefix) == 0 {
+			errs = append(errs, "prefix part must be non-empty")
+		} else if msgs := IsDNS1123Subdomain(prefix); len(msgs) != 0 {
+			for _, msg := range msgs {
+				errs = append(errs, "prefix part "+msg)
+			}
+		}
+	default:
+		return append(errs, "a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character, with an optional DNS subdomain prefix and '/'")
+	}
+
+	if len(name) == 0 {
+		errs = append(errs, "name part must be non-empty")
+	} else if len(name) > QUALIFIED_NAME_MAX_LENGTH {
+		errs = append(errs, fmt.Sprintf("name part must be no more than %d characters", QUALIFIED_NAME_MAX_LENGTH))
+	}
+	if !qualifiedNameRegexp.MatchString(name) {
+		errs = append(errs, "name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character")
+	}
+	return errs
+}
+
+func IsValidLabelValue(value string) []string {
+	var errs []string
+	if len(value) > LABEL_VALUE_MAX_LENGTH {
+		errs = append(errs, fmt.Sprintf("must be no more than %d characters", LABEL_VALUE_MAX_LENGTH))
+	}
+	if !labelValueRegexp.MatchString(value) {
+		errs = append(errs, "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character")
+	}
+	return errs
+}
+
+func IsValidPortNum(port int) []string {
+	if 1 <= port && port <= 65535 {
+		return nil
+	}
+	return []string{"must be between 1 and 65535, inclusive"}
+}
+
+func IsConfigMapKey(value string) []string {
+	var errs []string
+	if len(value) > DNS1123_SUBDOMAIN_MAX_LENGTH {
+		errs = append(errs, fmt.Sprintf("must be no more than %d characters", DNS1123_SUBDOMAIN_MAX_LENGTH))
+	}
+	if !configMapKeyRegexp.MatchString(value) {
+		errs = append(errs, "a valid config key must consist of alphanumeric characters, '-', '_' or '.'")
+	}
+	if value == "." || value == ".." || strings.HasPrefix(value, "..") {
+		errs = append(errs, "must not be '.' or '..' or start with '..'")
+	}
+	return errs
+}
+
+func IsEnvVarName(value string) []string {
+	if envVarNameRegexp.MatchString(value) {
+		return nil
+	}
+	return []string{"a valid environment variable name must consist of alphabetic characters, digits, '_', '-', or '.', and must not start with a digit"}
+}
+
+// 资源数量格式校验，如 500m、1Gi、1e3
+func IsQuantity(value string) []string {
+	if _, err := ParseQuantity(value); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+func ValidateDNS1123Label(value string, field *FieldPath) ErrorList {
+	return toErrors(field, value, IsDNS1123Label(value))
+}
+
+func ValidateDNS1123Subdomain(value string, field *FieldPath) ErrorList {
+	return toErrors(field, value, IsDNS1123Subdomain(value))
+}
+
+// 校验 metadata.name 与 metadata.namespace
+// nameFn 为空时按DNS-1123子域名校验名称
+func ValidateObjectMeta(name, namespace string, namespaced bool, nameFn func(string) []string, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	if nameFn == nil {
+		nameFn = IsDNS1123Subdomain
+	}
+	if name == "" {
+		allErrs = append(allErrs, Required(field.Child("name"), "name is required"))
+	} else {
+		allErrs = append(allErrs, toErrors(field.Child("name"), name, nameFn(name))...)
+	}
+	if namespaced {
+		if namespace != "" {
+			allErrs = append(allErrs, ValidateDNS1123Label(namespace, field.Child("namespace"))...)
+		}
+	} else if namespace != "" {
+		allErrs = append(allErrs, Invalid(field.Child("namespace"), namespace, "not allowed on this type"))
+	}
+	return allErrs
+}
+
+func ValidateLabels(labels map[string]string, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	for _, k := range sortedKeys(labels) {
+		v := labels[k]
+		allErrs = append(allErrs, toErrors(field, k, IsQualifiedName(k))...)
+		allErrs = append(allErrs, toErrors(field.Key(k), v, IsValidLabelValue(v))...)
+	}
+	return allErrs
+}
+
+func ValidateAnnotations(annotations map[string]string, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	total := 0
+	for _, k := range sortedKeys(annotations) {
+		allErrs = append(allErrs, toErrors(field, k, IsQualifiedName(strings.ToLower(k)))...)
+		total += len(k) + len(annotations[k])
+	}
+	if total > TOTAL_ANNOTATION_SIZE_LIMIT {
+		allErrs = append(allErrs, TooLong(field, "", TOTAL_ANNOTATION_SIZE_LIMIT))
+	}
+	return allErrs
+}
+
+func ValidateQuantity(value string, field *FieldPath) ErrorList {
+	if value == "" {
+		return ErrorList{}
+	}
+	return toErrors(field, value, IsQuantity(value))
+}
+
+func ValidateNonnegativeQuantity(value Quantity, field *FieldPath) ErrorList {
+	if value.Sign() < 0 {
+		return ErrorList{Invalid(field, value.String(), "must be greater than or equal to 0")}
+	}
+	return ErrorList{}
+}
+
+// 枚举值校验，value为空时不校验
+func ValidateEnum(value string, valid []string, field *FieldPath) ErrorList {
+	if value == "" {
+		return ErrorList{}
+	}
+	for _, v := range valid {
+		if v == value {
+			return ErrorList{}
+		}
+	}
+	return ErrorList{NotSupported(field, value, valid)}
+}
+
+func ValidatePort(port int, field *FieldPath) ErrorList {
+	return toErrors(field, port, IsValidPortNum(port))
+}
+
+var (
+	RestartPolicies    = []string{"Always", "OnFailure", "Never"}
+	ImagePullPolicies  = []string{"Always", "Never", "IfNotPresent"}
+	Protocols          = []string{"TCP", "UDP", "SCTP"}
+	AccessModes        = []string{"ReadWriteOnce", "ReadOnlyMany", "ReadWriteMany"}
+	VolumeModes        = []string{"Block", "Filesystem"}
+	TaintEffects       = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+	TolerationOperator = []string{"Exists", "Equal"}
+	SelectorOperators  = []string{"In", "NotIn", "Exists", "DoesNotExist"}
+)
+
+// 重启策略校验，policy为空时使用默认值 Always
+func ValidateRestartPolicy(policy string, allowed []string, field *FieldPath) ErrorList {
+	if policy == "" {
+		policy = "Always"
+	}
+	for _, v := range allowed {
+		if v == policy {
+			return ErrorList{}
+		}
+	}
+	return ErrorList{NotSupported(field, policy, allowed)}
+}
+
+func ValidateContainerResources(res ContainerResources, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	allErrs = append(allErrs, ValidateNonnegativeQuantity(res.Limits.Cpu, field.Child("limits", "cpu"))...)
+	allErrs = append(allErrs, ValidateNonnegativeQuantity(res.Limits.Memory, field.Child("limits", "memory"))...)
+	allErrs = append(allErrs, ValidateNonnegativeQuantity(res.Requests.Cpu, field.Child("requests", "cpu"))...)
+	allErrs = append(allErrs, ValidateNonnegativeQuantity(res.Requests.Memory, field.Child("requests", "memory"))...)
+	// request 不能大于 limit
+	if !res.Limits.Cpu.IsZero() && res.Requests.Cpu.Cmp(res.Limits.Cpu) > 0 {
+		allErrs = append(allErrs, Invalid(field.Child("requests", "cpu"), res.Requests.Cpu.String(), "must be less than or equal to cpu limit"))
+	}
+	if !res.Limits.Memory.IsZero() && res.Requests.Memory.Cmp(res.Limits.Memory) > 0 {
+		allErrs = append(allErrs, Invalid(field.Child("requests", "memory"), res.Requests.Memory.String(), "must be less than or equal to memory limit"))
+	}
+	return allErrs
+}
+
+func ValidateContainerPorts(ports []ContainerPort, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	names := map[string]bool{}
+	for i, port := range ports {
+		idxPath := field.Index(i)
+		if port.Name != "" {
+			if len(port.Name) > 15 {
+				allErrs = append(allErrs, TooLong(idxPath.Child("name"), port.Name, 15))
+			}
+			allErrs = append(allErrs, ValidateDNS1123Label(port.Name, idxPath.Child("name"))...)
+			if names[port.Name] {
+				allErrs = append(allErrs, Duplicate(idxPath.Child("name"), port.Name))
+			}
+			names[port.Name] = true
+		}
+		if port.ContainerPort == 0 {
+			allErrs = append(allErrs, Required(idxPath.Child("containerPort"), ""))
+		} else {
+			allErrs = append(allErrs, ValidatePort(port.ContainerPort, idxPath.Child("containerPort"))...)
+		}
+		if port.HostPort != 0 {
+			allErrs = append(allErrs, ValidatePort(port.HostPort, idxPath.Child("hostPort"))...)
+		}
+		allErrs = append(allErrs, ValidateEnum(port.Protocol, Protocols, idxPath.Child("protocol"))...)
+	}
+	return allErrs
+}
+
+func (r *Container) Validate(field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	if r.Name == "" {
+		allErrs = append(allErrs, Required(field.Child("name"), ""))
+	} else {
+		allErrs = append(allErrs, ValidateDNS1123Label(r.Name, field.Child("name"))...)
+	}
+	if r.Image == "" {
+		allErrs = append(allErrs, Required(field.Child("image"), ""))
+	}
+	allErrs = append(allErrs, ValidateEnum(r.ImagePullPolicy, ImagePullPolicies, field.Child("imagePullPolicy"))...)
+	allErrs = append(allErrs, ValidateContainerPorts(r.Ports, field.Child("ports"))...)
+	for i, env := range r.Env {
+		if env.Name == "" {
+			allErrs = append(allErrs, Required(field.Child("env").Index(i).Child("name"), ""))
+		} else {
+			allErrs = append(allErrs, toErrors(field.Child("env").Index(i).Child("name"), env.Name, IsEnvVarName(env.Name))...)
+		}
+	}
+	for i, mount := range r.VolumeMounts {
+		idxPath := field.Child("volumeMounts").Index(i)
+		if mount.Name == "" {
+			allErrs = append(allErrs, Required(idxPath.Child("name"), ""))
+		}
+		if mount.MountPath == "" {
+			allErrs = append(allErrs, Required(idxPath.Child("mountPath"), ""))
+		}
+	}
+	allErrs = append(allErrs, ValidateContainerResources(r.Resources, field.Child("resources"))...)
+	return allErrs
+}
+
+// 校验容器列表，名称不能重复
+func ValidateContainers(containers []IContainer, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	if len(containers) == 0 {
+		return append(allErrs, Required(field, "at least one container is required"))
+	}
+	names := map[string]bool{}
+	for i, c := range containers {
+		idxPath := field.Index(i)
+		container, ok := c.(*Container)
+		if !ok || container == nil {
+			allErrs = append(allErrs, Invalid(idxPath, nil, "container is nil"))
+			continue
+		}
+		allErrs = append(allErrs, container.Validate(idxPath)...)
+		if container.Name != "" {
+			if names[container.Name] {
+				allErrs = append(allErrs, Duplicate(idxPath.Child("name"), container.Name))
+			}
+			names[container.Name] = true
+		}
+	}
+	return allErrs
+}
+
+func ValidateVolumes(volumes []*Volume, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	names := map[string]bool{}
+	for i, vol := range volumes {
+		idxPath := field.Index(i)
+		if vol == nil {
+			allErrs = append(allErrs, Invalid(idxPath, nil, "volume is nil"))
+			continue
+		}
+		if vol.Name == "" {
+			allErrs = append(allErrs, Required(idxPath.Child("name"), ""))
+			continue
+		}
+		allErrs = append(allErrs, ValidateDNS1123Label(vol.Name, idxPath.Child("name"))...)
+		if names[vol.Name] {
+			allErrs = append(allErrs, Duplicate(idxPath.Child("name"), vol.Name))
+		}
+		names[vol.Name] = true
+	}
+	return allErrs
+}
+
+func ValidatePodSpec(spec *PodSpec, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	containers := make([]IContainer, 0, len(spec.Containers))
+	for i := range spec.Containers {
+		containers = append(containers, &spec.Containers[i])
+	}
+	allErrs = append(allErrs, ValidateContainers(containers, field.Child("containers"))...)
+	for i := range spec.InitContainers {
+		allErrs = append(allErrs, spec.InitContainers[i].Validate(field.Child("initContainers").Index(i))...)
+	}
+	volumes := make([]*Volume, 0, len(spec.Volumes))
+	for i := range spec.Volumes {
+		volumes = append(volumes, &spec.Volumes[i])
+	}
+	allErrs = append(allErrs, ValidateVolumes(volumes, field.Child("volumes"))...)
+	allErrs = append(allErrs, ValidateRestartPolicy(spec.RestartPolicy, RestartPolicies, field.Child("restartPolicy"))...)
+	for i, toler := range spec.Tolerations {
+		idxPath := field.Child("tolerations").Index(i)
+		allErrs = append(allErrs, ValidateEnum(toler.Operator, TolerationOperator, idxPath.Child("operator"))...)
+		allErrs = append(allErrs, ValidateEnum(toler.Effect, TaintEffects, idxPath.Child("effect"))...)
+	}
+	if spec.ActiveDeadlineSeconds < 0 {
+		allErrs = append(allErrs, Invalid(field.Child("activeDeadlineSeconds"), spec.ActiveDeadlineSeconds, "must be greater than or equal to 0"))
+	}
+	if spec.TerminationGracePeriodSeconds < 0 {
+		allErrs = append(allErrs, Invalid(field.Child("terminationGracePeriodSeconds"), spec.TerminationGracePeriodSeconds, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func ValidateSelector(selector *Selector, field *FieldPath) ErrorList {
+	allErrs := ErrorList{}
+	if selector == nil {
+		return append(allErrs, Required(field, ""))
+	}
+	allErrs = append(allErrs, ValidateLabels(selector.MatchLabels, field.Child("matchLabels"))...)
+	for i, expr := range selector.MatchExpressions {
+		idxPath := field.Child("matchExpressions").Index(i)
+		if expr == nil {
+			allErrs = append(allErrs, Invalid(idxPath, nil, "expression is nil"))
+			continue
+		}
+		allErrs = append(allErrs, toErrors(idxPath.Child("key"), expr.Key, IsQualifiedName(expr.Key))...)
+		switch expr.Operator {
+		case "In", "NotIn":
+			if len(expr.Values) == 0 {
+				allErrs = append(allErrs, Required(idxPath.Child("values"), "must be specified when `operator` is 'In' or 'NotIn'"))
+			}
+		case "Exists", "DoesNotExist":
+			if len(expr.Values) > 0 {
+				allErrs = append(allErrs, Invalid(idxPath.Child("values"), expr.Values, "may not be specified when `operator` is 'Exists' or 'DoesNotExist'"))
+			}
+		default:
+			allErrs = append(allErrs, NotSupported(idxPath.Child("operator"), expr.Operator, SelectorOperators))
+		}
+	}
+	if len(selector.MatchLabels)+len(selector.MatchExpressions) == 0 {
+		allErrs = append(allErrs, Invalid(field, selector, "empty selector is invalid for this resource"))
+	}
+	return allErrs
+}
+
+// 校验selector非空且与pod模板标签匹配
+func ValidateSelectorMatchesTemplate(selector *Selector, templateLabels map[string]string, field *FieldPath, templateField *FieldPath) ErrorList {
+	allErrs := ValidateSelector(selector, field)
+	if len(allErrs) != 0 {
+		return allErrs
+	}
+	if !selector.Matches(templateLabels) {
+		allErrs = append(allErrs, Invalid(templateField, templateLabels, "`selector` does not match template `labels`"))
+	}
+	return allErrs
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 校验非负整数字符串，如副本数
+func ValidateNonnegativeString(value string, field *FieldPath) ErrorList {
+	if value == "" {
+		return ErrorList{}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return ErrorList{Invalid(field, value, "must be a non-negative integer")}
+	}
+	return ErrorList{}
+}
+
+func ValidateNonnegative(value int, field *FieldPath) ErrorList {
+	if value < 0 {
+		return ErrorList{Invalid(field, value, "must be greater than or equal to 0")}
+	}
+	return ErrorList{}
+}
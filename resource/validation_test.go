@@ -0,0 +1,79 @@
+package resource
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldPath_String(t *testing.T) {
+	path := NewPath("spec", "template", "spec").Child("containers").Index(0).Child("image")
+	if path.String() != "spec.template.spec.containers[0].image" {
+		t.Fatalf("unexpected path %s", path.String())
+	}
+	if p := NewPath("metadata", "labels").Key("app"); p.String() != "metadata.labels[app]" {
+		t.Fatalf("unexpected path %s", p.String())
+	}
+}
+
+func TestIsQualifiedName(t *testing.T) {
+	for _, v := range []string{"app", "k8s.io/app", "app.kubernetes.io/name", "a_b-c.d"} {
+		if errs := IsQualifiedName(v); len(errs) != 0 {
+			t.Errorf("%s: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "/app", "-app", "a/b/c", "Bad_Prefix/app", strings.Repeat("a", 64)} {
+		if errs := IsQualifiedName(v); len(errs) == 0 {
+			t.Errorf("%s: expected error", v)
+		}
+	}
+}
+
+func TestContainer_Validate(t *testing.T) {
+	container := NewContainer("Nginx", "")
+	container.Ports = append(container.Ports, ContainerPort{ContainerPort: 70000, Protocol: "HTTP"})
+	container.Resources.Requests.Cpu = MustParse("-500m")
+
+	errs := container.Validate(NewPath("spec", "containers").Index(0))
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, f := range []string{
+		"spec.containers[0].name",
+		"spec.containers[0].image",
+		"spec.containers[0].ports[0].containerPort",
+		"spec.containers[0].ports[0].protocol",
+		"spec.containers[0].resources.requests.cpu",
+	} {
+		if !fields[f] {
+			t.Errorf("missing error for %s in %v", f, errs)
+		}
+	}
+}
+
+func TestValidateSelectorMatchesTemplate(t *testing.T) {
+	selector := &Selector{
+		MatchLabels:      map[string]string{"app": "web"},
+		MatchExpressions: []*MatchExpressions{{Key: "tier", Operator: "In", Values: []string{"a", "b"}}},
+	}
+	if errs := ValidateSelectorMatchesTemplate(selector, map[string]string{"app": "web", "tier": "a"}, NewPath("spec", "selector"), NewPath("spec", "template")); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	errs := ValidateSelectorMatchesTemplate(selector, map[string]string{"app": "web", "tier": "c"}, NewPath("spec", "selector"), NewPath("spec", "template"))
+	if len(errs) != 1 || errs[0].Field != "spec.template" {
+		t.Fatal(errs)
+	}
+	if errs := ValidateSelector(&Selector{}, NewPath("spec", "selector")); len(errs) != 1 {
+		t.Fatal(errs)
+	}
+}
+
+func TestErrorList_ToError(t *testing.T) {
+	if err := (ErrorList{}).ToError(); err != nil {
+		t.Fatal(err)
+	}
+	err := ErrorList{Required(NewPath("metadata", "name"), "")}.ToError()
+	if err == nil || err.Error() != "metadata.name: Required value" {
+		t.Fatal(err)
+	}
+}
@@ -9,17 +9,31 @@ import (
 	"time"
 )
 
+// patch请求的Content-Type
+type PatchType string
+
+const (
+	JSONPatchType           PatchType = "application/json-patch+json"
+	MergePatchType          PatchType = "application/merge-patch+json"
+	StrategicMergePatchType PatchType = "application/strategic-merge-patch+json"
+	ApplyPatchType          PatchType = "application/apply-patch+yaml"
+)
+
 type IHttpClient interface {
 	Get() (resp *http.Response, err error)
 	Post(body []byte, headers map[string]string) (resp *http.Response, err error)
 	Put(body []byte, headers map[string]string) (resp *http.Response, err error)
-	Delete() (resp *http.Response, err error)
-	Patch() (resp *http.Response, err error)
+	Delete(body []byte, headers map[string]string) (resp *http.Response, err error)
+	Patch(pt PatchType, body []byte, headers map[string]string) (resp *http.Response, err error)
 
 
 	dial(method string) (resp *http.Response, err error)
 	SetHeader(key string, values ...string)
 	SetUrl(url *url.URL)
+	SetQuery(key string, values ...string)
+	SetLabelSelector(selector string)
+	SetFieldSelector(selector string)
+	SetContext(ctx context.Context)
 	GetPath() string
 }
 
@@ -67,7 +81,14 @@ func (c *HttpClient) Post(body []byte, headers map[string]string) (resp *http.Re
 	return c.dial("POST")
 }
 
-func (c *HttpClient) Delete() (resp *http.Response, err error) {
+// body为空时不发送请求体，删除参数以DeleteOptions的json作为body
+func (c *HttpClient) Delete(body []byte, headers map[string]string) (resp *http.Response, err error) {
+	for k, v := range headers {
+		c.headers.Del(k)
+		c.SetHeader(k, v)
+	}
+
+	c.body = bytes.NewReader(body)
 
 	return c.dial("DELETE")
 }
@@ -83,12 +104,24 @@ func (c *HttpClient) Put(body []byte, headers map[string]string) (resp *http.Res
 	return c.dial("PUT")
 }
 
-func (c *HttpClient) Patch() (resp *http.Response, err error) {
+func (c *HttpClient) Patch(pt PatchType, body []byte, headers map[string]string) (resp *http.Response, err error) {
+	for k, v := range headers {
+		c.headers.Del(k)
+		c.SetHeader(k, v)
+	}
+	c.SetHeader("Content-Type", string(pt))
+
+	c.body = bytes.NewReader(body)
+
 	return c.dial("PATCH")
 }
 
 func (c *HttpClient) dial(method string) (resp *http.Response, err error) {
-	req, err := http.NewRequest(method, c.getUrl(), c.body)
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.getUrl(), c.body)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +147,33 @@ func (c *HttpClient) SetUrl(url *url.URL) {
 	c.url = url
 }
 
+// 设置查询参数，值为空时删除该参数
+func (c *HttpClient) SetQuery(key string, values ...string) {
+	query := c.url.Query()
+	query.Del(key)
+	for _, value := range values {
+		if value != "" {
+			query.Add(key, value)
+		}
+	}
+	c.url.RawQuery = query.Encode()
+}
+
+// 设置labelSelector参数，如 app=web,tier in (a,b)
+func (c *HttpClient) SetLabelSelector(selector string) {
+	c.SetQuery("labelSelector", selector)
+}
+
+// 设置fieldSelector参数，如 spec.nodeName=node-1,status.phase=Running
+func (c *HttpClient) SetFieldSelector(selector string) {
+	c.SetQuery("fieldSelector", selector)
+}
+
+// 设置请求的context，用于取消请求或设置超时
+func (c *HttpClient) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
 func NewHttpClient(url *url.URL, headers http.Header) IHttpClient {
 	return &HttpClient{
 		url:     url,
@@ -1,36 +1,60 @@
-package rest
+package rest_test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"testing"
+
+	"k8s-client-go/fakeserver"
+	"k8s-client-go/rest"
 )
 
 func TestNewHttpClient(t *testing.T) {
+	server := fakeserver.NewServer()
+	defer server.Close()
+
 	headers := http.Header{}
-	url := url.URL{}
-	url.Scheme = "http"
-	url.Host = "192.168.37.150:8080"
-	url.Path = "/api/v1/namespaces/"
-	client := NewHttpClient(&url, headers)
+	u, err := url.Parse(server.URL + "/api/v1/namespaces/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := rest.NewHttpClient(u, headers)
 
 	resp, err := client.Get()
-	defer resp.Body.Close()
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
-
 	body, err := ioutil.ReadAll(resp.Body)
-	t.Log(string(body))
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", resp.StatusCode, string(body))
+	}
+	list := struct {
+		Items []struct {
+			Metadata struct{ Name string }
+		}
+	}{}
+	if err := json.Unmarshal(body, &list); err != nil || len(list.Items) != 3 {
+		t.Fatalf("unexpected namespaces %s", string(body))
+	}
 
-	resp, err = client.Post(body, map[string]string{})
+	resp, err = client.Post([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"test"}}`), map[string]string{"Content-Type": "application/json"})
 	if err != nil {
-		t.Fatal(err.Error())
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
 	}
-	body, err = ioutil.ReadAll(resp.Body)
-	t.Log(string(body))
 
-	t.Fatal("COM")
+	// 重复创建返回409
+	resp, err = client.Post([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"test"}}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rest.CheckResponse(resp); !rest.IsAlreadyExists(err) {
+		t.Fatalf("expected already exists, got %v", err)
+	}
 }
-
@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+	"k8s-client-go/resource"
+)
+
+// 常见的失败原因
+const (
+	StatusReasonNotFound        = "NotFound"
+	StatusReasonAlreadyExists   = "AlreadyExists"
+	StatusReasonConflict        = "Conflict"
+	StatusReasonGone            = "Gone"
+	StatusReasonExpired         = "Expired"
+	StatusReasonTooManyRequests = "TooManyRequests"
+	StatusReasonInvalid         = "Invalid"
+	StatusReasonForbidden       = "Forbidden"
+)
+
+// apiserver返回的错误状态
+type StatusError struct {
+	Status resource.Status
+}
+
+func (e *StatusError) Error() string {
+	if e.Status.Message != "" {
+		return e.Status.Message
+	}
+	return fmt.Sprintf("the server responded with status %d (%s)", e.Status.Code, e.Status.Reason)
+}
+
+// 构造与apiserver一致的错误，qualifiedResource如 deployments.apps、pods
+func NewNotFound(qualifiedResource, name string) *StatusError {
+	return newStatusError(http.StatusNotFound, StatusReasonNotFound, fmt.Sprintf("%s %q not found", qualifiedResource, name))
+}
+
+func NewAlreadyExists(qualifiedResource, name string) *StatusError {
+	return newStatusError(http.StatusConflict, StatusReasonAlreadyExists, fmt.Sprintf("%s %q already exists", qualifiedResource, name))
+}
+
+func NewConflict(qualifiedResource, name string) *StatusError {
+	return newStatusError(http.StatusConflict, StatusReasonConflict, fmt.Sprintf("Operation cannot be fulfilled on %s %q: the object has been modified; please apply your changes to the latest version and try again", qualifiedResource, name))
+}
+
+// 删除时preconditions不满足
+func NewPreconditionFailed(qualifiedResource, name string, err error) *StatusError {
+	return newStatusError(http.StatusConflict, StatusReasonConflict, fmt.Sprintf("Operation cannot be fulfilled on %s %q: %v", qualifiedResource, name, err))
+}
+
+func NewBadRequest(message string) *StatusError {
+	return newStatusError(http.StatusBadRequest, "BadRequest", message)
+}
+
+// 429，retryAfterSeconds大于0时写入Details.RetryAfterSeconds
+func NewTooManyRequests(message string, retryAfterSeconds int) *StatusError {
+	err := newStatusError(http.StatusTooManyRequests, StatusReasonTooManyRequests, message)
+	err.Status.Details.RetryAfterSeconds = retryAfterSeconds
+	return err
+}
+
+func newStatusError(code int, reason, message string) *StatusError {
+	return &StatusError{Status: resource.Status{ApiVersion: "v1", Kind: "Status", Status: "Failure", Code: code, Reason: reason, Message: message}}
+}
+
+// 非2xx响应转换为StatusError，会读取并关闭响应体
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	status := resource.Status{}
+	if err := yaml.Unmarshal(body, &status); err != nil || status.Kind != "Status" {
+		status = resource.Status{Status: "Failure", Message: string(body)}
+	}
+	if status.Code == 0 {
+		status.Code = resp.StatusCode
+	}
+	if status.Reason == "" {
+		status.Reason = reasonForCode(resp.StatusCode)
+	}
+	if status.Message == "" {
+		status.Message = fmt.Sprintf("the server responded with status %d (%s)", status.Code, status.Reason)
+	}
+	if status.Details.RetryAfterSeconds == 0 {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			status.Details.RetryAfterSeconds = seconds
+		}
+	}
+	return &StatusError{Status: status}
+}
+
+func reasonForCode(code int) string {
+	switch code {
+	case http.StatusNotFound:
+		return StatusReasonNotFound
+	case http.StatusConflict:
+		return StatusReasonConflict
+	case http.StatusGone:
+		return StatusReasonGone
+	case http.StatusTooManyRequests:
+		return StatusReasonTooManyRequests
+	case http.StatusUnprocessableEntity:
+		return StatusReasonInvalid
+	case http.StatusForbidden:
+		return StatusReasonForbidden
+	}
+	return http.StatusText(code)
+}
+
+func statusOf(err error) (resource.Status, bool) {
+	if e, ok := err.(*StatusError); ok {
+		return e.Status, true
+	}
+	return resource.Status{}, false
+}
+
+func IsNotFound(err error) bool {
+	status, ok := statusOf(err)
+	return ok && (status.Reason == StatusReasonNotFound || status.Code == http.StatusNotFound)
+}
+
+func IsAlreadyExists(err error) bool {
+	status, ok := statusOf(err)
+	return ok && status.Reason == StatusReasonAlreadyExists
+}
+
+// 409冲突，通常是resourceVersion已过期
+func IsConflict(err error) bool {
+	status, ok := statusOf(err)
+	return ok && status.Code == http.StatusConflict && status.Reason != StatusReasonAlreadyExists
+}
+
+// 410，continue或resourceVersion已过期
+func IsGone(err error) bool {
+	status, ok := statusOf(err)
+	return ok && (status.Code == http.StatusGone || status.Reason == StatusReasonGone || status.Reason == StatusReasonExpired)
+}
+
+func IsTooManyRequests(err error) bool {
+	status, ok := statusOf(err)
+	return ok && (status.Code == http.StatusTooManyRequests || status.Reason == StatusReasonTooManyRequests)
+}
+
+// 服务端建议的重试间隔（秒），来自Status.Details.retryAfterSeconds或Retry-After响应头
+func SuggestsClientDelay(err error) (int, bool) {
+	status, ok := statusOf(err)
+	if !ok || status.Details.RetryAfterSeconds <= 0 {
+		return 0, false
+	}
+	return status.Details.RetryAfterSeconds, true
+}
+
+func IsInvalid(err error) bool {
+	status, ok := statusOf(err)
+	return ok && (status.Code == http.StatusUnprocessableEntity || status.Reason == StatusReasonInvalid)
+}
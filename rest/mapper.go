@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kind与REST资源路径的对应关系
+type RESTMapping struct {
+	Group      string
+	Version    string
+	Resource   string // 复数形式，如 deployments
+	Kind       string
+	Namespaced bool
+}
+
+func (m *RESTMapping) GroupVersion() string {
+	if m.Group == "" {
+		return m.Version
+	}
+	return m.Group + "/" + m.Version
+}
+
+// 带组名的资源名，如 deployments.apps，核心组为 pods
+func (m *RESTMapping) QualifiedResource() string {
+	if m.Group == "" {
+		return m.Resource
+	}
+	return m.Resource + "." + m.Group
+}
+
+// 资源路径，如 /api/v1/namespaces/default/pods/web、/apis/apps/v1/deployments
+func (m *RESTMapping) ResourcePath(namespace, name string) string {
+	var path string
+	if m.Group == "" {
+		path = "/api/" + m.Version
+	} else {
+		path = "/apis/" + m.Group + "/" + m.Version
+	}
+	if m.Namespaced && namespace != "" {
+		path += "/namespaces/" + namespace
+	}
+	path += "/" + m.Resource
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// 根据apiVersion和kind查找资源
+type RESTMapper interface {
+	RESTMapping(apiVersion, kind string) (*RESTMapping, error)
+}
+
+// 拆分apiVersion，如 apps/v1 -> apps, v1；v1 -> "", v1
+func ParseGroupVersion(apiVersion string) (group, version string, err error) {
+	parts := strings.Split(apiVersion, "/")
+	switch len(parts) {
+	case 1:
+		return "", parts[0], nil
+	case 2:
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("unexpected apiVersion string %q", apiVersion)
+}
+
+// 静态的映射表
+type StaticRESTMapper struct {
+	mappings []RESTMapping
+}
+
+func NewStaticRESTMapper(mappings ...RESTMapping) *StaticRESTMapper {
+	return &StaticRESTMapper{mappings: mappings}
+}
+
+func (m *StaticRESTMapper) Add(mapping RESTMapping) {
+	m.mappings = append(m.mappings, mapping)
+}
+
+// 所有映射的副本
+func (m *StaticRESTMapper) Mappings() []RESTMapping {
+	return append([]RESTMapping{}, m.mappings...)
+}
+
+func (m *StaticRESTMapper) RESTMapping(apiVersion, kind string) (*RESTMapping, error) {
+	group, version, err := ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	for i := range m.mappings {
+		mapping := m.mappings[i]
+		if mapping.Group == group && mapping.Version == version && mapping.Kind == kind {
+			return &mapping, nil
+		}
+	}
+	return nil, fmt.Errorf("no matches for kind %q in version %q", kind, apiVersion)
+}
+
+// 内置资源的映射
+var DefaultRESTMapper = NewStaticRESTMapper(defaultMappings()...)
+
+func defaultMappings() []RESTMapping {
+	var mappings []RESTMapping
+	add := func(group string, versions []string, kind, resource string, namespaced bool) {
+		for _, version := range versions {
+			mappings = append(mappings, RESTMapping{Group: group, Version: version, Resource: resource, Kind: kind, Namespaced: namespaced})
+		}
+	}
+	core := []string{"v1"}
+	add("", core, "Pod", "pods", true)
+	add("", core, "Service", "services", true)
+	add("", core, "Endpoints", "endpoints", true)
+	add("", core, "ConfigMap", "configmaps", true)
+	add("", core, "Secret", "secrets", true)
+	add("", core, "ServiceAccount", "serviceaccounts", true)
+	add("", core, "PersistentVolumeClaim", "persistentvolumeclaims", true)
+	add("", core, "ReplicationController", "replicationcontrollers", true)
+	add("", core, "ResourceQuota", "resourcequotas", true)
+	add("", core, "LimitRange", "limitranges", true)
+	add("", core, "Event", "events", true)
+	add("", core, "PersistentVolume", "persistentvolumes", false)
+	add("", core, "Namespace", "namespaces", false)
+	add("", core, "Node", "nodes", false)
+
+	apps := []string{"v1", "v1beta2", "v1beta1"}
+	add("apps", apps, "Deployment", "deployments", true)
+	add("apps", apps, "StatefulSet", "statefulsets", true)
+	add("apps", apps, "ControllerRevision", "controllerrevisions", true)
+	add("apps", []string{"v1", "v1beta2"}, "DaemonSet", "daemonsets", true)
+	add("apps", []string{"v1", "v1beta2"}, "ReplicaSet", "replicasets", true)
+
+	extensions := []string{"v1beta1"}
+	add("extensions", extensions, "Deployment", "deployments", true)
+	add("extensions", extensions, "DaemonSet", "daemonsets", true)
+	add("extensions", extensions, "ReplicaSet", "replicasets", true)
+	add("extensions", extensions, "Ingress", "ingresses", true)
+	add("extensions", extensions, "NetworkPolicy", "networkpolicies", true)
+
+	add("batch", []string{"v1"}, "Job", "jobs", true)
+	add("batch", []string{"v1", "v1beta1"}, "CronJob", "cronjobs", true)
+	add("autoscaling", []string{"v1", "v2beta1", "v2beta2", "v2"}, "HorizontalPodAutoscaler", "horizontalpodautoscalers", true)
+	add("networking.k8s.io", []string{"v1"}, "NetworkPolicy", "networkpolicies", true)
+	add("networking.k8s.io", []string{"v1", "v1beta1"}, "Ingress", "ingresses", true)
+	add("policy", []string{"v1", "v1beta1"}, "PodDisruptionBudget", "poddisruptionbudgets", true)
+	add("settings.k8s.io", []string{"v1alpha1"}, "PodPreset", "podpresets", true)
+	add("storage.k8s.io", []string{"v1", "v1beta1"}, "StorageClass", "storageclasses", false)
+	add("apiextensions.k8s.io", []string{"v1", "v1beta1"}, "CustomResourceDefinition", "customresourcedefinitions", false)
+	return mappings
+}
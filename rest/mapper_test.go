@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"testing"
+)
+
+func TestStaticRESTMapper(t *testing.T) {
+	cases := []struct {
+		apiVersion, kind, namespace, name string
+		path                              string
+	}{
+		{"v1", "Pod", "default", "web", "/api/v1/namespaces/default/pods/web"},
+		{"v1", "Node", "default", "node-1", "/api/v1/nodes/node-1"},
+		{"apps/v1", "Deployment", "kube-system", "", "/apis/apps/v1/namespaces/kube-system/deployments"},
+		{"storage.k8s.io/v1", "StorageClass", "", "fast", "/apis/storage.k8s.io/v1/storageclasses/fast"},
+		{"batch/v1beta1", "CronJob", "", "", "/apis/batch/v1beta1/cronjobs"},
+	}
+	for _, c := range cases {
+		mapping, err := DefaultRESTMapper.RESTMapping(c.apiVersion, c.kind)
+		if err != nil {
+			t.Fatalf("%s %s: %v", c.apiVersion, c.kind, err)
+		}
+		if got := mapping.ResourcePath(c.namespace, c.name); got != c.path {
+			t.Errorf("expected %s, got %s", c.path, got)
+		}
+	}
+	if _, err := DefaultRESTMapper.RESTMapping("apps/v1", "Widget"); err == nil {
+		t.Fatal("expected no match error")
+	}
+}
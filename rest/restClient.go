@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Host        string // apiserver地址，如 https://192.168.1.10:6443
+	BearerToken string
+	Headers     http.Header
+	Timeout     time.Duration
+	Client      *http.Client // 为空时使用默认客户端
+}
+
+// 按路径创建请求的客户端，所有请求共享同一个http.Client
+type RESTClient struct {
+	base    *url.URL
+	headers http.Header
+	Client  *http.Client
+}
+
+func NewRESTClient(config *Config) (*RESTClient, error) {
+	if config == nil || config.Host == "" {
+		return nil, errors.New("host is empty")
+	}
+	host := config.Host
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	base, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/")
+
+	headers := http.Header{}
+	for k, v := range config.Headers {
+		headers[k] = append([]string{}, v...)
+	}
+	if config.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+config.BearerToken)
+	}
+	if headers.Get("Accept") == "" {
+		headers.Set("Accept", "application/json")
+	}
+
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: config.Timeout}
+	}
+	return &RESTClient{base: base, headers: headers, Client: client}, nil
+}
+
+// apiserver地址
+func (c *RESTClient) Host() *url.URL {
+	u := *c.base
+	return &u
+}
+
+// 创建指向path的请求，如 /api/v1/namespaces/default/pods
+func (c *RESTClient) NewRequest(path string) IHttpClient {
+	u := *c.base
+	u.Path = c.base.Path + path
+	headers := http.Header{}
+	for k, v := range c.headers {
+		headers[k] = append([]string{}, v...)
+	}
+	return &HttpClient{
+		url:     &u,
+		headers: headers,
+		Client:  c.Client,
+		body:    strings.NewReader(""),
+	}
+}
+
+// 每个请求携带的header，如认证信息，返回的是副本
+func (c *RESTClient) Headers() http.Header {
+	headers := http.Header{}
+	for k, v := range c.headers {
+		headers[k] = append([]string{}, v...)
+	}
+	return headers
+}
+
+// http.Client使用的Transport，用于exec、port-forward等需要升级协议的长连接，不受Client.Timeout限制
+func (c *RESTClient) Transport() http.RoundTripper {
+	if c.Client != nil && c.Client.Transport != nil {
+		return c.Client.Transport
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,86 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"k8s-client-go/rest"
+)
+
+// 指数退避参数，每次等待Duration后Duration乘以Factor，最多尝试Steps次
+type Backoff struct {
+	Duration time.Duration
+	Factor   float64
+	Jitter   float64 // 在等待时间上随机增加的比例，0表示不增加
+	Steps    int
+	Cap      time.Duration // 等待时间上限，0表示不限制
+}
+
+// 适用于更新冲突的默认退避，总等待时间较短
+var DefaultRetry = Backoff{
+	Duration: 10 * time.Millisecond,
+	Factor:   1.0,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
+// 适用于请求失败重试的默认退避
+var DefaultBackoff = Backoff{
+	Duration: 10 * time.Millisecond,
+	Factor:   5.0,
+	Jitter:   0.1,
+	Steps:    4,
+}
+
+// 返回下一次的等待时间并更新退避状态
+func (b *Backoff) Step() time.Duration {
+	duration := b.Duration
+	if b.Factor > 0 {
+		b.Duration = time.Duration(float64(b.Duration) * b.Factor)
+		if b.Cap > 0 && b.Duration > b.Cap {
+			b.Duration = b.Cap
+		}
+	}
+	if b.Steps > 0 {
+		b.Steps--
+	}
+	if b.Jitter > 0 {
+		duration += time.Duration(rand.Float64() * b.Jitter * float64(duration))
+	}
+	return duration
+}
+
+// fn返回的错误满足retriable时按退避重试，返回最后一次的错误
+func OnError(backoff Backoff, retriable func(error) bool, fn func() error) error {
+	return OnErrorContext(context.Background(), backoff, retriable, fn)
+}
+
+// 与OnError相同，ctx取消时停止等待并返回ctx的错误
+func OnErrorContext(ctx context.Context, backoff Backoff, retriable func(error) bool, fn func() error) error {
+	steps := backoff.Steps
+	if steps < 1 {
+		steps = 1
+	}
+	var err error
+	for i := 0; i < steps; i++ {
+		if i > 0 {
+			timer := time.NewTimer(backoff.Step())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if err = fn(); err == nil || !retriable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// 发生409冲突时重试fn，fn中应重新获取对象后再修改
+func RetryOnConflict(backoff Backoff, fn func() error) error {
+	return OnError(backoff, rest.IsConflict, fn)
+}
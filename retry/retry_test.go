@@ -0,0 +1,52 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+func TestRetryOnConflict(t *testing.T) {
+	conflict := &rest.StatusError{Status: resource.Status{Reason: rest.StatusReasonConflict, Code: 409}}
+	backoff := Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+
+	calls := 0
+	err := RetryOnConflict(backoff, func() error {
+		if calls++; calls < 3 {
+			return conflict
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("expected success after 3 calls, got %v after %d", err, calls)
+	}
+
+	calls = 0
+	if err := RetryOnConflict(backoff, func() error { calls++; return conflict }); err != conflict || calls != 3 {
+		t.Fatalf("expected conflict after 3 calls, got %v after %d", err, calls)
+	}
+
+	// 其它错误不重试
+	calls = 0
+	other := errors.New("boom")
+	if err := RetryOnConflict(backoff, func() error { calls++; return other }); err != other || calls != 1 {
+		t.Fatalf("expected one call, got %d", calls)
+	}
+}
+
+func TestBackoffStep(t *testing.T) {
+	b := Backoff{Duration: 10 * time.Millisecond, Factor: 3, Steps: 5, Cap: 50 * time.Millisecond}
+	var got []time.Duration
+	for i := 0; i < 4; i++ {
+		got = append(got, b.Step())
+	}
+	want := []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("step %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
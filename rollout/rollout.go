@@ -0,0 +1,260 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"k8s-client-go/client"
+	"k8s-client-go/labels"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+)
+
+const (
+	// 修改pod模板中的这个注解触发重新发布
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// 发布的原因，由用户写入，history中显示
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	// ReplicaSet的pod模板中由控制器加上的标签
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// 回滚时不从ReplicaSet复制到Deployment的注解
+var skipCopyAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	RevisionAnnotation:                          true,
+	"deployment.kubernetes.io/revision-history": true,
+	"deployment.kubernetes.io/desired-replicas": true,
+	"deployment.kubernetes.io/max-replicas":     true,
+}
+
+func deploymentKey(namespace, name string) resource.ObjectKey {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return resource.ObjectKey{ApiVersion: "apps/v1", Kind: resource.RESOURCE_DEPLOYMENT, Namespace: namespace, Name: name}
+}
+
+func getDeployment(ctx context.Context, c client.Interface, namespace, name string) (*resource.Unstructured, error) {
+	deploy := &resource.Unstructured{}
+	if _, err := c.Get(ctx, deploymentKey(namespace, name), deploy); err != nil {
+		return nil, err
+	}
+	return deploy, nil
+}
+
+func isPaused(deploy *resource.Unstructured) bool {
+	paused, _, _ := resource.NestedBool(deploy.Object, "spec", "paused")
+	return paused
+}
+
+func mergePatch(ctx context.Context, c client.Interface, namespace, name string, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = c.Patch(ctx, deploymentKey(namespace, name), rest.MergePatchType, data, nil)
+	return err
+}
+
+// 在pod模板的注解中写入当前时间，使Deployment重新创建所有pod
+func Restart(ctx context.Context, c client.Interface, namespace, name string) error {
+	deploy, err := getDeployment(ctx, c, namespace, name)
+	if err != nil {
+		return err
+	}
+	if isPaused(deploy) {
+		return fmt.Errorf("can't restart paused deployment %q (run rollout resume first)", name)
+	}
+	return mergePatch(ctx, c, namespace, name, map[string]interface{}{
+		"spec": map[string]interface{}{"template": map[string]interface{}{"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+		}}},
+	})
+}
+
+// 暂停后对pod模板的修改不会触发发布
+func Pause(ctx context.Context, c client.Interface, namespace, name string) error {
+	return setPaused(ctx, c, namespace, name, true)
+}
+
+func Resume(ctx context.Context, c client.Interface, namespace, name string) error {
+	return setPaused(ctx, c, namespace, name, false)
+}
+
+func setPaused(ctx context.Context, c client.Interface, namespace, name string, paused bool) error {
+	deploy, err := getDeployment(ctx, c, namespace, name)
+	if err != nil {
+		return err
+	}
+	if isPaused(deploy) == paused {
+		if paused {
+			return fmt.Errorf("deployment %q is already paused", name)
+		}
+		return fmt.Errorf("deployment %q is not paused", name)
+	}
+	return mergePatch(ctx, c, namespace, name, map[string]interface{}{"spec": map[string]interface{}{"paused": paused}})
+}
+
+// Deployment的一个历史版本，对应一个ReplicaSet
+type Revision struct {
+	Revision    int64
+	ChangeCause string
+	ReplicaSet  string
+	Template    map[string]interface{} // 去掉了pod-template-hash标签的pod模板
+
+	annotations map[string]string
+}
+
+// 按revision从小到大返回Deployment的历史版本
+func History(ctx context.Context, c client.Interface, namespace, name string) ([]Revision, error) {
+	deploy, err := getDeployment(ctx, c, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return history(ctx, c, deploy)
+}
+
+// 列出由deploy控制的ReplicaSet
+func history(ctx context.Context, c client.Interface, deploy *resource.Unstructured) ([]Revision, error) {
+	selector, _, _ := resource.NestedStringMap(deploy.Object, "spec", "selector", "matchLabels")
+	pager := client.NewListPager(c, "apps/v1", resource.RESOURCE_REPLICASET, client.ListOptions{
+		Namespace:     deploy.GetNamespace(),
+		LabelSelector: labels.Set(selector).String(),
+	})
+	list, err := pager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []Revision
+	for i := range list.Items {
+		rs := &resource.Unstructured{}
+		if _, err := list.DecodeItem(i, rs); err != nil {
+			return nil, err
+		}
+		if !controlledBy(rs, deploy) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.GetAnnotations()[RevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		template, _, _ := resource.NestedMap(rs.Object, "spec", "template")
+		if podLabels, ok, _ := resource.NestedMap(template, "metadata", "labels"); ok {
+			delete(podLabels, podTemplateHashLabel)
+			resource.SetNestedMap(template, podLabels, "metadata", "labels")
+		}
+		revisions = append(revisions, Revision{
+			Revision:    revision,
+			ChangeCause: rs.GetAnnotations()[ChangeCauseAnnotation],
+			ReplicaSet:  rs.GetName(),
+			Template:    template,
+			annotations: rs.GetAnnotations(),
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, nil
+}
+
+func controlledBy(obj, owner *resource.Unstructured) bool {
+	refs, _, _ := resource.NestedSlice(obj.Object, "metadata", "ownerReferences")
+	for _, ref := range refs {
+		if m, ok := ref.(map[string]interface{}); ok && m["uid"] == owner.GetUid() && m["controller"] == true {
+			return true
+		}
+	}
+	return false
+}
+
+// 以 kubectl rollout history 的格式输出
+func PrintHistory(out io.Writer, revisions []Revision) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "REVISION\tCHANGE-CAUSE\n")
+	for _, r := range revisions {
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		fmt.Fprintf(w, "%d\t%s\n", r.Revision, cause)
+	}
+	return w.Flush()
+}
+
+// 将Deployment的pod模板回滚到toRevision对应的ReplicaSet，toRevision为0时回滚到上一个版本，返回回滚到的revision
+func Undo(ctx context.Context, c client.Interface, namespace, name string, toRevision int64) (int64, error) {
+	if toRevision < 0 {
+		return 0, errors.New("revision must be non-negative")
+	}
+	deploy, err := getDeployment(ctx, c, namespace, name)
+	if err != nil {
+		return 0, err
+	}
+	if isPaused(deploy) {
+		return 0, fmt.Errorf("you cannot rollback a paused deployment %q; resume it first and try again", name)
+	}
+	revisions, err := history(ctx, c, deploy)
+	if err != nil {
+		return 0, err
+	}
+	target, err := findRevision(revisions, toRevision)
+	if err != nil {
+		return 0, err
+	}
+	current, _, _ := resource.NestedMap(deploy.Object, "spec", "template")
+	if reflect.DeepEqual(current, target.Template) {
+		return target.Revision, fmt.Errorf("skipped rollback (current template already matches revision %d)", target.Revision)
+	}
+
+	// 模板和注解整体替换，以删除目标版本中不存在的字段
+	annotations := deploy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k := range annotations {
+		if !skipCopyAnnotations[k] {
+			delete(annotations, k)
+		}
+	}
+	for k, v := range target.annotations {
+		if !skipCopyAnnotations[k] {
+			annotations[k] = v
+		}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": target.Template},
+		{"op": "add", "path": "/metadata/annotations", "value": annotations},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := c.Patch(ctx, deploymentKey(namespace, name), rest.JSONPatchType, patch, nil); err != nil {
+		return 0, err
+	}
+	return target.Revision, nil
+}
+
+func findRevision(revisions []Revision, toRevision int64) (*Revision, error) {
+	if len(revisions) == 0 {
+		return nil, errors.New("no rollout history found")
+	}
+	if toRevision == 0 {
+		if len(revisions) < 2 {
+			return nil, errors.New("no previous revision found")
+		}
+		return &revisions[len(revisions)-2], nil
+	}
+	for i := range revisions {
+		if revisions[i].Revision == toRevision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find specified revision %d in history", toRevision)
+}
@@ -0,0 +1,125 @@
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"k8s-client-go/client/fake"
+	"k8s-client-go/resource"
+)
+
+func podTemplate(image string, extraLabels map[string]interface{}) map[string]interface{} {
+	podLabels := map[string]interface{}{"app": "web"}
+	for k, v := range extraLabels {
+		podLabels[k] = v
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": podLabels},
+		"spec":     map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web", "image": image}}},
+	}
+}
+
+func newReplicaSet(name, revision, image, cause string) *resource.Unstructured {
+	rs := resource.NewUnstructured("apps/v1", resource.RESOURCE_REPLICASET)
+	rs.SetName(name)
+	rs.SetLabels(map[string]string{"app": "web"})
+	annotations := map[string]string{RevisionAnnotation: revision}
+	if cause != "" {
+		annotations[ChangeCauseAnnotation] = cause
+	}
+	rs.SetAnnotations(annotations)
+	resource.SetNestedSlice(rs.Object, []interface{}{map[string]interface{}{
+		"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "uid": "deploy-uid", "controller": true,
+	}}, "metadata", "ownerReferences")
+	resource.SetNestedField(rs.Object, podTemplate(image, map[string]interface{}{podTemplateHashLabel: name}), "spec", "template")
+	return rs
+}
+
+func newRolloutClient() *fake.Client {
+	deploy := resource.NewUnstructured("apps/v1", resource.RESOURCE_DEPLOYMENT)
+	deploy.SetName("web")
+	resource.SetNestedField(deploy.Object, "deploy-uid", "metadata", "uid")
+	deploy.SetAnnotations(map[string]string{RevisionAnnotation: "3", ChangeCauseAnnotation: "upgrade to 1.19"})
+	resource.SetNestedStringMap(deploy.Object, map[string]string{"app": "web"}, "spec", "selector", "matchLabels")
+	resource.SetNestedField(deploy.Object, podTemplate("nginx:1.19", nil), "spec", "template")
+
+	orphan := newReplicaSet("web-other", "9", "nginx:1.20", "")
+	resource.SetNestedSlice(orphan.Object, nil, "metadata", "ownerReferences")
+	return fake.NewSimpleClient(deploy,
+		newReplicaSet("web-3", "3", "nginx:1.19", "upgrade to 1.19"),
+		newReplicaSet("web-1", "1", "nginx:1.17", ""),
+		newReplicaSet("web-2", "2", "nginx:1.18", "upgrade to 1.18"),
+		orphan,
+	)
+}
+
+func TestHistoryAndUndo(t *testing.T) {
+	c := newRolloutClient()
+	ctx := context.Background()
+
+	revisions, err := History(ctx, c, "default", "web")
+	if err != nil || len(revisions) != 3 || revisions[0].Revision != 1 || revisions[2].ReplicaSet != "web-3" {
+		t.Fatalf("unexpected history %+v, err %v", revisions, err)
+	}
+	out := &bytes.Buffer{}
+	PrintHistory(out, revisions)
+	if !strings.Contains(out.String(), "1         <none>\n") || !strings.Contains(out.String(), "2         upgrade to 1.18\n") {
+		t.Fatalf("unexpected history output:\n%s", out.String())
+	}
+
+	revision, err := Undo(ctx, c, "default", "web", 0)
+	if err != nil || revision != 2 {
+		t.Fatalf("unexpected undo result %d, err %v", revision, err)
+	}
+	deploy, _ := getDeployment(ctx, c, "default", "web")
+	containers, _, _ := resource.NestedSlice(deploy.Object, "spec", "template", "spec", "containers")
+	podLabels, _, _ := resource.NestedStringMap(deploy.Object, "spec", "template", "metadata", "labels")
+	if containers[0].(map[string]interface{})["image"] != "nginx:1.18" || podLabels[podTemplateHashLabel] != "" {
+		t.Fatalf("unexpected template %v", deploy.Object["spec"])
+	}
+	if annotations := deploy.GetAnnotations(); annotations[ChangeCauseAnnotation] != "upgrade to 1.18" || annotations[RevisionAnnotation] != "3" {
+		t.Fatalf("unexpected annotations %v", annotations)
+	}
+
+	if _, err := Undo(ctx, c, "default", "web", 2); err == nil || !strings.Contains(err.Error(), "skipped rollback") {
+		t.Fatalf("expected skipped rollback, got %v", err)
+	}
+	if _, err := Undo(ctx, c, "default", "web", 9); err == nil {
+		t.Fatal("expected error for revision of another deployment")
+	}
+}
+
+func TestPauseResumeRestart(t *testing.T) {
+	c := newRolloutClient()
+	ctx := context.Background()
+
+	if err := Pause(ctx, c, "default", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Pause(ctx, c, "default", "web"); err == nil {
+		t.Fatal("expected already paused error")
+	}
+	if err := Restart(ctx, c, "default", "web"); err == nil {
+		t.Fatal("expected error restarting a paused deployment")
+	}
+	if _, err := Undo(ctx, c, "default", "web", 0); err == nil {
+		t.Fatal("expected error rolling back a paused deployment")
+	}
+	if err := Resume(ctx, c, "default", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Resume(ctx, c, "default", "web"); err == nil {
+		t.Fatal("expected not paused error")
+	}
+
+	if err := Restart(ctx, c, "default", "web"); err != nil {
+		t.Fatal(err)
+	}
+	deploy, _ := getDeployment(ctx, c, "default", "web")
+	restartedAt, _, _ := resource.NestedString(deploy.Object, "spec", "template", "metadata", "annotations", RestartedAtAnnotation)
+	if paused := isPaused(deploy); paused || restartedAt == "" {
+		t.Fatalf("unexpected deployment %v", deploy.Object["spec"])
+	}
+}
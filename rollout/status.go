@@ -0,0 +1,232 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// Deployment当前的revision，由控制器写入
+const RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// 根据对象的spec和status判断发布是否完成，与kubectl rollout status一致
+// revision大于0时只检查Deployment是否为该revision；返回的消息以换行结尾，发布失败时返回错误
+type StatusViewer interface {
+	Status(obj *resource.Unstructured, revision int64) (string, bool, error)
+}
+
+type StatusViewerFunc func(obj *resource.Unstructured, revision int64) (string, bool, error)
+
+func (f StatusViewerFunc) Status(obj *resource.Unstructured, revision int64) (string, bool, error) {
+	return f(obj, revision)
+}
+
+// 支持的kind为Deployment、StatefulSet和DaemonSet
+func StatusViewerFor(kind string) (StatusViewer, error) {
+	switch kind {
+	case resource.RESOURCE_DEPLOYMENT:
+		return StatusViewerFunc(DeploymentStatus), nil
+	case resource.RESOURCE_STATEFULE_SET:
+		return StatusViewerFunc(StatefulSetStatus), nil
+	case resource.RESOURCE_DAEMONSET:
+		return StatusViewerFunc(DaemonSetStatus), nil
+	}
+	return nil, fmt.Errorf("no status viewer has been implemented for %s", kind)
+}
+
+func DeploymentStatus(obj *resource.Unstructured, revision int64) (string, bool, error) {
+	name := obj.GetName()
+	if revision > 0 {
+		current, err := strconv.ParseInt(obj.GetAnnotations()[RevisionAnnotation], 10, 64)
+		if err != nil {
+			return "", false, fmt.Errorf("cannot get the revision of deployment %q: %v", name, err)
+		}
+		if revision != current {
+			return "", false, fmt.Errorf("desired revision (%d) is different from the running revision (%d)", revision, current)
+		}
+	}
+	if obj.GetGeneration() > statusInt(obj, "observedGeneration") {
+		return "Waiting for deployment spec update to be observed...\n", false, nil
+	}
+	if cond := condition(obj, "Progressing"); cond != nil && cond["reason"] == "ProgressDeadlineExceeded" {
+		return "", false, fmt.Errorf("deployment %q exceeded its progress deadline", name)
+	}
+	updated := statusInt(obj, "updatedReplicas")
+	if replicas, ok := specReplicas(obj); ok && updated < replicas {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...\n", name, updated, replicas), false, nil
+	}
+	if replicas := statusInt(obj, "replicas"); replicas > updated {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...\n", name, replicas-updated), false, nil
+	}
+	if available := statusInt(obj, "availableReplicas"); available < updated {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...\n", name, available, updated), false, nil
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out\n", name), true, nil
+}
+
+// 只支持RollingUpdate更新策略，revision不生效
+func DaemonSetStatus(obj *resource.Unstructured, revision int64) (string, bool, error) {
+	if err := checkRollingUpdate(obj); err != nil {
+		return "", true, err
+	}
+	name := obj.GetName()
+	if obj.GetGeneration() > statusInt(obj, "observedGeneration") {
+		return "Waiting for daemon set spec update to be observed...\n", false, nil
+	}
+	desired := statusInt(obj, "desiredNumberScheduled")
+	if updated := statusInt(obj, "updatedNumberScheduled"); updated < desired {
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...\n", name, updated, desired), false, nil
+	}
+	if available := statusInt(obj, "numberAvailable"); available < desired {
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...\n", name, available, desired), false, nil
+	}
+	return fmt.Sprintf("daemon set %q successfully rolled out\n", name), true, nil
+}
+
+// 只支持RollingUpdate更新策略，设置了partition时只等待序号不小于partition的pod，revision不生效
+func StatefulSetStatus(obj *resource.Unstructured, revision int64) (string, bool, error) {
+	if err := checkRollingUpdate(obj); err != nil {
+		return "", true, err
+	}
+	observed := statusInt(obj, "observedGeneration")
+	if observed == 0 || obj.GetGeneration() > observed {
+		return "Waiting for statefulset spec update to be observed...\n", false, nil
+	}
+	replicas, hasReplicas := specReplicas(obj)
+	if ready := statusInt(obj, "readyReplicas"); hasReplicas && ready < replicas {
+		return fmt.Sprintf("Waiting for %d pods to be ready...\n", replicas-ready), false, nil
+	}
+	updated := statusInt(obj, "updatedReplicas")
+	if partition, found, _ := resource.NestedInt64(obj.Object, "spec", "updateStrategy", "rollingUpdate", "partition"); found && hasReplicas {
+		if updated < replicas-partition {
+			return fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...\n", updated, replicas-partition), false, nil
+		}
+		return fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...\n", updated), true, nil
+	}
+	updateRevision, _, _ := resource.NestedString(obj.Object, "status", "updateRevision")
+	currentRevision, _, _ := resource.NestedString(obj.Object, "status", "currentRevision")
+	if updateRevision != currentRevision {
+		return fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...\n", updated, updateRevision), false, nil
+	}
+	return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...\n", statusInt(obj, "currentReplicas"), currentRevision), true, nil
+}
+
+// 未设置更新策略时服务端默认为RollingUpdate
+func checkRollingUpdate(obj *resource.Unstructured) error {
+	strategy, _, _ := resource.NestedString(obj.Object, "spec", "updateStrategy", "type")
+	if strategy != "" && strategy != "RollingUpdate" {
+		return fmt.Errorf("rollout status is only available for RollingUpdate strategy type")
+	}
+	return nil
+}
+
+func statusInt(obj *resource.Unstructured, field string) int64 {
+	n, _, _ := resource.NestedInt64(obj.Object, "status", field)
+	return n
+}
+
+// spec.replicas未设置或不是整数时返回false
+func specReplicas(obj *resource.Unstructured) (int64, bool) {
+	n, found, err := resource.NestedInt64(obj.Object, "spec", "replicas")
+	return n, found && err == nil
+}
+
+func condition(obj *resource.Unstructured, conditionType string) map[string]interface{} {
+	conditions, _, _ := resource.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if m, ok := c.(map[string]interface{}); ok && m["type"] == conditionType {
+			return m
+		}
+	}
+	return nil
+}
+
+// 等待kind（Deployment、StatefulSet或DaemonSet）发布完成，直到ctx取消；out不为空时输出每次变化的状态消息
+// 先获取当前对象，未完成时从其resourceVersion开始监听，监听中断或过期时重新获取
+func WaitForRollout(ctx context.Context, c client.Interface, kind, namespace, name string, revision int64, out io.Writer) error {
+	viewer, err := StatusViewerFor(kind)
+	if err != nil {
+		return err
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	key := resource.ObjectKey{ApiVersion: "apps/v1", Kind: kind, Namespace: namespace, Name: name}
+	lastMessage := ""
+	// 返回是否完成
+	check := func(obj *resource.Unstructured) (bool, error) {
+		message, done, err := viewer.Status(obj, revision)
+		if err != nil {
+			return false, err
+		}
+		if out != nil && message != lastMessage {
+			fmt.Fprint(out, message)
+		}
+		lastMessage = message
+		return done, nil
+	}
+
+	for {
+		obj := &resource.Unstructured{}
+		if _, err := c.Get(ctx, key, obj); err != nil {
+			return err
+		}
+		if done, err := check(obj); done || err != nil {
+			return err
+		}
+		w, err := c.Watch(ctx, key.ApiVersion, kind, client.ListOptions{
+			Namespace:       namespace,
+			FieldSelector:   "metadata.name=" + name,
+			ResourceVersion: obj.GetResourceVersion(),
+		})
+		if err != nil {
+			return err
+		}
+		done, err := watchRollout(ctx, w, key, check)
+		w.Stop()
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// 返回false和nil时需重新获取对象后继续监听
+func watchRollout(ctx context.Context, w watch.Interface, key resource.ObjectKey, check func(obj *resource.Unstructured) (bool, error)) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("timed out waiting for the rollout of %s: %v", key, ctx.Err())
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				status, _ := event.Object.(*resource.Status)
+				if status == nil {
+					return false, fmt.Errorf("unexpected watch error %v", event.Object)
+				}
+				if err := (&rest.StatusError{Status: *status}); !rest.IsGone(err) {
+					return false, err
+				}
+				return false, nil
+			case watch.Deleted:
+				return false, fmt.Errorf("%s was deleted during the rollout", key)
+			case watch.Added, watch.Modified:
+				obj, ok := event.Object.(*resource.Unstructured)
+				if !ok {
+					continue
+				}
+				if done, err := check(obj); done || err != nil {
+					return done, err
+				}
+			}
+		}
+	}
+}
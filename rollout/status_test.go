@@ -0,0 +1,156 @@
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-client-go/client"
+	"k8s-client-go/dynamic"
+	"k8s-client-go/fakeserver"
+	"k8s-client-go/resource"
+)
+
+func newObject(kind string, generation int64, spec, status map[string]interface{}) *resource.Unstructured {
+	obj := resource.NewUnstructured("apps/v1", kind)
+	obj.SetName("web")
+	resource.SetNestedField(obj.Object, generation, "metadata", "generation")
+	obj.Object["spec"] = spec
+	obj.Object["status"] = status
+	return obj
+}
+
+func TestDeploymentStatus(t *testing.T) {
+	spec := map[string]interface{}{"replicas": int64(3)}
+	tests := []struct {
+		status  map[string]interface{}
+		message string
+		done    bool
+	}{
+		{map[string]interface{}{"observedGeneration": int64(1)}, "Waiting for deployment spec update to be observed...\n", false},
+		{map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(1)},
+			"Waiting for deployment \"web\" rollout to finish: 1 out of 3 new replicas have been updated...\n", false},
+		{map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(4), "updatedReplicas": int64(3)},
+			"Waiting for deployment \"web\" rollout to finish: 1 old replicas are pending termination...\n", false},
+		{map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(2)},
+			"Waiting for deployment \"web\" rollout to finish: 2 of 3 updated replicas are available...\n", false},
+		{map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			"deployment \"web\" successfully rolled out\n", true},
+	}
+	for i, test := range tests {
+		message, done, err := DeploymentStatus(newObject("Deployment", 2, spec, test.status), 0)
+		if err != nil || message != test.message || done != test.done {
+			t.Errorf("%d: unexpected status %q %v %v", i, message, done, err)
+		}
+	}
+
+	exceeded := newObject("Deployment", 1, spec, map[string]interface{}{
+		"observedGeneration": int64(1),
+		"conditions":         []interface{}{map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"}},
+	})
+	if _, _, err := DeploymentStatus(exceeded, 0); err == nil || !strings.Contains(err.Error(), "exceeded its progress deadline") {
+		t.Fatalf("expected progress deadline error, got %v", err)
+	}
+	exceeded.SetAnnotations(map[string]string{RevisionAnnotation: "2"})
+	if _, _, err := DeploymentStatus(exceeded, 3); err == nil || !strings.Contains(err.Error(), "desired revision (3)") {
+		t.Fatalf("expected revision error, got %v", err)
+	}
+}
+
+func TestStatefulSetAndDaemonSetStatus(t *testing.T) {
+	partitioned := map[string]interface{}{
+		"replicas":       int64(3),
+		"updateStrategy": map[string]interface{}{"type": "RollingUpdate", "rollingUpdate": map[string]interface{}{"partition": int64(1)}},
+	}
+	tests := []struct {
+		obj     *resource.Unstructured
+		viewer  StatusViewerFunc
+		message string
+		done    bool
+	}{
+		{newObject("StatefulSet", 1, map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{}), StatefulSetStatus,
+			"Waiting for statefulset spec update to be observed...\n", false},
+		{newObject("StatefulSet", 1, map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(1)}), StatefulSetStatus,
+			"Waiting for 2 pods to be ready...\n", false},
+		{newObject("StatefulSet", 1, partitioned, map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(3), "updatedReplicas": int64(1)}), StatefulSetStatus,
+			"Waiting for partitioned roll out to finish: 1 out of 2 new pods have been updated...\n", false},
+		{newObject("StatefulSet", 1, map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(3),
+			"updatedReplicas": int64(2), "currentRevision": "web-1", "updateRevision": "web-2"}), StatefulSetStatus,
+			"waiting for statefulset rolling update to complete 2 pods at revision web-2...\n", false},
+		{newObject("StatefulSet", 1, map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(3),
+			"currentReplicas": int64(3), "currentRevision": "web-2", "updateRevision": "web-2"}), StatefulSetStatus,
+			"statefulset rolling update complete 3 pods at revision web-2...\n", true},
+		{newObject("DaemonSet", 1, map[string]interface{}{}, map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(2)}), DaemonSetStatus,
+			"Waiting for daemon set \"web\" rollout to finish: 2 out of 3 new pods have been updated...\n", false},
+		{newObject("DaemonSet", 1, map[string]interface{}{}, map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3),
+			"updatedNumberScheduled": int64(3), "numberAvailable": int64(3)}), DaemonSetStatus,
+			"daemon set \"web\" successfully rolled out\n", true},
+	}
+	for i, test := range tests {
+		message, done, err := test.viewer(test.obj, 0)
+		if err != nil || message != test.message || done != test.done {
+			t.Errorf("%d: unexpected status %q %v %v", i, message, done, err)
+		}
+	}
+
+	onDelete := newObject("DaemonSet", 1, map[string]interface{}{"updateStrategy": map[string]interface{}{"type": "OnDelete"}}, nil)
+	if _, _, err := DaemonSetStatus(onDelete, 0); err == nil {
+		t.Fatal("expected error for OnDelete strategy")
+	}
+	if _, err := StatusViewerFor("Pod"); err == nil {
+		t.Fatal("expected error for unsupported kind")
+	}
+}
+
+func TestWaitForRollout(t *testing.T) {
+	s := fakeserver.NewServer()
+	defer s.Close()
+	c, err := client.NewClientForConfig(s.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, _ := dynamic.NewForConfig(s.Config())
+	ctx := context.Background()
+	deployments := d.Resource(resource.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("default")
+	if _, err := deployments.Create(ctx, newObject("Deployment", 0, map[string]interface{}{"replicas": int64(2)}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟控制器逐步更新status
+	go func() {
+		for _, status := range []map[string]interface{}{
+			{"observedGeneration": int64(1), "replicas": int64(2), "updatedReplicas": int64(1)},
+			{"observedGeneration": int64(1), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(1)},
+			{"observedGeneration": int64(1), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)},
+		} {
+			time.Sleep(20 * time.Millisecond)
+			obj, err := deployments.Get(ctx, "web")
+			if err != nil {
+				return
+			}
+			obj.Object["status"] = status
+			deployments.UpdateStatus(ctx, obj)
+		}
+	}()
+
+	out := &bytes.Buffer{}
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := WaitForRollout(waitCtx, c, "Deployment", "default", "web", 0, out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(out.String(), "deployment \"web\" successfully rolled out\n") || strings.Count(out.String(), "\n") < 2 {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	// status未更新时等到超时返回错误
+	deployments.Delete(ctx, "web", nil)
+	deployments.Create(ctx, newObject("Deployment", 0, map[string]interface{}{"replicas": int64(2)}, nil))
+	waitCtx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := WaitForRollout(waitCtx, c, "Deployment", "default", "web", 0, nil); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
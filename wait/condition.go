@@ -0,0 +1,216 @@
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s-client-go/resource"
+)
+
+// 判断对象是否满足条件，对象不存在或已被删除时obj为nil
+type Condition func(obj *resource.Unstructured) (bool, error)
+
+// status.conditions中类型为conditionType的条件状态为status，status为空时为True，类型不区分大小写
+func ForCondition(conditionType, status string) Condition {
+	if status == "" {
+		status = "True"
+	}
+	return func(obj *resource.Unstructured) (bool, error) {
+		if obj == nil {
+			return false, nil
+		}
+		for _, cond := range conditions(obj) {
+			if strings.EqualFold(cond.Type, conditionType) {
+				return cond.Status == status, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// 与pod的status.conditions结构相同，Job、Deployment和Node等类型的条件也按此解析
+func conditions(obj *resource.Unstructured) []resource.PodCondition {
+	status, ok := obj.Object["status"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	parsed := struct {
+		Conditions []resource.PodCondition
+	}{}
+	resource.FromMap(status, &parsed)
+	return parsed.Conditions
+}
+
+// 对象被删除
+func ForDelete() Condition {
+	return func(obj *resource.Unstructured) (bool, error) {
+		return obj == nil, nil
+	}
+}
+
+// path指定的字段的值等于value，path的格式如 {.status.phase}，支持 [n] 下标和 [?(@.type=="Ready")] 过滤
+// value为空时只要求字段存在
+func ForJSONPath(path, value string) (Condition, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return func(obj *resource.Unstructured) (bool, error) {
+		if obj == nil {
+			return false, nil
+		}
+		v, found := evaluate(obj.Object, steps)
+		if !found {
+			return false, nil
+		}
+		return value == "" || fmt.Sprint(v) == value, nil
+	}, nil
+}
+
+// 常用的条件
+var (
+	PodReady     = ForCondition("Ready", "True")
+	JobComplete  = ForCondition("Complete", "True")
+	JobFailed    = ForCondition("Failed", "True")
+	PVCBound     = mustJSONPath("{.status.phase}", "Bound")
+	PodSucceeded = mustJSONPath("{.status.phase}", "Succeeded")
+)
+
+func mustJSONPath(path, value string) Condition {
+	cond, err := ForJSONPath(path, value)
+	if err != nil {
+		panic(err)
+	}
+	return cond
+}
+
+// 解析kubectl wait --for的格式：delete、condition=Ready、condition=Ready=False、jsonpath={.status.phase}=Running
+func ParseCondition(s string) (Condition, error) {
+	switch {
+	case strings.ToLower(s) == "delete":
+		return ForDelete(), nil
+	case strings.HasPrefix(s, "condition="):
+		parts := strings.SplitN(strings.TrimPrefix(s, "condition="), "=", 2)
+		if parts[0] == "" {
+			return nil, fmt.Errorf("invalid condition %q", s)
+		}
+		status := ""
+		if len(parts) == 2 {
+			status = parts[1]
+		}
+		return ForCondition(parts[0], status), nil
+	case strings.HasPrefix(s, "jsonpath="):
+		expr := strings.TrimPrefix(s, "jsonpath=")
+		path, value := expr, ""
+		if i := strings.LastIndex(expr, "}="); i >= 0 {
+			path, value = expr[:i+1], expr[i+2:]
+		}
+		return ForJSONPath(path, value)
+	}
+	return nil, fmt.Errorf("unrecognized condition: %q", s)
+}
+
+// 路径中的一段
+type step struct {
+	field       string
+	index       int // 小于0时不取下标
+	filterKey   string
+	filterValue string
+}
+
+func parsePath(path string) ([]step, error) {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		if !strings.HasSuffix(path, "}") {
+			return nil, fmt.Errorf("unclosed jsonpath %q", path)
+		}
+		path = path[1 : len(path)-1]
+	}
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return nil, errors.New("jsonpath is empty")
+	}
+	var steps []step
+	for path != "" {
+		s := step{index: -1}
+		end := strings.IndexAny(path, ".[")
+		if end < 0 {
+			end = len(path)
+		}
+		s.field, path = path[:end], path[end:]
+		if strings.HasPrefix(path, "[") {
+			closing := strings.Index(path, "]")
+			if closing < 0 {
+				return nil, fmt.Errorf("unclosed bracket in jsonpath")
+			}
+			if err := parseBracket(path[1:closing], &s); err != nil {
+				return nil, err
+			}
+			path = path[closing+1:]
+		}
+		path = strings.TrimPrefix(path, ".")
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// 下标或 ?(@.key=="value") 形式的过滤
+func parseBracket(expr string, s *step) error {
+	if strings.HasPrefix(expr, "?(@.") && strings.HasSuffix(expr, ")") {
+		parts := strings.SplitN(expr[4:len(expr)-1], "==", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("unsupported filter %q", expr)
+		}
+		s.filterKey = strings.TrimSpace(parts[0])
+		s.filterValue = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		return nil
+	}
+	index, err := strconv.Atoi(expr)
+	if err != nil || index < 0 {
+		return fmt.Errorf("invalid index %q", expr)
+	}
+	s.index = index
+	return nil
+}
+
+func evaluate(obj interface{}, steps []step) (interface{}, bool) {
+	current := obj
+	for _, s := range steps {
+		if s.field != "" {
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if current, ok = m[s.field]; !ok {
+				return nil, false
+			}
+		}
+		if s.index < 0 && s.filterKey == "" {
+			continue
+		}
+		list, ok := current.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		if s.filterKey == "" {
+			if s.index >= len(list) {
+				return nil, false
+			}
+			current = list[s.index]
+			continue
+		}
+		found := false
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok && fmt.Sprint(m[s.filterKey]) == s.filterValue {
+				current, found = item, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,97 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-client-go/client"
+	"k8s-client-go/resource"
+	"k8s-client-go/rest"
+	"k8s-client-go/watch"
+)
+
+// 监听obj直到满足condition，超时由ctx控制，返回最后一次获取到的对象，对象被删除时为nil
+// obj只需设置apiVersion、kind、namespace和name；对象尚不存在时等待其被创建
+func Wait(ctx context.Context, c client.Interface, obj interface{}, condition Condition) (*resource.Unstructured, error) {
+	key, err := resource.GetObjectKey(obj)
+	if err != nil {
+		return nil, err
+	}
+	if key.Name == "" {
+		return nil, fmt.Errorf("name is required to wait for %s", key.Kind)
+	}
+	return WaitForKey(ctx, c, key, condition)
+}
+
+func WaitForKey(ctx context.Context, c client.Interface, key resource.ObjectKey, condition Condition) (*resource.Unstructured, error) {
+	if key.Namespace == "" {
+		key.Namespace = "default"
+	}
+	for {
+		current := &resource.Unstructured{}
+		resourceVersion := ""
+		if _, err := c.Get(ctx, key, current); err != nil {
+			if !rest.IsNotFound(err) {
+				return nil, err
+			}
+			current = nil
+		} else {
+			resourceVersion = current.GetResourceVersion()
+		}
+		if ok, err := condition(current); ok || err != nil {
+			return current, err
+		}
+
+		w, err := c.Watch(ctx, key.ApiVersion, key.Kind, client.ListOptions{
+			Namespace:       key.Namespace,
+			FieldSelector:   "metadata.name=" + key.Name,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		current, done, err := watchUntil(ctx, w, key, current, condition)
+		w.Stop()
+		if done || err != nil {
+			return current, err
+		}
+	}
+}
+
+// 返回的done为false且没有错误时需重新获取对象后继续监听
+func watchUntil(ctx context.Context, w watch.Interface, key resource.ObjectKey, current *resource.Unstructured, condition Condition) (*resource.Unstructured, bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return current, false, fmt.Errorf("timed out waiting for the condition on %s: %v", key, ctx.Err())
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return current, false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				status, _ := event.Object.(*resource.Status)
+				if status == nil {
+					return current, false, fmt.Errorf("unexpected watch error %v", event.Object)
+				}
+				if err := (&rest.StatusError{Status: *status}); !rest.IsGone(err) {
+					return current, false, err
+				}
+				return current, false, nil
+			case watch.Added, watch.Modified:
+				obj, ok := event.Object.(*resource.Unstructured)
+				if !ok {
+					continue
+				}
+				current = obj
+			case watch.Deleted:
+				current = nil
+			default:
+				continue
+			}
+			if ok, err := condition(current); ok || err != nil {
+				return current, true, err
+			}
+		}
+	}
+}
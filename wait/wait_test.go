@@ -0,0 +1,95 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s-client-go/client/fake"
+	"k8s-client-go/resource"
+)
+
+func newPod(phase string, conditions ...map[string]interface{}) *resource.Unstructured {
+	pod := resource.NewUnstructured("v1", resource.RESOURCE_POD)
+	pod.SetName("web")
+	pod.SetNamespace("default")
+	status := map[string]interface{}{"phase": phase}
+	if len(conditions) > 0 {
+		list := make([]interface{}, len(conditions))
+		for i, c := range conditions {
+			list[i] = c
+		}
+		status["conditions"] = list
+	}
+	pod.Object["status"] = status
+	return pod
+}
+
+func TestConditions(t *testing.T) {
+	ready := newPod("Running",
+		map[string]interface{}{"type": "Initialized", "status": "True"},
+		map[string]interface{}{"type": "Ready", "status": "True", "lastTransitionTime": "2020-01-01T00:00:00Z"})
+	notReady := newPod("Pending", map[string]interface{}{"type": "Ready", "status": "False"})
+
+	tests := []struct {
+		condition string
+		obj       *resource.Unstructured
+		expected  bool
+	}{
+		{"condition=Ready", ready, true},
+		{"condition=ready", ready, true},
+		{"condition=Ready", notReady, false},
+		{"condition=Ready=False", notReady, true},
+		{"condition=Ready", nil, false},
+		{"delete", nil, true},
+		{"delete", ready, false},
+		{"jsonpath={.status.phase}=Running", ready, true},
+		{"jsonpath={.status.phase}=Running", notReady, false},
+		{`jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, ready, true},
+		{"jsonpath={.status.conditions[1].type}=Ready", ready, true},
+		{"jsonpath={.status.conditions[5].type}=Ready", ready, false},
+		{"jsonpath={.status.podIP}", ready, false},
+		{"jsonpath={.status.phase}", ready, true},
+	}
+	for _, test := range tests {
+		condition, err := ParseCondition(test.condition)
+		if err != nil {
+			t.Fatalf("%s: %v", test.condition, err)
+		}
+		if ok, err := condition(test.obj); err != nil || ok != test.expected {
+			t.Errorf("%s: expected %v, got %v %v", test.condition, test.expected, ok, err)
+		}
+	}
+	for _, invalid := range []string{"ready", "condition=", "jsonpath={.status.phase", "jsonpath={.status.conditions[x]}=1"} {
+		if _, err := ParseCondition(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	c := fake.NewSimpleClient(newPod("Pending"))
+	ctx := context.Background()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		c.Update(ctx, newPod("Running", map[string]interface{}{"type": "Ready", "status": "True"}))
+		time.Sleep(20 * time.Millisecond)
+		c.Delete(ctx, resource.ObjectKey{ApiVersion: "v1", Kind: resource.RESOURCE_POD, Namespace: "default", Name: "web"}, nil)
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	pod, err := Wait(waitCtx, c, newPod(""), PodReady)
+	if err != nil || pod.GetName() != "web" {
+		t.Fatalf("unexpected result %v, err %v", pod, err)
+	}
+	if pod, err := Wait(waitCtx, c, newPod(""), ForDelete()); err != nil || pod != nil {
+		t.Fatalf("unexpected result %v, err %v", pod, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := Wait(timeoutCtx, c, newPod(""), PodReady); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
@@ -0,0 +1,59 @@
+package watch
+
+import "sync"
+
+// 由调用方手动发送事件的watch，用于测试
+// 通道默认无缓冲，发送会阻塞直到事件被接收
+type FakeWatcher struct {
+	result  chan Event
+	stopped bool
+	lock    sync.Mutex
+}
+
+func NewFake() *FakeWatcher {
+	return &FakeWatcher{result: make(chan Event)}
+}
+
+func NewFakeWithChanSize(size int) *FakeWatcher {
+	return &FakeWatcher{result: make(chan Event, size)}
+}
+
+func (f *FakeWatcher) Stop() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.stopped {
+		close(f.result)
+		f.stopped = true
+	}
+}
+
+func (f *FakeWatcher) IsStopped() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.stopped
+}
+
+func (f *FakeWatcher) ResultChan() <-chan Event {
+	return f.result
+}
+
+func (f *FakeWatcher) Add(obj interface{}) {
+	f.Action(Added, obj)
+}
+
+func (f *FakeWatcher) Modify(obj interface{}) {
+	f.Action(Modified, obj)
+}
+
+func (f *FakeWatcher) Delete(obj interface{}) {
+	f.Action(Deleted, obj)
+}
+
+func (f *FakeWatcher) Error(obj interface{}) {
+	f.Action(Error, obj)
+}
+
+// 发送任意类型的事件，Stop之后调用会panic
+func (f *FakeWatcher) Action(action EventType, obj interface{}) {
+	f.result <- Event{Type: action, Object: obj}
+}
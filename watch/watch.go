@@ -0,0 +1,126 @@
+package watch
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"k8s-client-go/resource"
+)
+
+// 事件类型
+type EventType string
+
+const (
+	Added    EventType = "ADDED"
+	Modified EventType = "MODIFIED"
+	Deleted  EventType = "DELETED"
+	Bookmark EventType = "BOOKMARK"
+	Error    EventType = "ERROR"
+)
+
+// 事件，Error类型的Object为*resource.Status
+type Event struct {
+	Type   EventType
+	Object interface{}
+}
+
+type Interface interface {
+	// 停止监听并关闭ResultChan
+	Stop()
+	// 服务端断开或出错时通道会被关闭
+	ResultChan() <-chan Event
+}
+
+// 解码事件中的对象
+type Decoder func(eventType EventType, raw json.RawMessage) (interface{}, error)
+
+// 从apiserver的watch响应中逐个读取事件，每个事件为 {"type": "...", "object": {...}}
+type StreamWatcher struct {
+	body   io.ReadCloser
+	decode Decoder
+	result chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewStreamWatcher(body io.ReadCloser, decode Decoder) *StreamWatcher {
+	w := &StreamWatcher{
+		body:   body,
+		decode: decode,
+		result: make(chan Event),
+		done:   make(chan struct{}),
+	}
+	go w.receive()
+	return w
+}
+
+func (w *StreamWatcher) ResultChan() <-chan Event {
+	return w.result
+}
+
+func (w *StreamWatcher) Stop() {
+	w.once.Do(func() {
+		close(w.done)
+		w.body.Close()
+	})
+}
+
+func (w *StreamWatcher) stopped() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *StreamWatcher) receive() {
+	defer close(w.result)
+	defer w.Stop()
+	decoder := json.NewDecoder(w.body)
+	for {
+		var frame struct {
+			Type   EventType
+			Object json.RawMessage
+		}
+		if err := decoder.Decode(&frame); err != nil {
+			// 主动停止或连接正常结束时不发送错误
+			if w.stopped() || err == io.EOF {
+				return
+			}
+			w.send(errorEvent(err))
+			return
+		}
+		if frame.Type == Error {
+			status := &resource.Status{}
+			if err := json.Unmarshal(frame.Object, status); err != nil {
+				status = &resource.Status{Status: "Failure", Message: string(frame.Object)}
+			}
+			w.send(Event{Type: Error, Object: status})
+			continue
+		}
+		obj, err := w.decode(frame.Type, frame.Object)
+		if err != nil {
+			w.send(errorEvent(err))
+			return
+		}
+		if !w.send(Event{Type: frame.Type, Object: obj}) {
+			return
+		}
+	}
+}
+
+// 调用方停止后不再阻塞发送
+func (w *StreamWatcher) send(event Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+func errorEvent(err error) Event {
+	return Event{Type: Error, Object: &resource.Status{Status: "Failure", Message: err.Error()}}
+}
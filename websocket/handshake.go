@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// 建立WebSocket连接，protocols为按优先级排列的子协议，服务端须从中选择一个
+// 通过transport发送升级请求，可以直接使用http.Client的Transport以沿用TLS和代理设置
+// 升级失败时返回握手响应，非101的响应体由调用方处理
+func Dial(ctx context.Context, transport http.RoundTripper, u *url.URL, header http.Header, protocols []string) (*Conn, *http.Response, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	challenge := base64.StdEncoding.EncodeToString(key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = append([]string{}, v...)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", challenge)
+	for _, protocol := range protocols {
+		req.Header.Add("Sec-WebSocket-Protocol", protocol)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, resp, fmt.Errorf("websocket: unexpected status %s", resp.Status)
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, resp, errors.New("websocket: transport does not support protocol upgrade")
+	}
+	if !headerContains(resp.Header, "Upgrade", "websocket") || resp.Header.Get("Sec-WebSocket-Accept") != computeAcceptKey(challenge) {
+		rwc.Close()
+		return nil, resp, errors.New("websocket: invalid handshake response")
+	}
+	subprotocol := resp.Header.Get("Sec-WebSocket-Protocol")
+	if len(protocols) > 0 && !contains(protocols, subprotocol) {
+		rwc.Close()
+		return nil, resp, fmt.Errorf("websocket: server selected unsupported subprotocol %q", subprotocol)
+	}
+	return newConn(rwc, nil, true, subprotocol), resp, nil
+}
+
+// 服务端处理升级请求，从客户端请求的子协议中选择protocols里第一个匹配的
+// 失败时已向客户端返回错误响应
+func Upgrade(w http.ResponseWriter, r *http.Request, protocols []string) (*Conn, error) {
+	if r.Method != http.MethodGet || !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, errors.New("websocket: not an upgrade request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, errors.New("websocket: unsupported version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("websocket: missing key")
+	}
+	subprotocol := ""
+	if len(protocols) > 0 {
+		requested := splitProtocols(r.Header)
+		for _, p := range protocols {
+			if contains(requested, p) {
+				subprotocol = p
+				break
+			}
+		}
+		if subprotocol == "" {
+			http.Error(w, "no supported subprotocol", http.StatusBadRequest)
+			return nil, errors.New("websocket: no supported subprotocol")
+		}
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + computeAcceptKey(key) + "\r\n"
+	if subprotocol != "" {
+		response += "Sec-WebSocket-Protocol: " + subprotocol + "\r\n"
+	}
+	if _, err := conn.Write([]byte(response + "\r\n")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return newConn(conn, rw.Reader, false, subprotocol), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 消息类型
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+
+	continuationFrame = 0
+)
+
+// 单个消息的最大长度
+const maxMessageSize = 32 << 20
+
+// 握手时计算Sec-WebSocket-Accept使用的GUID
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var ErrMessageTooLarge = errors.New("websocket: message too large")
+
+// 一个WebSocket连接，客户端发送的帧需要掩码，服务端发送的帧不需要
+// ReadMessage只能在一个goroutine中调用，WriteMessage可以并发调用
+type Conn struct {
+	rwc         io.ReadWriteCloser
+	reader      *bufio.Reader
+	isClient    bool
+	subprotocol string
+
+	writeLock sync.Mutex
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newConn(rwc io.ReadWriteCloser, reader *bufio.Reader, isClient bool, subprotocol string) *Conn {
+	if reader == nil {
+		reader = bufio.NewReader(rwc)
+	}
+	return &Conn{rwc: rwc, reader: reader, isClient: isClient, subprotocol: subprotocol}
+}
+
+// 握手时协商的子协议
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// 读取一个完整的数据消息，自动回复ping，收到close时返回io.EOF
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	messageType = -1
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch opcode {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			c.writeFrame(CloseMessage, payload)
+			return 0, nil, io.EOF
+		case continuationFrame:
+			if messageType < 0 {
+				return 0, nil, errors.New("websocket: unexpected continuation frame")
+			}
+		case TextMessage, BinaryMessage:
+			if messageType >= 0 {
+				return 0, nil, errors.New("websocket: expected continuation frame")
+			}
+			messageType = opcode
+		default:
+			return 0, nil, fmt.Errorf("websocket: unknown opcode %d", opcode)
+		}
+		if len(data)+len(payload) > maxMessageSize {
+			return 0, nil, ErrMessageTooLarge
+		}
+		data = append(data, payload...)
+		if fin {
+			return messageType, data, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+	if length > maxMessageSize {
+		return false, 0, nil, ErrMessageTooLarge
+	}
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err := io.ReadFull(c.reader, mask); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, opcode, payload, nil
+}
+
+// 以单个帧发送消息
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	return c.writeFrame(messageType, data)
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	frame := []byte{0x80 | byte(opcode), 0}
+	length := len(payload)
+	switch {
+	case length < 126:
+		frame[1] = byte(length)
+	case length <= 0xffff:
+		frame[1] = 126
+		frame = append(frame, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame[1] = 127
+		frame = append(frame, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+	if c.isClient {
+		frame[1] |= 0x80
+		mask := make([]byte, 4)
+		if _, err := rand.Read(mask); err != nil {
+			return err
+		}
+		frame = append(frame, mask...)
+		masked := make([]byte, length)
+		copy(masked, payload)
+		maskBytes(mask, masked)
+		payload = masked
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if _, err := c.rwc.Write(append(frame, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 发送close帧并关闭连接，可以多次调用
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.writeFrame(CloseMessage, []byte{0x03, 0xe8}) // 1000 正常关闭
+		c.closeErr = c.rwc.Close()
+	})
+	return c.closeErr
+}
+
+func maskBytes(mask, data []byte) {
+	for i := range data {
+		data[i] ^= mask[i%4]
+	}
+}
+
+func computeAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContains(header http.Header, name, value string) bool {
+	for _, v := range header[http.CanonicalHeaderKey(name)] {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitProtocols(header http.Header) []string {
+	var protocols []string
+	for _, v := range header[http.CanonicalHeaderKey("Sec-WebSocket-Protocol")] {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				protocols = append(protocols, p)
+			}
+		}
+	}
+	return protocols
+}
@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDialAndUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, []string{"v2", "v1"})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// 先发送ping，客户端读取时应自动回复
+		conn.WriteMessage(PingMessage, []byte("ping"))
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(messageType, data)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	conn, _, err := Dial(context.Background(), nil, u, nil, []string{"v1", "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.Subprotocol() != "v2" {
+		t.Fatalf("unexpected subprotocol %q", conn.Subprotocol())
+	}
+	for _, size := range []int{0, 125, 126, 70000} {
+		data := bytes.Repeat([]byte{'x'}, size)
+		if err := conn.WriteMessage(BinaryMessage, data); err != nil {
+			t.Fatal(err)
+		}
+		messageType, echoed, err := conn.ReadMessage()
+		if err != nil || messageType != BinaryMessage || !bytes.Equal(echoed, data) {
+			t.Fatalf("unexpected echo of %d bytes: %d %d %v", size, messageType, len(echoed), err)
+		}
+	}
+	conn.Close()
+
+	if _, resp, err := Dial(context.Background(), nil, u, nil, []string{"v3"}); err == nil || resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected handshake failure, got %v", err)
+	}
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Fatalf("expected closed connection, got %v", err)
+	}
+}